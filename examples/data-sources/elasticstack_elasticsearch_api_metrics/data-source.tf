@@ -0,0 +1,15 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+// read once all the other resources of the module are applied
+data "elasticstack_elasticsearch_api_metrics" "apply" {
+  depends_on = [
+    elasticstack_elasticsearch_index_lifecycle.logs,
+    elasticstack_elasticsearch_index_template.logs,
+  ]
+}
+
+output "slowest_endpoints" {
+  value = slice(data.elasticstack_elasticsearch_api_metrics.apply.endpoints, 0, min(5, length(data.elasticstack_elasticsearch_api_metrics.apply.endpoints)))
+}
@@ -0,0 +1,41 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "ec_deployment" "example" {
+  name                   = "example"
+  region                 = "us-east-1"
+  version                = "8.13.0"
+  deployment_template_id = "aws-io-optimized-v2"
+
+  elasticsearch = {
+    hot = {
+      autoscaling = {}
+    }
+  }
+}
+
+// the data source doesn't connect to Elasticsearch, so the default provider is enough
+data "elasticstack_elasticsearch_cloud_connection" "example" {
+  cloud_id = ec_deployment.example.elasticsearch.cloud_id
+  username = ec_deployment.example.elasticsearch_username
+  password = ec_deployment.example.elasticsearch_password
+}
+
+provider "elasticstack" {
+  alias = "cloud"
+
+  dynamic "elasticsearch" {
+    for_each = data.elasticstack_elasticsearch_cloud_connection.example.elasticsearch
+    content {
+      endpoints = elasticsearch.value.endpoints
+      username  = elasticsearch.value.username
+      password  = elasticsearch.value.password
+    }
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "logs" {
+  provider = elasticstack.cloud
+  name     = "logs"
+}
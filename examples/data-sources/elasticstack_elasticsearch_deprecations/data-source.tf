@@ -0,0 +1,18 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_deprecations" "upgrade" {
+  categories = ["cluster_settings", "templates", "ml_settings"]
+
+  lifecycle {
+    postcondition {
+      condition     = self.critical_count == 0
+      error_message = "Resolve the critical deprecations before the upgrade: ${join(", ", [for d in self.deprecations : "${d.category} ${d.name}: ${d.message}" if d.level == "critical"])}"
+    }
+  }
+}
+
+output "warnings" {
+  value = [for d in data.elasticstack_elasticsearch_deprecations.upgrade.deprecations : d.message if d.level == "warning"]
+}
@@ -0,0 +1,12 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_import_blocks" "existing" {
+  resource_types = ["index_lifecycle", "index_template", "component_template"]
+}
+
+# terraform output -raw import_blocks > imports.tf
+output "import_blocks" {
+  value = data.elasticstack_elasticsearch_import_blocks.existing.hcl
+}
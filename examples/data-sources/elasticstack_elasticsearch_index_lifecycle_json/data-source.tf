@@ -0,0 +1,21 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_index_lifecycle_json" "logs" {
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  delete {
+    min_age = "30d"
+    delete {}
+  }
+}
+
+// e.g. to store the policy with another tool
+output "logs_policy" {
+  value = data.elasticstack_elasticsearch_index_lifecycle_json.logs.json
+}
@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_index_rollover_alias" "logs" {
+  alias = "my-logs"
+}
+
+output "logs_rollover_issues" {
+  value = data.elasticstack_elasticsearch_index_rollover_alias.logs.issues
+}
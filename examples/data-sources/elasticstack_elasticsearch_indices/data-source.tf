@@ -0,0 +1,18 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_indices" "logs" {
+  pattern = "logs-*"
+}
+
+resource "elasticstack_elasticsearch_index_settings" "logs" {
+  for_each = toset(data.elasticstack_elasticsearch_indices.logs.names)
+
+  index            = each.value
+  refresh_interval = "30s"
+}
+
+output "logs_size" {
+  value = sum(concat([0], [for i in data.elasticstack_elasticsearch_indices.logs.indices : i.store_size]))
+}
@@ -0,0 +1,24 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_license" "current" {}
+
+// the searchable snapshots require the enterprise license
+resource "elasticstack_elasticsearch_index_lifecycle" "archive" {
+  count = contains(["enterprise", "trial"], data.elasticstack_elasticsearch_license.current.type) ? 1 : 0
+  name  = "archive"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  frozen {
+    min_age = "30d"
+    searchable_snapshot {
+      snapshot_repository = "found-snapshots"
+    }
+  }
+}
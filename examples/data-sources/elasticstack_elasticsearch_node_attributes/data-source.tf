@@ -0,0 +1,28 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_node_attributes" "attrs" {}
+
+locals {
+  zones = one([for a in data.elasticstack_elasticsearch_node_attributes.attrs.attributes : a.values if a.name == "zone"])
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "warm_in_zone" {
+  name = "warm-in-zone"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  warm {
+    min_age = "7d"
+    allocate {
+      include = jsonencode({
+        zone = join(",", local.zones)
+      })
+    }
+  }
+}
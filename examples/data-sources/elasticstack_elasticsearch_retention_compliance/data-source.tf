@@ -0,0 +1,16 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+# fail the scheduled plan if any log index outlives its retention,
+# or declares a retention longer than a year
+data "elasticstack_elasticsearch_retention_compliance" "logs" {
+  index              = "logs-*"
+  max_retention      = "365d"
+  grace_period       = "2d"
+  fail_on_violations = true
+}
+
+output "indices_without_retention" {
+  value = data.elasticstack_elasticsearch_retention_compliance.logs.no_retention
+}
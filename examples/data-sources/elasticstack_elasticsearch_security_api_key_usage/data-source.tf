@@ -0,0 +1,15 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_api_key_usage" "stale" {
+  stale_after = "90d"
+
+  audit {
+    index = "logs-elasticsearch.audit-*"
+  }
+}
+
+output "stale_api_keys" {
+  value = data.elasticstack_elasticsearch_security_api_key_usage.stale.stale_ids
+}
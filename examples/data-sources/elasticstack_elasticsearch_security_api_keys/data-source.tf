@@ -0,0 +1,18 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_api_keys" "ci" {
+  name       = "ci-*"
+  realm_name = "native1"
+
+  metadata = {
+    team = "platform"
+  }
+}
+
+output "ci_api_keys" {
+  value = {
+    for k in data.elasticstack_elasticsearch_security_api_keys.ci.api_keys : k.id => k.expiration
+  }
+}
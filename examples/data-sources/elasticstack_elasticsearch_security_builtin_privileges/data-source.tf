@@ -0,0 +1,27 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_builtin_privileges" "privileges" {}
+
+resource "elasticstack_elasticsearch_security_role" "role" {
+  name    = "logs_reader"
+  cluster = ["monitor", "read_ilm"]
+
+  indices {
+    names      = ["logs-*"]
+    privileges = ["read", "view_index_metadata"]
+  }
+
+  // fail at plan time if the cluster doesn't support any of the privileges
+  lifecycle {
+    precondition {
+      condition     = length(setsubtract(["monitor", "read_ilm"], data.elasticstack_elasticsearch_security_builtin_privileges.privileges.cluster)) == 0
+      error_message = "The cluster doesn't support all the cluster privileges of the role."
+    }
+    precondition {
+      condition     = length(setsubtract(["read", "view_index_metadata"], data.elasticstack_elasticsearch_security_builtin_privileges.privileges.index)) == 0
+      error_message = "The cluster doesn't support all the index privileges of the role."
+    }
+  }
+}
@@ -0,0 +1,18 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_descriptor" "logs_reader" {
+  cluster = ["monitor"]
+
+  indices {
+    names      = ["logs-*"]
+    privileges = ["read", "view_index_metadata"]
+  }
+}
+
+output "role_descriptors" {
+  value = jsonencode({
+    logs_reader = jsondecode(data.elasticstack_elasticsearch_security_role_descriptor.logs_reader.json)
+  })
+}
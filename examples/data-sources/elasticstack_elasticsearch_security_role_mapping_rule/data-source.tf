@@ -0,0 +1,35 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "saml_realm" {
+  field {
+    name   = "realm.name"
+    values = ["saml1"]
+  }
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "admin_groups" {
+  field {
+    name   = "groups"
+    values = ["cn=admins,ou=groups,dc=example,dc=com", "cn=ops,ou=groups,dc=example,dc=com"]
+  }
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "not_contractors" {
+  except = jsonencode({
+    field = { "metadata.employee_type" = ["contractor"] }
+  })
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "saml_admins" {
+  all = [
+    data.elasticstack_elasticsearch_security_role_mapping_rule.saml_realm.json,
+    data.elasticstack_elasticsearch_security_role_mapping_rule.admin_groups.json,
+    data.elasticstack_elasticsearch_security_role_mapping_rule.not_contractors.json,
+  ]
+}
+
+output "rules" {
+  value = data.elasticstack_elasticsearch_security_role_mapping_rule.saml_admins.json
+}
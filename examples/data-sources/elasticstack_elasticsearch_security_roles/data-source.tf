@@ -0,0 +1,22 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_roles" "team" {
+  name_pattern = "team-*"
+}
+
+# Terraform 1.7 and later imports every matching role
+import {
+  for_each = { for role in data.elasticstack_elasticsearch_security_roles.team.roles : role.name => role }
+  to       = elasticstack_elasticsearch_security_role.team[each.key]
+  id       = each.value.import_id
+}
+
+resource "elasticstack_elasticsearch_security_role" "team" {
+  for_each = { for role in data.elasticstack_elasticsearch_security_roles.team.roles : role.name => jsondecode(role.json) }
+
+  name    = each.key
+  cluster = lookup(each.value, "cluster", [])
+  run_as  = lookup(each.value, "run_as", [])
+}
@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_user_profiles" "jacknich" {
+  name = "jack"
+}
+
+output "profiles" {
+  value = data.elasticstack_elasticsearch_security_user_profiles.jacknich.profiles
+}
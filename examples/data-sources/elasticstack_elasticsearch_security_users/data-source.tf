@@ -0,0 +1,25 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_users" "services" {
+  username_pattern = "svc-*"
+}
+
+# Terraform 1.7 and later imports every matching user
+import {
+  for_each = { for user in data.elasticstack_elasticsearch_security_users.services.users : user.username => user }
+  to       = elasticstack_elasticsearch_security_user.services[each.key]
+  id       = each.value.import_id
+}
+
+resource "elasticstack_elasticsearch_security_user" "services" {
+  for_each = { for user in data.elasticstack_elasticsearch_security_users.services.users : user.username => user }
+
+  username  = each.key
+  full_name = each.value.full_name
+  email     = each.value.email
+  roles     = each.value.roles
+  metadata  = each.value.metadata
+  enabled   = each.value.enabled
+}
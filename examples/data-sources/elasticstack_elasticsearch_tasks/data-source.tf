@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_tasks" "reindex" {
+  actions = ["*reindex"]
+}
+
+output "reindex_tasks" {
+  value = [for task in data.elasticstack_elasticsearch_tasks.reindex.tasks : "${task.task_id}: ${task.description} (${task.running_time})"]
+}
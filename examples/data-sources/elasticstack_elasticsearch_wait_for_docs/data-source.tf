@@ -0,0 +1,22 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+# wait until the agents start shipping the system logs
+data "elasticstack_elasticsearch_wait_for_docs" "system_logs" {
+  index = "logs-system.syslog-*"
+  query = jsonencode({
+    range = {
+      "@timestamp" = { gte = "now-15m" }
+    }
+  })
+  min_count = 10
+  timeout   = "15m"
+}
+
+resource "elasticstack_elasticsearch_index_template" "downstream" {
+  name           = "downstream"
+  index_patterns = ["downstream-*"]
+
+  depends_on = [data.elasticstack_elasticsearch_wait_for_docs.system_logs]
+}
@@ -0,0 +1,23 @@
+provider "elasticstack" {
+  elasticsearch {}
+
+  elasticsearch_connection_alias {
+    name             = "monitoring"
+    endpoints        = ["https://monitoring.example.com:9200"]
+    credentials_file = "/run/secrets/monitoring-elasticsearch.json"
+  }
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "metrics" {
+  name = "metrics"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  elasticsearch_connection {
+    alias = "monitoring"
+  }
+}
@@ -0,0 +1,6 @@
+provider "elasticstack" {
+  elasticsearch {
+    endpoints          = ["https://elasticsearch.example.com:9200"]
+    credential_process = ["vault", "read", "-format=json", "-field=data", "elasticsearch/creds/terraform"]
+  }
+}
@@ -0,0 +1,7 @@
+provider "elasticstack" {
+  elasticsearch {
+    endpoints = ["https://elasticsearch.example.com:9200"]
+    # the definitions read on every plan are appended to the file
+    drift_report_file = "${path.root}/drift-report.jsonl"
+  }
+}
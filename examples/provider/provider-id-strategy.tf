@@ -0,0 +1,7 @@
+provider "elasticstack" {
+  elasticsearch {
+    endpoints = ["https://elasticsearch.example.com:9200"]
+  }
+  # the IDs are kept when the cluster behind the endpoint is rebuilt
+  id_strategy = "name"
+}
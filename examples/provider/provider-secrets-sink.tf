@@ -0,0 +1,7 @@
+provider "elasticstack" {
+  elasticsearch {
+    endpoints = ["https://elasticsearch.example.com:9200"]
+    # e.g. the script storing the value with: vault kv put "secret/elasticsearch/${attribute}" value=-
+    secrets_sink = ["${path.module}/scripts/store-secret.sh"]
+  }
+}
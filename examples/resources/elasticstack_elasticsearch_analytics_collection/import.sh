@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_analytics_collection.website <cluster_uuid>/<collection_name>
@@ -0,0 +1,7 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_analytics_collection" "website" {
+  name = "website"
+}
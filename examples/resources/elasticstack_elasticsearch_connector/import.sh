@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_connector.drive <cluster_uuid>/<connector_id>
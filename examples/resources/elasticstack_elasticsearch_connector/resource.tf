@@ -0,0 +1,36 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+variable "drive_service_account" {
+  type      = string
+  sensitive = true
+}
+
+resource "elasticstack_elasticsearch_connector" "drive" {
+  connector_id = "company-drive"
+  name         = "Company drive"
+  index_name   = "search-company-drive"
+  service_type = "google_drive"
+  is_native    = true
+
+  scheduling {
+    full {
+      enabled  = true
+      interval = "0 0 2 * * ?"
+    }
+    access_control {
+      enabled  = true
+      interval = "0 0 * * * ?"
+    }
+  }
+
+  pipeline {
+    name             = "search-default-ingestion"
+    run_ml_inference = false
+  }
+
+  configuration = {
+    service_account_credentials = var.drive_service_account
+  }
+}
@@ -0,0 +1,18 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+variable "drive_service_account" {
+  type      = string
+  sensitive = true
+}
+
+resource "elasticstack_elasticsearch_connector_sync_job" "drive_initial_sync" {
+  connector_id = "company-drive"
+  job_type     = "full"
+
+  # start a new sync whenever the configuration of the connector changes
+  triggers = {
+    configuration = sha1(var.drive_service_account)
+  }
+}
@@ -0,0 +1,35 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+variable "logs_cluster_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "elasticstack_elasticsearch_cross_cluster_search" "logs" {
+  alias            = "logs-cluster"
+  mode             = "proxy"
+  proxy_address    = "logs.example.com:9443"
+  skip_unavailable = true
+
+  api_key {
+    name       = "logs-cluster-ccs"
+    expiration = "365d"
+
+    search {
+      names = ["logs-*"]
+    }
+  }
+
+  remote_elasticsearch_connection {
+    endpoints = ["https://logs.example.com:9200"]
+    username  = "elastic"
+    password  = var.logs_cluster_password
+  }
+}
+
+output "logs_cluster_credentials" {
+  value     = elasticstack_elasticsearch_cross_cluster_search.logs.encoded_api_key
+  sensitive = true
+}
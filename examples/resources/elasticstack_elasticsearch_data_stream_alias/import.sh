@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_data_stream_alias.logs <cluster_uuid>/<alias_name>
@@ -0,0 +1,10 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+# read from both the old and the new data stream, while writing only into the new one
+resource "elasticstack_elasticsearch_data_stream_alias" "logs" {
+  name              = "logs-app"
+  data_streams      = ["logs-app-v1", "logs-app-v2"]
+  write_data_stream = "logs-app-v2"
+}
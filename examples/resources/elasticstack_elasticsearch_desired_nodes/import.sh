@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_desired_nodes.deployment <cluster_uuid>/<history_id>
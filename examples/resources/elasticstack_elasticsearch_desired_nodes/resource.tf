@@ -0,0 +1,22 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_desired_nodes" "deployment" {
+  history_id = "deployment-1"
+
+  dynamic "node" {
+    for_each = ["instance-0", "instance-1", "instance-2"]
+    content {
+      settings = jsonencode({
+        node = {
+          name  = node.value
+          roles = ["data_hot", "master", "ingest"]
+        }
+      })
+      processors = 8
+      memory     = "64gb"
+      storage    = "1tb"
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_geoip_database.my_database <cluster_uuid>/<database_id>
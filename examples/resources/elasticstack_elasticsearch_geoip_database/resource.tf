@@ -0,0 +1,23 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_geoip_database" "domain" {
+  database_id = "maxmind-domain"
+  name        = "GeoIP2-Domain"
+  account_id  = "1234567"
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "domains" {
+  name = "domains"
+
+  processors = [
+    jsonencode({
+      geoip = {
+        field         = "source.ip"
+        target_field  = "source.domain"
+        database_file = "${elasticstack_elasticsearch_geoip_database.domain.name}.mmdb"
+      }
+    })
+  ]
+}
@@ -0,0 +1,42 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "articles" {
+  name = "articles"
+
+  analysis {
+    analyzer {
+      name        = "english_text"
+      tokenizer   = "standard"
+      filter      = ["lowercase", "english_stop", "english_stemmer"]
+      char_filter = ["html_strip"]
+    }
+
+    normalizer {
+      name   = "lowercase_keyword"
+      filter = ["lowercase", "asciifolding"]
+    }
+
+    filter {
+      name       = "english_stop"
+      type       = "stop"
+      parameters = jsonencode({ stopwords = "_english_" })
+    }
+
+    filter {
+      name       = "english_stemmer"
+      type       = "stemmer"
+      parameters = jsonencode({ language = "english" })
+    }
+  }
+
+  mappings = jsonencode({
+    properties = {
+      title = { type = "text", analyzer = "english_text" }
+      tags  = { type = "keyword", normalizer = "lowercase_keyword" }
+    }
+  })
+
+  allow_close = true
+}
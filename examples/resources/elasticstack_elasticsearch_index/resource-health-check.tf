@@ -0,0 +1,20 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "orders" {
+  name = "orders"
+
+  settings {
+    setting {
+      name  = "index.number_of_replicas"
+      value = "1"
+    }
+  }
+
+  // fail the apply when the replicas are not allocated within 2 minutes
+  health_check {
+    status  = "green"
+    timeout = "2m"
+  }
+}
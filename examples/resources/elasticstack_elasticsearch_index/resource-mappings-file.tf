@@ -0,0 +1,10 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "orders" {
+  name = "orders"
+
+  // the structure of the mappings is checked during the plan
+  mappings_file = "${path.module}/mappings.json"
+}
@@ -0,0 +1,27 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "events" {
+  name = "events"
+
+  sort_field                               = ["@timestamp", "tenant"]
+  sort_order                               = ["desc", "asc"]
+  routing_partition_size                   = 2
+  routing_allocation_total_shards_per_node = 2
+
+  mappings = jsonencode({
+    _routing = { required = true }
+    properties = {
+      "@timestamp" = { type = "date" }
+      tenant       = { type = "keyword" }
+    }
+  })
+
+  settings {
+    setting {
+      name  = "index.number_of_shards"
+      value = "4"
+    }
+  }
+}
@@ -0,0 +1,12 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+// policies.json maps the names of the policies to their definitions, e.g.
+// { "logs": { "phases": { ... } }, "metrics": { "policy": { "phases": { ... } } } }
+resource "elasticstack_elasticsearch_index_lifecycle" "policies" {
+  for_each = jsondecode(file("${path.module}/policies.json"))
+
+  name        = each.key
+  policy_json = jsonencode(each.value)
+}
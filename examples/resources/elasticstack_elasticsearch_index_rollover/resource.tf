@@ -0,0 +1,26 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "logs" {
+  name = "logs-app"
+
+  index_patterns = ["logs-app*"]
+
+  template {
+    settings = jsonencode({
+      number_of_shards = 2
+    })
+  }
+
+  data_stream {}
+}
+
+// apply the changes of the template to the write index of the data stream
+resource "elasticstack_elasticsearch_index_rollover" "logs" {
+  target = "logs-app"
+
+  triggers = {
+    template_settings = elasticstack_elasticsearch_index_template.logs.template[0].settings
+  }
+}
@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_index_settings.filebeat <cluster_uuid>/<index_name_or_pattern>
@@ -0,0 +1,13 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_settings" "filebeat" {
+  index              = "filebeat-*"
+  number_of_replicas = 1
+  refresh_interval   = "30s"
+
+  routing_allocation_require = jsonencode({
+    box_type = "hot"
+  })
+}
@@ -0,0 +1,7 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_license" "platinum" {
+  license = file("${path.module}/license.json")
+}
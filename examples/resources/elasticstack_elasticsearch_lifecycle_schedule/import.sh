@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_lifecycle_schedule.off_peak <cluster_uuid>/lifecycle-schedule
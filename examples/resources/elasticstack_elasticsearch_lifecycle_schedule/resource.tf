@@ -0,0 +1,12 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_lifecycle_schedule" "off_peak" {
+  // check for the ILM actions to run every 30 minutes
+  ilm_poll_interval = "30m"
+
+  // run the SLM retention every night at 1:30AM and limit it to 1 hour
+  slm_retention_schedule = "0 30 1 * * ?"
+  slm_retention_duration = "1h"
+}
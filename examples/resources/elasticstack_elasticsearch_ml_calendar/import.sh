@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_ml_calendar.maintenance <cluster_uuid>/<calendar_id>
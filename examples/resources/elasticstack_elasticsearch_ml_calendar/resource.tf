@@ -0,0 +1,9 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ml_calendar" "maintenance" {
+  calendar_id = "planned-maintenance"
+  description = "The planned deployments of the web shop"
+  job_ids     = ["web-shop-latency", "web-shop-errors"]
+}
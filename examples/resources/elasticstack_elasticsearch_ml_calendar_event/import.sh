@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_ml_calendar_event.release <cluster_uuid>/<calendar_id>:<event_id>
@@ -0,0 +1,15 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ml_calendar" "maintenance" {
+  calendar_id = "planned-maintenance"
+  job_ids     = ["web-shop-latency"]
+}
+
+resource "elasticstack_elasticsearch_ml_calendar_event" "release" {
+  calendar_id = elasticstack_elasticsearch_ml_calendar.maintenance.calendar_id
+  description = "Release 2.4 of the web shop"
+  start_time  = "2024-05-04T22:00:00Z"
+  end_time    = "2024-05-05T02:00:00Z"
+}
@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_node_shutdown.replace <cluster_uuid>/<node_id>
@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+# move the shards of the old node to its replacement, before the old node is removed
+resource "elasticstack_elasticsearch_node_shutdown" "replace" {
+  node_id          = "USpTGYaBSIKbgSUJR2Z9lg"
+  type             = "replace"
+  reason           = "Replacing the node with the bigger instance"
+  target_node_name = "instance-0000000002"
+}
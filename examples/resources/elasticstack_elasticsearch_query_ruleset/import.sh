@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_query_ruleset.products <cluster_uuid>/<ruleset_name>
@@ -0,0 +1,38 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_query_ruleset" "products" {
+  name = "products"
+
+  rule {
+    rule_id = "promote-laptops"
+    type    = "pinned"
+
+    criteria {
+      type     = "contains"
+      metadata = "user_query"
+      values   = ["laptop", "notebook"]
+    }
+
+    actions {
+      ids = ["laptop-1", "laptop-2"]
+    }
+  }
+
+  rule {
+    rule_id = "hide-discontinued"
+    type    = "exclude"
+
+    criteria {
+      type = "always"
+    }
+
+    actions {
+      docs {
+        index = "products"
+        id    = "discontinued-1"
+      }
+    }
+  }
+}
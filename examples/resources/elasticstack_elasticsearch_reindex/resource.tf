@@ -0,0 +1,40 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "logs_v2" {
+  name = "logs-v2"
+
+  mappings = jsonencode({
+    properties = {
+      message = { type = "text" }
+      level   = { type = "keyword" }
+    }
+  })
+}
+
+resource "elasticstack_elasticsearch_reindex" "logs_v2" {
+  source {
+    index = ["logs-v1"]
+    query = jsonencode({
+      range = { "@timestamp" = { gte = "now-30d" } }
+    })
+  }
+
+  dest {
+    index   = elasticstack_elasticsearch_index.logs_v2.name
+    op_type = "create"
+  }
+
+  script {
+    source = "ctx._source.level = ctx._source.remove('severity')"
+  }
+
+  conflicts = "proceed"
+  slices    = "auto"
+  timeout   = "2h"
+
+  triggers = {
+    mappings = elasticstack_elasticsearch_index.logs_v2.mappings
+  }
+}
@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_search_application.website <cluster_uuid>/<search_application_name>
@@ -0,0 +1,28 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_analytics_collection" "website" {
+  name = "website"
+}
+
+resource "elasticstack_elasticsearch_search_application" "website" {
+  name                      = "website"
+  indices                   = ["website-products", "website-articles"]
+  analytics_collection_name = elasticstack_elasticsearch_analytics_collection.website.name
+
+  template = jsonencode({
+    script = {
+      source = {
+        query = {
+          query_string = {
+            query = "{{query_string}}"
+          }
+        }
+      }
+      params = {
+        query_string = "*"
+      }
+    }
+  })
+}
@@ -0,0 +1,15 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+variable "s3_keystore_version" {
+  description = "Bumped whenever the S3 credentials are rotated in the keystores of the nodes"
+  type        = string
+}
+
+// reload the rotated S3 credentials, added with `bin/elasticsearch-keystore add s3.client.default.secret_key` on every node
+resource "elasticstack_elasticsearch_secure_settings_reload" "s3_credentials" {
+  triggers = {
+    keystore = var.s3_keystore_version
+  }
+}
@@ -0,0 +1,21 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+// invalidate the API keys leaked in the build logs
+resource "elasticstack_elasticsearch_security_api_key_invalidation" "leaked" {
+  ids = [
+    "VuaCfGcBCdbkQm-e5aOx",
+    "H3_AhoIBA9hmeQJdg7ij",
+  ]
+}
+
+// invalidate all the API keys of the CI jobs, again whenever the credentials are rotated
+resource "elasticstack_elasticsearch_security_api_key_invalidation" "ci" {
+  name     = "ci-*"
+  username = "ci"
+
+  triggers = {
+    rotation = "2024-01"
+  }
+}
@@ -0,0 +1,19 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+variable "kibana_system_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "elasticstack_elasticsearch_security_system_user" "kibana_system" {
+  username = "kibana_system"
+  password = var.kibana_system_password
+}
+
+// the built-in user which is not used in the deployment
+resource "elasticstack_elasticsearch_security_system_user" "beats_system" {
+  username = "beats_system"
+  enabled  = false
+}
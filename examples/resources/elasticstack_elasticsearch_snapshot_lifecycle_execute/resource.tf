@@ -0,0 +1,31 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_snapshot_repository" "repo" {
+  name = "my_snap_repo"
+
+  fs {
+    location = "/tmp/snapshots"
+  }
+}
+
+resource "elasticstack_elasticsearch_snapshot_lifecycle" "slm_policy" {
+  name = "my_slm_policy"
+
+  schedule      = "0 30 1 * * ?"
+  snapshot_name = "<daily-snap-{now/d}>"
+  repository    = elasticstack_elasticsearch_snapshot_repository.repo.name
+
+  expire_after = "30d"
+}
+
+// take a snapshot before the indices are changed, and clean up the expired snapshots
+resource "elasticstack_elasticsearch_snapshot_lifecycle_execute" "before_upgrade" {
+  policy            = elasticstack_elasticsearch_snapshot_lifecycle.slm_policy.name
+  execute_retention = true
+
+  triggers = {
+    version = "8.1.0"
+  }
+}
@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_synonym_rule.laptop <cluster_uuid>/<synonyms_set_name>:<rule_id>
@@ -0,0 +1,9 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_synonym_rule" "laptop" {
+  set_id   = "products"
+  rule_id  = "laptop"
+  synonyms = "laptop, notebook"
+}
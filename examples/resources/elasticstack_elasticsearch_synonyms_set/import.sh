@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_synonyms_set.products <cluster_uuid>/<synonyms_set_name>
@@ -0,0 +1,17 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_synonyms_set" "products" {
+  name = "products"
+
+  rule {
+    id       = "greetings"
+    synonyms = "hello, hi"
+  }
+
+  rule {
+    id       = "ipod"
+    synonyms = "i-pod, i pod => ipod"
+  }
+}
@@ -0,0 +1,20 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_reindex" "migration" {
+  source {
+    index = ["logs-old"]
+  }
+
+  dest {
+    index = "logs-new"
+  }
+
+  wait_for_completion = false
+}
+
+// cancel the reindex, e.g. once it turns out to overload the cluster
+resource "elasticstack_elasticsearch_task_cancel" "migration" {
+  task_id = elasticstack_elasticsearch_reindex.migration.task_id
+}
@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_voting_config_exclusions.decommission <cluster_uuid>/voting-config-exclusions
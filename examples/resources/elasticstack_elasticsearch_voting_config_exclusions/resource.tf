@@ -0,0 +1,9 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+# move the voting rights away from the master nodes being decommissioned
+resource "elasticstack_elasticsearch_voting_config_exclusions" "decommission" {
+  node_names = ["master-zone-c-0"]
+  timeout    = "1m"
+}
@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_watcher_settings.watcher <cluster_uuid>/watcher-settings
@@ -0,0 +1,17 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_watcher_settings" "watcher" {
+  // don't repeat the actions of the watches within 1 minute by default
+  default_throttle_period = "1m"
+
+  execution_scroll_size    = 100
+  execution_scroll_timeout = "1m"
+
+  // keep a single replica of the .watches index
+  watches_index {
+    auto_expand_replicas = "false"
+    number_of_replicas   = 1
+  }
+}
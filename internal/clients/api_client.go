@@ -1,18 +1,20 @@
 package clients
 
 import (
+	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"os"
 	"strings"
 
 	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -25,11 +27,15 @@ type CompositeId struct {
 func CompositeIdFromStr(id string) (*CompositeId, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	idParts := strings.Split(id, "/")
+	// the resource identifier alone is the ID when the resources are identified by their names
+	if len(idParts) == 1 && id != "" {
+		return &CompositeId{ResourceId: id}, diags
+	}
 	if len(idParts) != 2 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Wrong resource ID.",
-			Detail:   "Resource ID must have following format: <cluster_uuid>/<resource identifier>",
+			Detail:   "Resource ID must have following format: <cluster_uuid>/<resource identifier> or <resource identifier>",
 		})
 		return nil, diags
 	}
@@ -41,12 +47,26 @@ func CompositeIdFromStr(id string) (*CompositeId, diag.Diagnostics) {
 }
 
 func (c *CompositeId) String() string {
+	if c.ClusterId == "" {
+		return c.ResourceId
+	}
 	return fmt.Sprintf("%s/%s", c.ClusterId, c.ResourceId)
 }
 
 type ApiClient struct {
-	es      *elasticsearch.Client
-	version string
+	es            *elasticsearch.Client
+	version       string
+	debugRequests bool
+	secretsSink   SecretsSink
+	metrics       *apiMetrics
+	driftReport   *driftReport
+	idStrategy    string
+	// the X-Opaque-Id of all the requests of the run, shown in the audit, slow and deprecation logs of Elasticsearch
+	opaqueId string
+	// the named connections configured in the provider, referenced by the connection blocks of the resources
+	aliases map[string]*ApiClient
+	// whether the security features are available on the cluster of the connection
+	security securityAvailability
 }
 
 func NewApiClientFunc(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -54,6 +74,18 @@ func NewApiClientFunc(version string, p *schema.Provider) func(context.Context,
 		var diags diag.Diagnostics
 		config := elasticsearch.Config{}
 		config.Header = http.Header{"User-Agent": []string{fmt.Sprintf("elasticstack-terraform-provider/%s", version)}}
+		opaqueId := d.Get("opaque_id").(string)
+		if opaqueId == "" {
+			opaqueId = newOpaqueId()
+		}
+		config.Header.Set(opaqueIdHeader, opaqueId)
+		tflog.Info(ctx, fmt.Sprintf("the requests to Elasticsearch are sent with the %s header: %s", opaqueIdHeader, opaqueId))
+		insecure := false
+		proxy := ""
+		debugRequests := false
+		var creds *credentialsProvider
+		var secretsSink SecretsSink
+		var report *driftReport
 
 		if v, ok := d.GetOk("elasticsearch"); ok {
 			// if defined we must have only one entry
@@ -83,12 +115,6 @@ func NewApiClientFunc(version string, p *schema.Provider) func(context.Context,
 					config.Addresses = endpoints
 				}
 
-				if insecure, ok := esConfig["insecure"]; ok && insecure.(bool) {
-					tr := http.DefaultTransport.(*http.Transport)
-					tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-					config.Transport = tr
-				}
-
 				if caFile, ok := esConfig["ca_file"]; ok && caFile.(string) != "" {
 					caCert, err := ioutil.ReadFile(caFile.(string))
 					if err != nil {
@@ -101,9 +127,31 @@ func NewApiClientFunc(version string, p *schema.Provider) func(context.Context,
 					}
 					config.CACert = caCert
 				}
+				insecure, _ = esConfig["insecure"].(bool)
+				proxy, _ = esConfig["proxy_url"].(string)
+				headers, _ := esConfig["headers"].(map[string]interface{})
+				configureHeaders(&config, headers)
+				debugRequests, _ = esConfig["debug_requests"].(bool)
+				creds = credentialsProviderFromConfig(esConfig)
+				secretsSink = secretsSinkFromConfig(esConfig)
+				report = driftReportFromConfig(esConfig)
 			}
 		}
 
+		if debugRequests {
+			config.Logger = &debugLogger{}
+		}
+
+		metrics := newApiMetrics()
+		if err := configureTransport(&config, insecure, proxy, creds, metrics, report); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to configure Elasticsearch client transport",
+				Detail:   err.Error(),
+			})
+			return nil, diags
+		}
+
 		es, err := elasticsearch.NewClient(config)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
@@ -113,73 +161,137 @@ func NewApiClientFunc(version string, p *schema.Provider) func(context.Context,
 			})
 		}
 
-		return &ApiClient{es, version}, diags
+		client := &ApiClient{es, version, debugRequests, secretsSink, metrics, report, d.Get("id_strategy").(string), opaqueId, nil, securityAvailability{}}
+		if diags.HasError() {
+			return client, diags
+		}
+		aliases, diags := connectionAliasesFromConfig(d, client)
+		if diags.HasError() {
+			return nil, diags
+		}
+		client.aliases = aliases
+		if d.Get("validate_connection").(bool) {
+			if diags := client.ValidateConnections(ctx); diags.HasError() {
+				return nil, diags
+			}
+		}
+		return client, diags
+	}
+}
+
+// Creates the clients of the named connections configured in the provider
+func connectionAliasesFromConfig(d *schema.ResourceData, defaultClient *ApiClient) (map[string]*ApiClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	aliases := make(map[string]*ApiClient)
+	for _, v := range d.Get("elasticsearch_connection_alias").([]interface{}) {
+		conn := v.(map[string]interface{})
+		name := conn["name"].(string)
+		if _, ok := aliases[name]; ok {
+			return nil, diag.Errorf("The connection alias '%s' is configured more than once", name)
+		}
+		client, err := newApiClientFromConnection(conn, defaultClient)
+		if err != nil {
+			return nil, diag.Errorf("Unable to configure the connection alias '%s': %s", name, err)
+		}
+		aliases[name] = client
 	}
+	return aliases, diags
 }
 
 func NewApiClient(d *schema.ResourceData, meta interface{}) (*ApiClient, error) {
 	defaultClient := meta.(*ApiClient)
 	// if the config provided let's use it
 	if esConn, ok := d.GetOk("elasticsearch_connection"); ok {
-		config := elasticsearch.Config{}
-		config.Header = http.Header{"User-Agent": []string{fmt.Sprintf("elasticstack-terraform-provider/%s", defaultClient.version)}}
-
 		// there is always only 1 connection per resource
-		conn := esConn.([]interface{})[0].(map[string]interface{})
+		return newApiClientFromConnection(esConn.([]interface{})[0].(map[string]interface{}), defaultClient)
+	} else { // or return the default client
+		return defaultClient, nil
+	}
+}
 
-		if u := conn["username"]; u != nil {
-			config.Username = u.(string)
-		}
-		if p := conn["password"]; p != nil {
-			config.Password = p.(string)
-		}
-		if endpoints := conn["endpoints"]; endpoints != nil {
-			var addrs []string
-			for _, e := range endpoints.([]interface{}) {
-				addrs = append(addrs, e.(string))
-			}
-			config.Addresses = addrs
-		}
-		if insecure := conn["insecure"]; insecure.(bool) {
-			tr := http.DefaultTransport.(*http.Transport)
-			tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-			config.Transport = tr
-		}
-		if caFile, ok := conn["ca_file"]; ok && caFile.(string) != "" {
-			caCert, err := ioutil.ReadFile(caFile.(string))
-			if err != nil {
-				return nil, fmt.Errorf("Unable to read ca_file: %w", err)
-			}
-			config.CACert = caCert
+// Creates the client for the connection configured in the given block of the resource,
+// e.g. when the resource has to reach another cluster besides the one it's managed in.
+func NewApiClientFromConnectionBlock(d *schema.ResourceData, key string, meta interface{}) (*ApiClient, error) {
+	esConn, ok := d.GetOk(key)
+	if !ok {
+		return nil, fmt.Errorf("The connection block '%s' is not configured", key)
+	}
+	return newApiClientFromConnection(esConn.([]interface{})[0].(map[string]interface{}), meta.(*ApiClient))
+}
+
+func newApiClientFromConnection(conn map[string]interface{}, defaultClient *ApiClient) (*ApiClient, error) {
+	if alias, _ := conn["alias"].(string); alias != "" {
+		client, ok := defaultClient.aliases[alias]
+		if !ok {
+			return nil, fmt.Errorf("The connection alias '%s' is not configured in the provider", alias)
 		}
+		return client, nil
+	}
 
-		es, err := elasticsearch.NewClient(config)
+	config := elasticsearch.Config{}
+	config.Header = http.Header{"User-Agent": []string{fmt.Sprintf("elasticstack-terraform-provider/%s", defaultClient.version)}}
+	if defaultClient.opaqueId != "" {
+		config.Header.Set(opaqueIdHeader, defaultClient.opaqueId)
+	}
+
+	if u := conn["username"]; u != nil {
+		config.Username = u.(string)
+	}
+	if p := conn["password"]; p != nil {
+		config.Password = p.(string)
+	}
+	if endpoints := conn["endpoints"]; endpoints != nil {
+		var addrs []string
+		for _, e := range endpoints.([]interface{}) {
+			addrs = append(addrs, e.(string))
+		}
+		config.Addresses = addrs
+	}
+	if caFile, ok := conn["ca_file"]; ok && caFile.(string) != "" {
+		caCert, err := ioutil.ReadFile(caFile.(string))
 		if err != nil {
-			return nil, fmt.Errorf("Unable to create Elasticsearch client")
+			return nil, fmt.Errorf("Unable to read ca_file: %w", err)
 		}
-		return &ApiClient{es, defaultClient.version}, nil
-	} else { // or return the default client
-		return defaultClient, nil
+		config.CACert = caCert
+	}
+	insecure, _ := conn["insecure"].(bool)
+	proxy, _ := conn["proxy_url"].(string)
+	headers, _ := conn["headers"].(map[string]interface{})
+	configureHeaders(&config, headers)
+	if err := configureTransport(&config, insecure, proxy, credentialsProviderFromConfig(conn), defaultClient.metrics, defaultClient.driftReport); err != nil {
+		return nil, fmt.Errorf("Unable to configure Elasticsearch client transport: %w", err)
+	}
+	if defaultClient.debugRequests {
+		config.Logger = &debugLogger{}
 	}
+
+	es, err := elasticsearch.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create Elasticsearch client")
+	}
+	return &ApiClient{es, defaultClient.version, defaultClient.debugRequests, defaultClient.secretsSink, defaultClient.metrics, defaultClient.driftReport, defaultClient.idStrategy, defaultClient.opaqueId, defaultClient.aliases, securityAvailability{}}, nil
 }
 
 func (a *ApiClient) GetESClient() *elasticsearch.Client {
 	return a.es
 }
 
-func (a *ApiClient) ID(resourceId string) (*CompositeId, diag.Diagnostics) {
+func (a *ApiClient) ID(ctx context.Context, resourceId string) (*CompositeId, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	clusterId, diags := a.ClusterID()
+	if a.idStrategy == IdStrategyName {
+		return &CompositeId{ResourceId: resourceId}, diags
+	}
+	clusterId, diags := a.ClusterID(ctx)
 	if diags.HasError() {
 		return nil, diags
 	}
-	log.Printf("[TRACE] cluster UUID: %s", *clusterId)
+	tflog.Trace(ctx, fmt.Sprintf("cluster UUID: %s", *clusterId))
 	return &CompositeId{*clusterId, resourceId}, diags
 }
 
-func (a *ApiClient) ClusterID() (*string, diag.Diagnostics) {
+func (a *ApiClient) ClusterID(ctx context.Context) (*string, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	res, err := a.es.Info()
+	res, err := a.es.Info(a.es.Info.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -193,7 +305,7 @@ func (a *ApiClient) ClusterID() (*string, diag.Diagnostics) {
 		return nil, diag.FromErr(err)
 	}
 	if uuid := info["cluster_uuid"].(string); uuid != "" && uuid != "_na_" {
-		log.Printf("[TRACE] cluster UUID: %s", uuid)
+		tflog.Trace(ctx, fmt.Sprintf("cluster UUID: %s", uuid))
 		return &uuid, diags
 	}
 
@@ -205,3 +317,50 @@ func (a *ApiClient) ClusterID() (*string, diag.Diagnostics) {
 	})
 	return nil, diags
 }
+
+// Returns the major version of Elasticsearch, the one of the node answering the request
+func (a *ApiClient) ServerMajorVersion(ctx context.Context) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Info(a.es.Info.WithContext(ctx))
+	if err != nil {
+		return 0, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to connect to the Elasticsearch cluster"); diags.HasError() {
+		return 0, diags
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, diag.FromErr(err)
+	}
+	major := serverMajorVersion(body)
+	if major == 0 {
+		return 0, diag.Errorf("Unable to parse the version of Elasticsearch: %s", body)
+	}
+	return major, diags
+}
+
+// Performs the request to the Elasticsearch API, which is not supported by the typed client yet.
+// The body, if provided, is sent as JSON.
+func (a *ApiClient) performRequest(ctx context.Context, method, path string, body interface{}) (*esapi.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(bodyBytes)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := a.es.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+	return &esapi.Response{StatusCode: res.StatusCode, Body: res.Body, Header: res.Header}, nil
+}
@@ -0,0 +1,22 @@
+package clients
+
+import (
+	"testing"
+)
+
+func TestNewApiClientFromConnectionAlias(t *testing.T) {
+	monitoring := &ApiClient{version: "test"}
+	defaultClient := &ApiClient{version: "test", aliases: map[string]*ApiClient{"monitoring": monitoring}}
+
+	client, err := newApiClientFromConnection(map[string]interface{}{"alias": "monitoring"}, defaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client != monitoring {
+		t.Error("expected the client of the connection alias")
+	}
+
+	if _, err := newApiClientFromConnection(map[string]interface{}{"alias": "missing"}, defaultClient); err == nil {
+		t.Error("expected the missing connection alias to be rejected")
+	}
+}
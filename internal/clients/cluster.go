@@ -2,24 +2,30 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
-func (a *ApiClient) PutElasticsearchSnapshotRepository(repository *models.SnapshotRepository) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchSnapshotRepository(ctx context.Context, repository *models.SnapshotRepository) diag.Diagnostics {
 	var diags diag.Diagnostics
 	snapRepoBytes, err := json.Marshal(repository)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] sending snapshot repository definition to ES API: %s", snapRepoBytes)
-	res, err := a.es.Snapshot.CreateRepository(repository.Name, bytes.NewReader(snapRepoBytes))
+	tflog.Trace(ctx, fmt.Sprintf("sending snapshot repository definition to ES API: %s", snapRepoBytes))
+	res, err := a.es.Snapshot.CreateRepository(repository.Name, bytes.NewReader(snapRepoBytes), a.es.Snapshot.CreateRepository.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -31,10 +37,10 @@ func (a *ApiClient) PutElasticsearchSnapshotRepository(repository *models.Snapsh
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchSnapshotRepository(name string) (*models.SnapshotRepository, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchSnapshotRepository(ctx context.Context, name string) (*models.SnapshotRepository, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.Snapshot.GetRepository.WithRepository(name)
-	res, err := a.es.Snapshot.GetRepository(req)
+	res, err := a.es.Snapshot.GetRepository(req, a.es.Snapshot.GetRepository.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -54,7 +60,7 @@ func (a *ApiClient) GetElasticsearchSnapshotRepository(name string) (*models.Sna
 	if err := json.NewDecoder(res.Body).Decode(&snapRepoResponse); err != nil {
 		return nil, diag.FromErr(err)
 	}
-	log.Printf("[TRACE] response ES API snapshot repository: %+v", snapRepoResponse)
+	tflog.Trace(ctx, fmt.Sprintf("response ES API snapshot repository: %+v", snapRepoResponse))
 
 	if currentRepo, ok := snapRepoResponse[name]; ok {
 		return &currentRepo, diags
@@ -68,9 +74,9 @@ func (a *ApiClient) GetElasticsearchSnapshotRepository(name string) (*models.Sna
 	return nil, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchSnapshotRepository(name string) diag.Diagnostics {
+func (a *ApiClient) DeleteElasticsearchSnapshotRepository(ctx context.Context, name string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := a.es.Snapshot.DeleteRepository([]string{name})
+	res, err := a.es.Snapshot.DeleteRepository([]string{name}, a.es.Snapshot.DeleteRepository.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -81,16 +87,16 @@ func (a *ApiClient) DeleteElasticsearchSnapshotRepository(name string) diag.Diag
 	return diags
 }
 
-func (a *ApiClient) PutElasticsearchSlm(slm *models.SnapshotPolicy) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchSlm(ctx context.Context, slm *models.SnapshotPolicy) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	slmBytes, err := json.Marshal(slm)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] sending SLM to ES API: %s", slmBytes)
+	tflog.Trace(ctx, fmt.Sprintf("sending SLM to ES API: %s", slmBytes))
 	req := a.es.SlmPutLifecycle.WithBody(bytes.NewReader(slmBytes))
-	res, err := a.es.SlmPutLifecycle(slm.Id, req)
+	res, err := a.es.SlmPutLifecycle(slm.Id, req, a.es.SlmPutLifecycle.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -102,10 +108,10 @@ func (a *ApiClient) PutElasticsearchSlm(slm *models.SnapshotPolicy) diag.Diagnos
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchSlm(slmName string) (*models.SnapshotPolicy, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchSlm(ctx context.Context, slmName string) (*models.SnapshotPolicyInfo, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.SlmGetLifecycle.WithPolicyID(slmName)
-	res, err := a.es.SlmGetLifecycle(req)
+	res, err := a.es.SlmGetLifecycle(req, a.es.SlmGetLifecycle.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -116,15 +122,13 @@ func (a *ApiClient) GetElasticsearchSlm(slmName string) (*models.SnapshotPolicy,
 	if diags := utils.CheckError(res, "Unable to get SLM policy from ES API"); diags.HasError() {
 		return nil, diags
 	}
-	type SlmReponse = map[string]struct {
-		Policy models.SnapshotPolicy `json:"policy"`
-	}
+	type SlmReponse = map[string]models.SnapshotPolicyInfo
 	var slmResponse SlmReponse
 	if err := json.NewDecoder(res.Body).Decode(&slmResponse); err != nil {
 		return nil, diag.FromErr(err)
 	}
 	if slm, ok := slmResponse[slmName]; ok {
-		return &slm.Policy, diags
+		return &slm, diags
 	}
 	diags = append(diags, diag.Diagnostic{
 		Severity: diag.Error,
@@ -134,9 +138,44 @@ func (a *ApiClient) GetElasticsearchSlm(slmName string) (*models.SnapshotPolicy,
 	return nil, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchSlm(slmName string) diag.Diagnostics {
+// Takes a snapshot with the policy immediately, returns the name of the snapshot
+func (a *ApiClient) ExecuteElasticsearchSlm(ctx context.Context, slmName string) (string, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	res, err := a.es.SlmDeleteLifecycle(slmName)
+	res, err := a.es.SlmExecuteLifecycle(slmName, a.es.SlmExecuteLifecycle.WithContext(ctx))
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to execute SLM policy: %s", slmName)); diags.HasError() {
+		return "", diags
+	}
+	var response struct {
+		SnapshotName string `json:"snapshot_name"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return "", diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("SLM policy %s started the snapshot %s", slmName, response.SnapshotName))
+	return response.SnapshotName, diags
+}
+
+// Deletes the snapshots expired according to the retention of all the policies immediately
+func (a *ApiClient) ExecuteElasticsearchSlmRetention(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.SlmExecuteRetention(a.es.SlmExecuteRetention.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to execute SLM retention"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) DeleteElasticsearchSlm(ctx context.Context, slmName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.SlmDeleteLifecycle(slmName, a.es.SlmDeleteLifecycle.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -148,14 +187,14 @@ func (a *ApiClient) DeleteElasticsearchSlm(slmName string) diag.Diagnostics {
 	return diags
 }
 
-func (a *ApiClient) PutElasticsearchSettings(settings map[string]interface{}) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchSettings(ctx context.Context, settings map[string]interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	settingsBytes, err := json.Marshal(settings)
 	if err != nil {
 		diag.FromErr(err)
 	}
-	log.Printf("[TRACE] settings to set: %s", settingsBytes)
-	res, err := a.es.Cluster.PutSettings(bytes.NewReader(settingsBytes))
+	tflog.Trace(ctx, fmt.Sprintf("settings to set: %s", settingsBytes))
+	res, err := a.es.Cluster.PutSettings(bytes.NewReader(settingsBytes), a.es.Cluster.PutSettings.WithContext(ctx))
 	if err != nil {
 		diag.FromErr(err)
 	}
@@ -166,10 +205,10 @@ func (a *ApiClient) PutElasticsearchSettings(settings map[string]interface{}) di
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchSettings() (map[string]interface{}, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchSettings(ctx context.Context) (map[string]interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.Cluster.GetSettings.WithFlatSettings(true)
-	res, err := a.es.Cluster.GetSettings(req)
+	res, err := a.es.Cluster.GetSettings(req, a.es.Cluster.GetSettings.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -184,3 +223,562 @@ func (a *ApiClient) GetElasticsearchSettings() (map[string]interface{}, diag.Dia
 	}
 	return clusterSettings, diags
 }
+
+// Updates the settings of the index of the watches, the unset settings are reset with the nil values
+func (a *ApiClient) PutElasticsearchWatcherSettings(ctx context.Context, settings map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("sending watcher settings to ES API: %+v", settings))
+	res, err := a.performRequest(ctx, http.MethodPut, "/_watcher/settings", settings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update the watcher settings."); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// Returns the flattened settings of the index of the watches, e.g. `index.number_of_replicas`
+func (a *ApiClient) GetElasticsearchWatcherSettings(ctx context.Context) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, "/_watcher/settings", nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to read the watcher settings."); diags.HasError() {
+		return nil, diags
+	}
+
+	settings := make(map[string]interface{})
+	if err := json.NewDecoder(res.Body).Decode(&settings); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return utils.FlattenMap(settings), diags
+}
+
+func (a *ApiClient) GetElasticsearchRemoteInfo(ctx context.Context) (map[string]models.RemoteClusterInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Cluster.RemoteInfo(a.es.Cluster.RemoteInfo.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the remote clusters info."); diags.HasError() {
+		return nil, diags
+	}
+
+	remotes := make(map[string]models.RemoteClusterInfo)
+	if err := json.NewDecoder(res.Body).Decode(&remotes); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return remotes, diags
+}
+
+// Excludes the master-eligible nodes from the voting configuration, waiting until they are removed from it
+func (a *ApiClient) PostElasticsearchVotingConfigExclusions(ctx context.Context, nodeNames []string, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Cluster.PostVotingConfigExclusions(
+		a.es.Cluster.PostVotingConfigExclusions.WithNodeNames(strings.Join(nodeNames, ",")),
+		a.es.Cluster.PostVotingConfigExclusions.WithTimeout(timeout),
+		a.es.Cluster.PostVotingConfigExclusions.WithContext(ctx),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to exclude the nodes from the voting configuration: %s", strings.Join(nodeNames, ","))); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchVotingConfigExclusions(ctx context.Context) ([]models.VotingConfigExclusion, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Cluster.State(
+		a.es.Cluster.State.WithMetric("metadata"),
+		a.es.Cluster.State.WithFilterPath("metadata.cluster_coordination.voting_config_exclusions"),
+		a.es.Cluster.State.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the voting configuration exclusions."); diags.HasError() {
+		return nil, diags
+	}
+
+	var state struct {
+		Metadata struct {
+			ClusterCoordination struct {
+				VotingConfigExclusions []models.VotingConfigExclusion `json:"voting_config_exclusions"`
+			} `json:"cluster_coordination"`
+		} `json:"metadata"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&state); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return state.Metadata.ClusterCoordination.VotingConfigExclusions, diags
+}
+
+// Clears all the voting configuration exclusions, optionally waiting until the excluded nodes leave the cluster
+func (a *ApiClient) DeleteElasticsearchVotingConfigExclusions(ctx context.Context, waitForRemoval bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Cluster.DeleteVotingConfigExclusions(
+		a.es.Cluster.DeleteVotingConfigExclusions.WithWaitForRemoval(waitForRemoval),
+		a.es.Cluster.DeleteVotingConfigExclusions.WithContext(ctx),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to clear the voting configuration exclusions."); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) PutElasticsearchDesiredNodes(ctx context.Context, desiredNodes *models.DesiredNodes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body := map[string]interface{}{"nodes": desiredNodes.Nodes}
+	tflog.Trace(ctx, fmt.Sprintf("updating desired nodes %s to version %d: %+v", desiredNodes.HistoryId, desiredNodes.Version, body))
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_internal/desired_nodes/%s/%d", url.PathEscape(desiredNodes.HistoryId), desiredNodes.Version), body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to update the desired nodes: %s", desiredNodes.HistoryId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// Gets the latest desired nodes of the cluster, or nil if they are not set
+func (a *ApiClient) GetElasticsearchDesiredNodes(ctx context.Context) (*models.DesiredNodes, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, "/_internal/desired_nodes/_latest", nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, "Unable to get the desired nodes."); diags.HasError() {
+		return nil, diags
+	}
+
+	var desiredNodes models.DesiredNodes
+	if err := json.NewDecoder(res.Body).Decode(&desiredNodes); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("get desired nodes from ES API: %+v", desiredNodes))
+	return &desiredNodes, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchDesiredNodes(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, "/_internal/desired_nodes", nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to delete the desired nodes."); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) PutElasticsearchNodeShutdown(ctx context.Context, shutdown *models.NodeShutdown) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body := map[string]interface{}{
+		"type":   shutdown.Type,
+		"reason": shutdown.Reason,
+	}
+	if shutdown.AllocationDelay != "" {
+		body["allocation_delay"] = shutdown.AllocationDelay
+	}
+	if shutdown.TargetNodeName != "" {
+		body["target_node_name"] = shutdown.TargetNodeName
+	}
+	tflog.Trace(ctx, fmt.Sprintf("preparing node %s for shutdown: %+v", shutdown.NodeId, body))
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_nodes/%s/shutdown", url.PathEscape(shutdown.NodeId)), body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to prepare the node for shutdown: %s", shutdown.NodeId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchNodeShutdown(ctx context.Context, nodeId string) (*models.NodeShutdown, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_nodes/%s/shutdown", url.PathEscape(nodeId)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the shutdown status of the node: %s", nodeId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var shutdowns struct {
+		Nodes []models.NodeShutdown `json:"nodes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&shutdowns); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for _, s := range shutdowns.Nodes {
+		if s.NodeId == nodeId {
+			tflog.Trace(ctx, fmt.Sprintf("get node shutdown '%s' from ES API: %+v", nodeId, s))
+			return &s, diags
+		}
+	}
+	return nil, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchNodeShutdown(ctx context.Context, nodeId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_nodes/%s/shutdown", url.PathEscape(nodeId)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to cancel the shutdown of the node: %s", nodeId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchNodeAttributes(ctx context.Context) ([]models.NodeAttribute, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Cat.Nodeattrs(
+		a.es.Cat.Nodeattrs.WithFormat("json"),
+		a.es.Cat.Nodeattrs.WithH("id", "node", "attr", "value"),
+		a.es.Cat.Nodeattrs.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the node attributes."); diags.HasError() {
+		return nil, diags
+	}
+
+	attributes := make([]models.NodeAttribute, 0)
+	if err := json.NewDecoder(res.Body).Decode(&attributes); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("get node attributes from ES API: %+v", attributes))
+	return attributes, diags
+}
+
+// Gets the deprecated features used by the cluster, which are removed or changed in the next major version.
+// Only the deprecations of the matching indices are returned when the index pattern is given.
+func (a *ApiClient) GetElasticsearchDeprecations(ctx context.Context, index string) (*models.DeprecationsResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.MigrationDeprecationsRequest){a.es.Migration.Deprecations.WithContext(ctx)}
+	if index != "" {
+		opts = append(opts, a.es.Migration.Deprecations.WithIndex(index))
+	}
+	res, err := a.es.Migration.Deprecations(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the deprecation info."); diags.HasError() {
+		return nil, diags
+	}
+
+	var deprecations models.DeprecationsResponse
+	if err := json.NewDecoder(res.Body).Decode(&deprecations); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &deprecations, diags
+}
+
+// Gets the information of the nodes by their IDs, with the flat settings. The secure settings of the keystores are never returned.
+func (a *ApiClient) GetElasticsearchNodesInfo(ctx context.Context) (map[string]models.NodeInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Nodes.Info(
+		a.es.Nodes.Info.WithMetric("settings"),
+		a.es.Nodes.Info.WithFlatSettings(true),
+		a.es.Nodes.Info.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the information of the nodes."); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.NodesInfoResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return response.Nodes, diags
+}
+
+// Reloads the reloadable secure settings from the keystores of the nodes, all of them when no node is given.
+// The password is required only when the keystores are password protected.
+func (a *ApiClient) ReloadElasticsearchSecureSettings(ctx context.Context, nodeIds []string, password string) (*models.ReloadSecureSettingsResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.NodesReloadSecureSettingsRequest){a.es.Nodes.ReloadSecureSettings.WithContext(ctx)}
+	if len(nodeIds) > 0 {
+		opts = append(opts, a.es.Nodes.ReloadSecureSettings.WithNodeID(nodeIds...))
+	}
+	if password != "" {
+		body, err := json.Marshal(map[string]string{"secure_settings_password": password})
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		opts = append(opts, a.es.Nodes.ReloadSecureSettings.WithBody(bytes.NewReader(body)))
+	}
+	res, err := a.es.Nodes.ReloadSecureSettings(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to reload the secure settings"); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.ReloadSecureSettingsResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response, diags
+}
+
+// Gets the task, optionally waiting up to the timeout for its completion. The result of the task is kept by Elasticsearch
+// only when it was started in the background.
+func (a *ApiClient) GetElasticsearchTask(ctx context.Context, taskId string, waitTimeout time.Duration) (*models.Task, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.TasksGetRequest){a.es.Tasks.Get.WithContext(ctx)}
+	if waitTimeout > 0 {
+		opts = append(opts, a.es.Tasks.Get.WithWaitForCompletion(true), a.es.Tasks.Get.WithTimeout(waitTimeout))
+	}
+	res, err := a.es.Tasks.Get(taskId, opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	// the task is still running once the wait times out
+	if res.StatusCode == http.StatusRequestTimeout {
+		return &models.Task{}, diags
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the task: %s", taskId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var task models.Task
+	if err := json.NewDecoder(res.Body).Decode(&task); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("get task '%s' from ES API: %+v", taskId, task))
+	return &task, diags
+}
+
+// Gets the health of the targets, waiting up to the timeout for their status and the number of their active shards.
+// The health is returned with `timed_out` set once the wait times out.
+func (a *ApiClient) GetElasticsearchClusterHealth(ctx context.Context, targets []string, waitForStatus, waitForActiveShards string, timeout time.Duration) (*models.ClusterHealth, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.ClusterHealthRequest){a.es.Cluster.Health.WithContext(ctx), a.es.Cluster.Health.WithIndex(targets...), a.es.Cluster.Health.WithTimeout(timeout)}
+	if waitForStatus != "" {
+		opts = append(opts, a.es.Cluster.Health.WithWaitForStatus(waitForStatus))
+	}
+	if waitForActiveShards != "" {
+		opts = append(opts, a.es.Cluster.Health.WithWaitForActiveShards(waitForActiveShards))
+	}
+	res, err := a.es.Cluster.Health(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusRequestTimeout {
+		if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the health of: %s", strings.Join(targets, ","))); diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	var health models.ClusterHealth
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("cluster health of '%s' from ES API: %+v", strings.Join(targets, ","), health))
+	return &health, diags
+}
+
+// Lists the running tasks matching the actions, e.g. `*reindex`, on the nodes, all the tasks on all the nodes by default
+func (a *ApiClient) GetElasticsearchTasks(ctx context.Context, actions, nodes []string, parentTaskId string) ([]models.TaskInfo, diag.Diagnostics) {
+	opts := []func(*esapi.TasksListRequest){
+		a.es.Tasks.List.WithDetailed(true),
+		a.es.Tasks.List.WithContext(ctx),
+	}
+	if len(actions) > 0 {
+		opts = append(opts, a.es.Tasks.List.WithActions(actions...))
+	}
+	if len(nodes) > 0 {
+		opts = append(opts, a.es.Tasks.List.WithNodes(nodes...))
+	}
+	if parentTaskId != "" {
+		opts = append(opts, a.es.Tasks.List.WithParentTaskID(parentTaskId))
+	}
+	res, err := a.es.Tasks.List(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to list the tasks"); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.TasksResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return flattenTasksResponse(&response), tasksFailures(&response, diag.Warning, "Unable to list the tasks on all the nodes")
+}
+
+// Cancels the task, or all the tasks matching the actions on the nodes, returning the cancelled tasks. The task which
+// is not running anymore is not cancelled, without an error.
+func (a *ApiClient) CancelElasticsearchTasks(ctx context.Context, taskId string, actions, nodes []string, waitForCompletion bool) ([]models.TaskInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.TasksCancelRequest){
+		a.es.Tasks.Cancel.WithWaitForCompletion(waitForCompletion),
+		a.es.Tasks.Cancel.WithContext(ctx),
+	}
+	if taskId != "" {
+		opts = append(opts, a.es.Tasks.Cancel.WithTaskID(taskId))
+	}
+	if len(actions) > 0 {
+		opts = append(opts, a.es.Tasks.Cancel.WithActions(actions...))
+	}
+	if len(nodes) > 0 {
+		opts = append(opts, a.es.Tasks.Cancel.WithNodes(nodes...))
+	}
+	res, err := a.es.Tasks.Cancel(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, diags
+	}
+	if diags := utils.CheckError(res, "Unable to cancel the tasks"); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.TasksResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return flattenTasksResponse(&response), tasksFailures(&response, diag.Error, "Unable to cancel the tasks")
+}
+
+// Returns the tasks of all the nodes, ordered by the node and the ID of the task
+func flattenTasksResponse(response *models.TasksResponse) []models.TaskInfo {
+	tasks := make([]models.TaskInfo, 0)
+	for _, node := range response.Nodes {
+		for _, task := range node.Tasks {
+			task.NodeName = node.Name
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Node != tasks[j].Node {
+			return tasks[i].Node < tasks[j].Node
+		}
+		return tasks[i].Id < tasks[j].Id
+	})
+	return tasks
+}
+
+// The failures of the single nodes or tasks are reported with the request succeeding
+func tasksFailures(response *models.TasksResponse, severity diag.Severity, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, failure := range append(response.NodeFailures, response.TaskFailures...) {
+		reason := failure["reason"]
+		if cause, ok := failure["caused_by"].(map[string]interface{}); ok && reason == nil {
+			reason = cause["reason"]
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary:  summary,
+			Detail:   fmt.Sprint(reason),
+		})
+	}
+	return diags
+}
+
+// Installs the license, given as the JSON document with either the `license` or the `licenses` key, as issued by Elastic
+func (a *ApiClient) PutElasticsearchLicense(ctx context.Context, license string, acknowledge bool) (*models.LicenseResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.License.Post(
+		a.es.License.Post.WithBody(strings.NewReader(license)),
+		a.es.License.Post.WithAcknowledge(acknowledge),
+		a.es.License.Post.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to install the license"); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.LicenseResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response, diags
+}
+
+func (a *ApiClient) GetElasticsearchLicense(ctx context.Context) (*models.License, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.License.Get(a.es.License.Get.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	// there is no license before the cluster has formed
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, "Unable to get the license"); diags.HasError() {
+		return nil, diags
+	}
+
+	var response struct {
+		License models.License `json:"license"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("get license from ES API: %+v", response.License))
+	return &response.License, diags
+}
+
+// Deletes the license, the cluster falls back to the basic license
+func (a *ApiClient) DeleteElasticsearchLicense(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.License.Delete(a.es.License.Delete.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to delete the license"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Checks that the default connection and the aliased ones reach Elasticsearch with valid credentials, so the misconfigured
+// connection fails the configuration of the provider rather than the first resource using it
+func (a *ApiClient) ValidateConnections(ctx context.Context) diag.Diagnostics {
+	diags := a.validateConnection(ctx, "elasticsearch")
+	aliases := make([]string, 0, len(a.aliases))
+	for alias := range a.aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		diags = append(diags, a.aliases[alias].validateConnection(ctx, fmt.Sprintf("connection alias '%s'", alias))...)
+	}
+	return diags
+}
+
+func (a *ApiClient) validateConnection(ctx context.Context, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Info(a.es.Info.WithContext(ctx))
+	if err != nil {
+		return diag.Diagnostics{connectionErrorDiagnostic(name, err)}
+	}
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusUnauthorized:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to authenticate to Elasticsearch with the %s", name),
+			Detail:   "Elasticsearch rejected the credentials. Check the username and password, or the API key, and that the user exists in the realms of the cluster.",
+		}}
+	case http.StatusForbidden:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("The user of the %s is not allowed to read the cluster information", name),
+			Detail:   "The credentials are valid, but the user lacks the `monitor` cluster privilege, which the provider needs to identify the cluster.",
+		}}
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to connect to Elasticsearch with the %s", name)); diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("the %s to Elasticsearch is valid", name))
+	return diags
+}
+
+// Explains the failure to reach Elasticsearch with the settings to check
+func connectionErrorDiagnostic(name string, err error) diag.Diagnostic {
+	summary := fmt.Sprintf("Unable to connect to Elasticsearch with the %s", name)
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	var detail string
+	switch {
+	case errors.As(err, &unknownAuthority):
+		detail = "The certificate of Elasticsearch is not signed by a trusted authority. Set `ca_file` to the CA certificate of the cluster, or `insecure = true` to skip the verification of the certificate, which is not recommended."
+	case errors.As(err, &hostname):
+		detail = "The certificate of Elasticsearch is not valid for the host name of the endpoint. Use the host name the certificate was issued for in the `endpoints`."
+	case errors.As(err, &invalid):
+		detail = "The certificate of Elasticsearch is not valid, e.g. it has expired. Renew the certificate of the cluster."
+	case strings.Contains(err.Error(), "server gave HTTP response to HTTPS client"), strings.Contains(err.Error(), "first record does not look like a TLS handshake"):
+		detail = "Elasticsearch doesn't use TLS on the endpoint. Use the `http://` scheme in the `endpoints`."
+	case errors.As(err, &dnsErr):
+		detail = fmt.Sprintf("The host name %s of the endpoint cannot be resolved. Check the `endpoints`.", dnsErr.Name)
+	case errors.As(err, &opErr):
+		detail = "Elasticsearch cannot be reached on the endpoint. Check the `endpoints`, the network between Terraform and the cluster, and the `proxy_url` if any."
+	default:
+		detail = "Check the `endpoints` and the credentials of the connection."
+	}
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   fmt.Sprintf("%s\nFailed with: %s", detail, err),
+	}
+}
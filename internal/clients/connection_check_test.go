@@ -0,0 +1,62 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateConnection(t *testing.T) {
+	handler := func(status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"version":{"number":"7.16.0"}}`))
+		}
+	}
+	valid := httptest.NewServer(handler(http.StatusOK))
+	defer valid.Close()
+	unauthorized := httptest.NewServer(handler(http.StatusUnauthorized))
+	defer unauthorized.Close()
+	tlsServer := httptest.NewUnstartedServer(handler(http.StatusOK))
+	// the rejected handshakes are expected
+	tlsServer.Config.ErrorLog = log.New(io.Discard, "", 0)
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		summary  string
+		detail   string
+	}{
+		{"valid", valid.URL, "", ""},
+		{"unauthorized", unauthorized.URL, "Unable to authenticate to Elasticsearch with the connection alias 'test'", "rejected the credentials"},
+		{"untrusted certificate", tlsServer.URL, "Unable to connect to Elasticsearch with the connection alias 'test'", "Set `ca_file`"},
+		{"plain HTTP", strings.Replace(valid.URL, "http://", "https://", 1), "Unable to connect to Elasticsearch with the connection alias 'test'", "Use the `http://` scheme"},
+	}
+
+	for _, tc := range tests {
+		client, err := newApiClientFromConnection(map[string]interface{}{
+			"endpoints": []interface{}{tc.endpoint},
+		}, &ApiClient{version: "test", metrics: newApiMetrics()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		diags := client.validateConnection(context.Background(), "connection alias 'test'")
+		if tc.summary == "" {
+			if len(diags) > 0 {
+				t.Errorf("%s: expected the connection to be valid, got %v", tc.name, diags)
+			}
+			continue
+		}
+		if len(diags) != 1 || diags[0].Summary != tc.summary || !strings.Contains(diags[0].Detail, tc.detail) {
+			t.Errorf("%s: expected the error %q with %q, got %v", tc.name, tc.summary, tc.detail, diags)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func (a *ApiClient) PutElasticsearchConnector(ctx context.Context, connector *models.Connector) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body := map[string]interface{}{
+		"index_name":  connector.IndexName,
+		"name":        connector.Name,
+		"description": connector.Description,
+		"is_native":   connector.IsNative,
+	}
+	if connector.ServiceType != "" {
+		body["service_type"] = connector.ServiceType
+	}
+	if connector.Language != "" {
+		body["language"] = connector.Language
+	}
+	tflog.Trace(ctx, fmt.Sprintf("sending connector '%s' to ES API: %+v", connector.Id, body))
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_connector/%s", url.PathEscape(connector.Id)), body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create the connector"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// Updates a single part of the connector using one of the dedicated update APIs, e.g. "_scheduling" or "_pipeline".
+func (a *ApiClient) UpdateElasticsearchConnector(ctx context.Context, connectorId, part string, body interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// the body is not logged, since the configuration carries the secrets of the connector
+	tflog.Trace(ctx, fmt.Sprintf("updating %s of the connector '%s'", part, connectorId))
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_connector/%s/%s", url.PathEscape(connectorId), part), body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to update %s of the connector: %s", part, connectorId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchConnector(ctx context.Context, connectorId string) (*models.Connector, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_connector/%s", url.PathEscape(connectorId)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the connector: %s", connectorId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var connector models.Connector
+	if err := json.NewDecoder(res.Body).Decode(&connector); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &connector, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchConnector(ctx context.Context, connectorId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_connector/%s", url.PathEscape(connectorId)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the connector: %s", connectorId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// Triggers a new sync job of the connector, returns the ID of the created job.
+func (a *ApiClient) CreateElasticsearchConnectorSyncJob(ctx context.Context, connectorId, jobType string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body := map[string]interface{}{
+		"id":             connectorId,
+		"job_type":       jobType,
+		"trigger_method": "on_demand",
+	}
+	tflog.Trace(ctx, fmt.Sprintf("triggering %s sync of the connector '%s'", jobType, connectorId))
+	res, err := a.performRequest(ctx, http.MethodPost, "/_connector/_sync_job", body)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to create the sync job of the connector: %s", connectorId)); diags.HasError() {
+		return "", diags
+	}
+
+	var created struct {
+		Id string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		return "", diag.FromErr(err)
+	}
+	return created.Id, diags
+}
+
+func (a *ApiClient) GetElasticsearchConnectorSyncJob(ctx context.Context, jobId string) (*models.ConnectorSyncJob, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_connector/_sync_job/%s", url.PathEscape(jobId)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the connector sync job: %s", jobId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var job models.ConnectorSyncJob
+	if err := json.NewDecoder(res.Body).Decode(&job); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &job, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchConnectorSyncJob(ctx context.Context, jobId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_connector/_sync_job/%s", url.PathEscape(jobId)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return diags
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the connector sync job: %s", jobId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
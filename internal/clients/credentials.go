@@ -0,0 +1,216 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// How long before the expiration the credentials are refreshed, so the in-flight requests don't fail
+const credentialsExpiryWindow = time.Minute
+
+// How long the credential process may run
+const credentialProcessTimeout = time.Minute
+
+// Credentials used to authenticate the requests, as printed by the credential process or written into the credentials file.
+// Either the username and password, or the API key must be provided.
+type credentials struct {
+	Username   string     `json:"username"`
+	Password   string     `json:"password"`
+	ApiKey     string     `json:"api_key"`
+	Expiration *time.Time `json:"expiration"`
+}
+
+func (c *credentials) validate() error {
+	if c.ApiKey == "" && (c.Username == "" || c.Password == "") {
+		return errors.New("the credentials must contain either the username and password, or the api_key")
+	}
+	return nil
+}
+
+func (c *credentials) authorization() string {
+	if c.ApiKey != "" {
+		return "ApiKey " + c.ApiKey
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Username+":"+c.Password))
+}
+
+// Loads the credentials on demand and caches them until they expire, or until their source changes.
+type credentialsProvider struct {
+	mu    sync.Mutex
+	creds *credentials
+	// the version of the source the cached credentials were loaded from, e.g. the modification time of the file
+	version time.Time
+
+	// loads the credentials, returning the version of the source they were loaded from
+	load func(ctx context.Context) (*credentials, time.Time, error)
+	// returns the current version of the source, the credentials are reloaded when it differs from the cached one
+	currentVersion func() (time.Time, error)
+}
+
+// Returns the provider running the external command, which must print the credentials as JSON to the standard output.
+// The command is run again once the credentials expire.
+func newProcessCredentialsProvider(command []string) *credentialsProvider {
+	return &credentialsProvider{
+		load: func(ctx context.Context) (*credentials, time.Time, error) {
+			ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+			defer cancel()
+			var stdout, stderr bytes.Buffer
+			cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+			if err := cmd.Run(); err != nil {
+				return nil, time.Time{}, fmt.Errorf("the credential process %s failed: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+			}
+			var creds credentials
+			if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+				return nil, time.Time{}, fmt.Errorf("unable to parse the output of the credential process %s: %w", command[0], err)
+			}
+			return &creds, time.Time{}, nil
+		},
+	}
+}
+
+// Returns the provider reading the credentials as JSON from the file, e.g. written by the Vault agent.
+// The file is read again whenever it's modified.
+func newFileCredentialsProvider(path string) *credentialsProvider {
+	modTime := func() (time.Time, error) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unable to read the credentials file: %w", err)
+		}
+		return info.ModTime(), nil
+	}
+	return &credentialsProvider{
+		load: func(ctx context.Context) (*credentials, time.Time, error) {
+			version, err := modTime()
+			if err != nil {
+				return nil, time.Time{}, err
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, time.Time{}, fmt.Errorf("unable to read the credentials file: %w", err)
+			}
+			var creds credentials
+			if err := json.Unmarshal(content, &creds); err != nil {
+				return nil, time.Time{}, fmt.Errorf("unable to parse the credentials file %s: %w", path, err)
+			}
+			return &creds, version, nil
+		},
+		currentVersion: modTime,
+	}
+}
+
+// Returns the cached credentials, or loads them again if they are about to expire or their source has changed.
+func (p *credentialsProvider) get(ctx context.Context) (*credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.creds != nil && !p.stale() {
+		return p.creds, nil
+	}
+	creds, version, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := creds.validate(); err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, "loaded the Elasticsearch credentials")
+	p.creds = creds
+	p.version = version
+	return creds, nil
+}
+
+func (p *credentialsProvider) stale() bool {
+	if p.creds.Expiration != nil && time.Now().Add(credentialsExpiryWindow).After(*p.creds.Expiration) {
+		return true
+	}
+	if p.currentVersion != nil {
+		version, err := p.currentVersion()
+		// let the reload report the error
+		return err != nil || !version.Equal(p.version)
+	}
+	return false
+}
+
+// Drops the cached credentials, so they are loaded again on the next request, e.g. once they are rejected by Elasticsearch.
+func (p *credentialsProvider) invalidate(creds *credentials) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// the credentials could have been reloaded by the concurrent request already
+	if p.creds == creds {
+		p.creds = nil
+	}
+}
+
+// Transport which authenticates every request with the credentials of the provider. If the credentials are rejected,
+// they are loaded again and the request is retried once, so the long running applies survive the rotation of the credentials.
+type credentialsTransport struct {
+	rt          http.RoundTripper
+	credentials *credentialsProvider
+}
+
+func (t *credentialsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, creds, err := t.roundTrip(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	t.credentials.invalidate(creds)
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the request can't be replayed
+		return res, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return res, nil
+		}
+		retry.Body = body
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+	tflog.Debug(req.Context(), "the Elasticsearch credentials were rejected, retrying with the reloaded credentials")
+	res, _, err = t.roundTrip(retry)
+	return res, err
+}
+
+func (t *credentialsTransport) roundTrip(req *http.Request) (*http.Response, *credentials, error) {
+	creds, err := t.credentials.get(req.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set("Authorization", creds.authorization())
+	res, err := t.rt.RoundTrip(authReq)
+	return res, creds, err
+}
+
+// Returns the credentials provider configured in the connection settings, if any
+func credentialsProviderFromConfig(conf map[string]interface{}) *credentialsProvider {
+	if process, ok := conf["credential_process"].([]interface{}); ok && len(process) > 0 {
+		command := make([]string, len(process))
+		for i, c := range process {
+			command[i] = c.(string)
+		}
+		return newProcessCredentialsProvider(command)
+	}
+	if path, ok := conf["credentials_file"].(string); ok && path != "" {
+		return newFileCredentialsProvider(path)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileCredentialsProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "creds.json")
+	writeCredentials := func(content string, modTime time.Time) {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	writeCredentials(`{"username": "elastic", "password": "changeme"}`, now)
+	p := newFileCredentialsProvider(path)
+
+	creds, err := p.get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Username != "elastic" || creds.Password != "changeme" {
+		t.Errorf("unexpected credentials: %+v", creds)
+	}
+
+	writeCredentials(`{"api_key": "a2V5"}`, now.Add(time.Minute))
+	creds, err = p.get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.authorization() != "ApiKey a2V5" {
+		t.Errorf("the modified credentials file was not reloaded: %+v", creds)
+	}
+
+	writeCredentials(`{"username": "elastic"}`, now.Add(2*time.Minute))
+	if _, err := p.get(context.Background()); err == nil || !strings.Contains(err.Error(), "must contain") {
+		t.Errorf("expected the invalid credentials to be rejected, got: %v", err)
+	}
+}
+
+func TestCredentialsProviderExpiration(t *testing.T) {
+	loads := 0
+	p := &credentialsProvider{
+		load: func(ctx context.Context) (*credentials, time.Time, error) {
+			loads++
+			expiration := time.Now().Add(30 * time.Second)
+			return &credentials{ApiKey: fmt.Sprint(loads), Expiration: &expiration}, time.Time{}, nil
+		},
+	}
+
+	for i := 1; i <= 2; i++ {
+		creds, err := p.get(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the credentials expire within the expiry window, so they must be loaded on every call
+		if creds.ApiKey != fmt.Sprint(i) {
+			t.Errorf("expected the credentials to be reloaded, got: %+v", creds)
+		}
+	}
+}
+
+func TestCredentialsTransportRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "ApiKey fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	keys := []string{"stale", "fresh"}
+	p := &credentialsProvider{
+		load: func(ctx context.Context) (*credentials, time.Time, error) {
+			key := keys[0]
+			keys = keys[1:]
+			return &credentials{ApiKey: key}, time.Time{}, nil
+		},
+	}
+	client := &http.Client{Transport: &credentialsTransport{http.DefaultTransport, p}}
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected the request to be retried with the reloaded credentials, got: %d", res.StatusCode)
+	}
+}
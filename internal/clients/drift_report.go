@@ -0,0 +1,113 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The definition of the managed object as returned by Elasticsearch when the resource is read, written into the drift
+// report so it can be compared with the configuration rendered by Terraform.
+type DriftRecord struct {
+	// the type of the resource, e.g. `elasticstack_elasticsearch_index_lifecycle`
+	Resource string `json:"resource"`
+	// the ID of the resource
+	Id string `json:"id"`
+	// the request which returned the definition, e.g. `GET /_ilm/policy/logs`
+	Request    string          `json:"request"`
+	Definition json.RawMessage `json:"definition"`
+	Time       time.Time       `json:"time"`
+}
+
+// Appends the drift records to the file as JSON lines, shared by all the clients of the provider
+type driftReport struct {
+	mu   sync.Mutex
+	path string
+}
+
+func driftReportFromConfig(conf map[string]interface{}) *driftReport {
+	if path, ok := conf["drift_report_file"].(string); ok && path != "" {
+		return &driftReport{path: path}
+	}
+	return nil
+}
+
+func (r *driftReport) write(record DriftRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+type driftReportResourceKey struct{}
+
+type driftReportResource struct {
+	Type string
+	Id   string
+}
+
+// Wraps the read of the resource, so the definitions fetched by it are written into the drift report when it's configured.
+func ReadWithDriftReport(resourceType string, read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx = context.WithValue(ctx, driftReportResourceKey{}, driftReportResource{resourceType, d.Id()})
+		return read(ctx, d, meta)
+	}
+}
+
+// Transport which writes the responses of the successful GET requests sent while reading the resources into the drift report
+type driftReportTransport struct {
+	rt     http.RoundTripper
+	report *driftReport
+}
+
+func (t *driftReportTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.rt.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet || res.StatusCode < 200 || res.StatusCode > 299 {
+		return res, err
+	}
+	resource, ok := req.Context().Value(driftReportResourceKey{}).(driftReportResource)
+	if !ok {
+		return res, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if !json.Valid(body) {
+		return res, nil
+	}
+	record := DriftRecord{
+		Resource:   resource.Type,
+		Id:         resource.Id,
+		Request:    fmt.Sprintf("%s %s", req.Method, req.URL.Path),
+		Definition: body,
+		Time:       time.Now().UTC(),
+	}
+	// the read must not fail because of the report, the missing records are reported in the logs instead
+	if err := t.report.write(record); err != nil {
+		tflog.Warn(req.Context(), fmt.Sprintf("unable to write the definition of %s %s into the drift report: %s", resource.Type, resource.Id, err))
+	}
+	return res, nil
+}
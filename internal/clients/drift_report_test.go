@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDriftReportTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"logs":{"version":1}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "drift.jsonl")
+	client := &http.Client{Transport: &driftReportTransport{http.DefaultTransport, &driftReport{path: path}}}
+	get := func(ctx context.Context, method string) {
+		req, err := http.NewRequestWithContext(ctx, method, server.URL+"/_ilm/policy/logs", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != `{"logs":{"version":1}}` {
+			t.Errorf("expected the body to be passed through, got: %s", body)
+		}
+	}
+
+	// only the GET requests sent while reading the resources are reported
+	get(context.Background(), http.MethodGet)
+	read := ReadWithDriftReport("elasticstack_elasticsearch_index_lifecycle", func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		get(ctx, http.MethodGet)
+		get(ctx, http.MethodPut)
+		return nil
+	})
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("cluster/logs")
+	read(context.Background(), d, nil)
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var records []DriftRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record DriftRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got: %+v", records)
+	}
+	r := records[0]
+	if r.Resource != "elasticstack_elasticsearch_index_lifecycle" || r.Id != "cluster/logs" || r.Request != "GET /_ilm/policy/logs" || string(r.Definition) != `{"logs":{"version":1}}` {
+		t.Errorf("unexpected record: %+v", r)
+	}
+}
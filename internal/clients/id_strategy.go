@@ -0,0 +1,60 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// How the IDs of the resources are built
+const (
+	// The ID is prefixed with the UUID of the cluster, i.e. `<cluster_uuid>/<resource identifier>`
+	IdStrategyClusterUuid = "cluster_uuid"
+	// The ID is the resource identifier alone, so it's kept when the cluster is rebuilt, e.g. behind the same load balancer
+	IdStrategyName = "name"
+)
+
+var IdStrategies = []string{IdStrategyClusterUuid, IdStrategyName}
+
+// Wraps the read of the resource, so the ID in the state follows the configured ID strategy. The IDs are migrated whenever
+// the strategy is changed, in either direction, since the resources accept both forms of the ID.
+func ReadWithIdStrategy(read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if d.Id() == "" {
+			return read(ctx, d, meta)
+		}
+		client, err := NewApiClient(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		id, diags := client.migrateID(ctx, d.Id())
+		if diags.HasError() {
+			return diags
+		}
+		if id != d.Id() {
+			tflog.Debug(ctx, fmt.Sprintf("migrating the resource ID %s to %s", d.Id(), id))
+			d.SetId(id)
+		}
+		return read(ctx, d, meta)
+	}
+}
+
+// Returns the ID following the ID strategy of the client
+func (a *ApiClient) migrateID(ctx context.Context, id string) (string, diag.Diagnostics) {
+	compId, diags := CompositeIdFromStr(id)
+	if diags.HasError() {
+		// the resources with their own IDs are left untouched
+		return id, nil
+	}
+	if (a.idStrategy == IdStrategyName) == (compId.ClusterId == "") {
+		return id, diags
+	}
+	newId, diags := a.ID(ctx, compId.ResourceId)
+	if diags.HasError() {
+		return "", diags
+	}
+	return newId.String(), diags
+}
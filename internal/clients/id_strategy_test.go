@@ -0,0 +1,49 @@
+package clients
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCompositeIdFromStrResourceIdentifier(t *testing.T) {
+	compId, diags := CompositeIdFromStr("my-index")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if compId.ClusterId != "" || compId.ResourceId != "my-index" {
+		t.Errorf("unexpected ID: %+v", compId)
+	}
+	if compId.String() != "my-index" {
+		t.Errorf("expected the resource identifier alone, got %s", compId.String())
+	}
+
+	if _, diags := CompositeIdFromStr("a/b/c"); !diags.HasError() {
+		t.Error("expected the ID with more than two parts to be rejected")
+	}
+}
+
+func TestIdStrategyName(t *testing.T) {
+	client := &ApiClient{idStrategy: IdStrategyName}
+
+	id, diags := client.ID(context.Background(), "my-index")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if id.String() != "my-index" {
+		t.Errorf("expected the resource identifier alone, got %s", id.String())
+	}
+
+	for _, tc := range []struct{ id, expected string }{
+		{"uuid/my-index", "my-index"},
+		{"my-index", "my-index"},
+		{"a/b/c", "a/b/c"},
+	} {
+		migrated, diags := client.migrateID(context.Background(), tc.id)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if migrated != tc.expected {
+			t.Errorf("expected %s to be migrated to %s, got %s", tc.id, tc.expected, migrated)
+		}
+	}
+}
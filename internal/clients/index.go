@@ -2,26 +2,28 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
-func (a *ApiClient) PutElasticsearchIlm(policy *models.Policy) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchIlm(ctx context.Context, policy *models.Policy) diag.Diagnostics {
 	var diags diag.Diagnostics
 	policyBytes, err := json.Marshal(map[string]interface{}{"policy": policy})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] sending new ILM policy to ES API: %s", policyBytes)
+	tflog.Trace(ctx, fmt.Sprintf("sending new ILM policy to ES API: %s", policyBytes))
 	req := a.es.ILM.PutLifecycle.WithBody(bytes.NewReader(policyBytes))
-	res, err := a.es.ILM.PutLifecycle(policy.Name, req)
+	res, err := a.es.ILM.PutLifecycle(policy.Name, req, a.es.ILM.PutLifecycle.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -32,10 +34,10 @@ func (a *ApiClient) PutElasticsearchIlm(policy *models.Policy) diag.Diagnostics
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchIlm(policyName string) (*models.PolicyDefinition, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchIlm(ctx context.Context, policyName string) (*models.PolicyDefinition, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.ILM.GetLifecycle.WithPolicy(policyName)
-	res, err := a.es.ILM.GetLifecycle(req)
+	res, err := a.es.ILM.GetLifecycle(req, a.es.ILM.GetLifecycle.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -52,7 +54,7 @@ func (a *ApiClient) GetElasticsearchIlm(policyName string) (*models.PolicyDefini
 	if err := json.NewDecoder(res.Body).Decode(&ilm); err != nil {
 		return nil, diag.FromErr(err)
 	}
-	log.Printf("[TRACE] get ILM policy '%s' from ES API: %#+v", policyName, ilm)
+	tflog.Trace(ctx, fmt.Sprintf("get ILM policy '%s' from ES API: %#+v", policyName, ilm))
 
 	if ilm, ok := ilm[policyName]; ok {
 		return &ilm, diags
@@ -65,10 +67,52 @@ func (a *ApiClient) GetElasticsearchIlm(policyName string) (*models.PolicyDefini
 	return nil, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchIlm(policyName string) diag.Diagnostics {
+func (a *ApiClient) GetElasticsearchIlms(ctx context.Context) (map[string]models.PolicyDefinition, diag.Diagnostics) {
 	var diags diag.Diagnostics
+	res, err := a.es.ILM.GetLifecycle(a.es.ILM.GetLifecycle.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to fetch ILM policies from the cluster."); diags.HasError() {
+		return nil, diags
+	}
 
-	res, err := a.es.ILM.DeleteLifecycle(policyName)
+	policies := make(map[string]models.PolicyDefinition)
+	if err := json.NewDecoder(res.Body).Decode(&policies); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return policies, diags
+}
+
+// Explains the ILM state of the indices matching the pattern, the result is the map of the index names to their ILM state.
+func (a *ApiClient) ExplainElasticsearchIlm(ctx context.Context, pattern string) (map[string]models.IlmExplain, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.ILM.ExplainLifecycle(pattern, a.es.ILM.ExplainLifecycle.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to explain the ILM state of: %s", pattern)); diags.HasError() {
+		return nil, diags
+	}
+
+	var explain struct {
+		Indices map[string]models.IlmExplain `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&explain); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return explain.Indices, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchIlm(ctx context.Context, policyName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	res, err := a.es.ILM.DeleteLifecycle(policyName, a.es.ILM.DeleteLifecycle.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -79,15 +123,14 @@ func (a *ApiClient) DeleteElasticsearchIlm(policyName string) diag.Diagnostics {
 	return diags
 }
 
-func (a *ApiClient) PutElasticsearchComponentTemplate(template *models.ComponentTemplate) diag.Diagnostics {
-	var diags diag.Diagnostics
+func (a *ApiClient) PutElasticsearchComponentTemplate(ctx context.Context, template *models.ComponentTemplate) diag.Diagnostics {
 	templateBytes, err := json.Marshal(template)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] sending request to ES: %s to create component template '%s' ", templateBytes, template.Name)
+	tflog.Trace(ctx, fmt.Sprintf("sending request to ES: %s to create component template '%s' ", templateBytes, template.Name))
 
-	res, err := a.es.Cluster.PutComponentTemplate(template.Name, bytes.NewReader(templateBytes))
+	res, err := a.es.Cluster.PutComponentTemplate(template.Name, bytes.NewReader(templateBytes), a.es.Cluster.PutComponentTemplate.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -96,13 +139,13 @@ func (a *ApiClient) PutElasticsearchComponentTemplate(template *models.Component
 		return diags
 	}
 
-	return diags
+	return utils.ResponseWarnings(res, "Elasticsearch warned about the component template")
 }
 
-func (a *ApiClient) GetElasticsearchComponentTemplate(templateName string) (*models.ComponentTemplateResponse, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchComponentTemplate(ctx context.Context, templateName string) (*models.ComponentTemplateResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.Cluster.GetComponentTemplate.WithName(templateName)
-	res, err := a.es.Cluster.GetComponentTemplate(req)
+	res, err := a.es.Cluster.GetComponentTemplate(req, a.es.Cluster.GetComponentTemplate.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -132,9 +175,31 @@ func (a *ApiClient) GetElasticsearchComponentTemplate(templateName string) (*mod
 	return &tpl, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchComponentTemplate(templateName string) diag.Diagnostics {
+// Gets all the component templates
+func (a *ApiClient) GetElasticsearchComponentTemplates(ctx context.Context) ([]models.ComponentTemplateResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	res, err := a.es.Cluster.DeleteComponentTemplate(templateName)
+	res, err := a.es.Cluster.GetComponentTemplate(a.es.Cluster.GetComponentTemplate.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, diags
+	}
+	if diags := utils.CheckError(res, "Unable to get the component templates."); diags.HasError() {
+		return nil, diags
+	}
+
+	var componentTemplates models.ComponentTemplatesResponse
+	if err := json.NewDecoder(res.Body).Decode(&componentTemplates); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return componentTemplates.ComponentTemplates, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchComponentTemplate(ctx context.Context, templateName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Cluster.DeleteComponentTemplate(templateName, a.es.Cluster.DeleteComponentTemplate.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -145,15 +210,14 @@ func (a *ApiClient) DeleteElasticsearchComponentTemplate(templateName string) di
 	return diags
 }
 
-func (a *ApiClient) PutElasticsearchIndexTemplate(template *models.IndexTemplate) diag.Diagnostics {
-	var diags diag.Diagnostics
+func (a *ApiClient) PutElasticsearchIndexTemplate(ctx context.Context, template *models.IndexTemplate) diag.Diagnostics {
 	templateBytes, err := json.Marshal(template)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] sending request to ES: %s to create template '%s' ", templateBytes, template.Name)
+	tflog.Trace(ctx, fmt.Sprintf("sending request to ES: %s to create template '%s' ", templateBytes, template.Name))
 
-	res, err := a.es.Indices.PutIndexTemplate(template.Name, bytes.NewReader(templateBytes))
+	res, err := a.es.Indices.PutIndexTemplate(template.Name, bytes.NewReader(templateBytes), a.es.Indices.PutIndexTemplate.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -162,13 +226,13 @@ func (a *ApiClient) PutElasticsearchIndexTemplate(template *models.IndexTemplate
 		return diags
 	}
 
-	return diags
+	return utils.ResponseWarnings(res, "Elasticsearch warned about the index template")
 }
 
-func (a *ApiClient) GetElasticsearchIndexTemplate(templateName string) (*models.IndexTemplateResponse, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchIndexTemplate(ctx context.Context, templateName string) (*models.IndexTemplateResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.Indices.GetIndexTemplate.WithName(templateName)
-	res, err := a.es.Indices.GetIndexTemplate(req)
+	res, err := a.es.Indices.GetIndexTemplate(req, a.es.Indices.GetIndexTemplate.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -195,13 +259,63 @@ func (a *ApiClient) GetElasticsearchIndexTemplate(templateName string) (*models.
 		return nil, diags
 	}
 	tpl := indexTemplates.IndexTemplates[0]
-	log.Printf("[TRACE] read index template from API: %+v", tpl)
+	tflog.Trace(ctx, fmt.Sprintf("read index template from API: %+v", tpl))
 	return &tpl, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchIndexTemplate(templateName string) diag.Diagnostics {
+// Gets all the composable index templates
+func (a *ApiClient) GetElasticsearchIndexTemplates(ctx context.Context) ([]models.IndexTemplateResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Indices.GetIndexTemplate(a.es.Indices.GetIndexTemplate.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, diags
+	}
+	if diags := utils.CheckError(res, "Unable to get the index templates."); diags.HasError() {
+		return nil, diags
+	}
+
+	var indexTemplates models.IndexTemplatesResponse
+	if err := json.NewDecoder(res.Body).Decode(&indexTemplates); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return indexTemplates.IndexTemplates, diags
+}
+
+// Simulates storing the index template, returning the existing templates with the overlapping index patterns
+func (a *ApiClient) SimulateElasticsearchIndexTemplate(ctx context.Context, template *models.IndexTemplate) (*models.SimulatedIndexTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	templateBytes, err := json.Marshal(template)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := a.es.Indices.SimulateTemplate(
+		a.es.Indices.SimulateTemplate.WithName(template.Name),
+		a.es.Indices.SimulateTemplate.WithBody(bytes.NewReader(templateBytes)),
+		a.es.Indices.SimulateTemplate.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to simulate index template"); diags.HasError() {
+		return nil, diags
+	}
+
+	var simulated models.SimulatedIndexTemplate
+	if err := json.NewDecoder(res.Body).Decode(&simulated); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("simulated index template '%s': %+v", template.Name, simulated))
+	return &simulated, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchIndexTemplate(ctx context.Context, templateName string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := a.es.Indices.DeleteIndexTemplate(templateName)
+	res, err := a.es.Indices.DeleteIndexTemplate(templateName, a.es.Indices.DeleteIndexTemplate.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -212,16 +326,16 @@ func (a *ApiClient) DeleteElasticsearchIndexTemplate(templateName string) diag.D
 	return diags
 }
 
-func (a *ApiClient) PutElasticsearchIndex(index *models.Index) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchIndex(ctx context.Context, index *models.Index) diag.Diagnostics {
 	var diags diag.Diagnostics
 	indexBytes, err := json.Marshal(index)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] index definition: %s", indexBytes)
+	tflog.Trace(ctx, fmt.Sprintf("index definition: %s", indexBytes))
 
 	req := a.es.Indices.Create.WithBody(bytes.NewReader(indexBytes))
-	res, err := a.es.Indices.Create(index.Name, req)
+	res, err := a.es.Indices.Create(index.Name, req, a.es.Indices.Create.WithContext(ctx))
 	if err != nil {
 		diag.FromErr(err)
 	}
@@ -232,10 +346,10 @@ func (a *ApiClient) PutElasticsearchIndex(index *models.Index) diag.Diagnostics
 	return diags
 }
 
-func (a *ApiClient) DeleteElasticsearchIndex(name string) diag.Diagnostics {
+func (a *ApiClient) DeleteElasticsearchIndex(ctx context.Context, name string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	res, err := a.es.Indices.Delete([]string{name})
+	res, err := a.es.Indices.Delete([]string{name}, a.es.Indices.Delete.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -247,11 +361,11 @@ func (a *ApiClient) DeleteElasticsearchIndex(name string) diag.Diagnostics {
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchIndex(name string) (*models.Index, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchIndex(ctx context.Context, name string) (*models.Index, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	req := a.es.Indices.Get.WithFlatSettings(true)
-	res, err := a.es.Indices.Get([]string{name}, req)
+	res, err := a.es.Indices.Get([]string{name}, req, a.es.Indices.Get.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -273,10 +387,66 @@ func (a *ApiClient) GetElasticsearchIndex(name string) (*models.Index, diag.Diag
 	return &index, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchIndexAlias(index string, aliases []string) diag.Diagnostics {
+// Returns the state of the index: `open` or `close`
+func (a *ApiClient) GetElasticsearchIndexState(ctx context.Context, name string) (string, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	log.Printf("[TRACE] Deleting aliases for index %s: %v", index, aliases)
-	res, err := a.es.Indices.DeleteAlias([]string{index}, aliases)
+	res, err := a.es.Cluster.State(
+		a.es.Cluster.State.WithMetric("metadata"),
+		a.es.Cluster.State.WithIndex(name),
+		a.es.Cluster.State.WithFilterPath("metadata.indices.*.state"),
+		a.es.Cluster.State.WithContext(ctx),
+	)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the state of the index: %s", name)); diags.HasError() {
+		return "", diags
+	}
+
+	var state struct {
+		Metadata struct {
+			Indices map[string]struct {
+				State string `json:"state"`
+			} `json:"indices"`
+		} `json:"metadata"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&state); err != nil {
+		return "", diag.FromErr(err)
+	}
+	return state.Metadata.Indices[name].State, diags
+}
+
+func (a *ApiClient) OpenElasticsearchIndex(ctx context.Context, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Indices.Open([]string{name}, a.es.Indices.Open.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to open the index: %s", name)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) CloseElasticsearchIndex(ctx context.Context, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Indices.Close([]string{name}, a.es.Indices.Close.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to close the index: %s", name)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) DeleteElasticsearchIndexAlias(ctx context.Context, index string, aliases []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("Deleting aliases for index %s: %v", index, aliases))
+	res, err := a.es.Indices.DeleteAlias([]string{index}, aliases, a.es.Indices.DeleteAlias.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -287,15 +457,15 @@ func (a *ApiClient) DeleteElasticsearchIndexAlias(index string, aliases []string
 	return diags
 }
 
-func (a *ApiClient) UpdateElasticsearchIndexAlias(index string, alias *models.IndexAlias) diag.Diagnostics {
+func (a *ApiClient) UpdateElasticsearchIndexAlias(ctx context.Context, index string, alias *models.IndexAlias) diag.Diagnostics {
 	var diags diag.Diagnostics
 	aliasBytes, err := json.Marshal(alias)
 	if err != nil {
 		diag.FromErr(err)
 	}
-	log.Printf("[TRACE] updaing index %s alias: %s", index, aliasBytes)
+	tflog.Trace(ctx, fmt.Sprintf("updaing index %s alias: %s", index, aliasBytes))
 	req := a.es.Indices.PutAlias.WithBody(bytes.NewReader(aliasBytes))
-	res, err := a.es.Indices.PutAlias([]string{index}, alias.Name, req)
+	res, err := a.es.Indices.PutAlias([]string{index}, alias.Name, req, a.es.Indices.PutAlias.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -306,15 +476,47 @@ func (a *ApiClient) UpdateElasticsearchIndexAlias(index string, alias *models.In
 	return diags
 }
 
-func (a *ApiClient) UpdateElasticsearchIndexSettings(index string, settings map[string]interface{}) diag.Diagnostics {
+func (a *ApiClient) GetElasticsearchAlias(ctx context.Context, aliasName string) (map[string]models.IndexAlias, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	req := a.es.Indices.GetAlias.WithName(aliasName)
+	res, err := a.es.Indices.GetAlias(req, a.es.Indices.GetAlias.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get alias '%s'", aliasName)); diags.HasError() {
+		return nil, diags
+	}
+
+	indices := make(map[string]models.Index)
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("get alias '%s' from ES API: %#+v", aliasName, indices))
+
+	// map of the index names to the alias definition in that index
+	aliases := make(map[string]models.IndexAlias)
+	for indexName, index := range indices {
+		if alias, ok := index.Aliases[aliasName]; ok {
+			alias.Name = aliasName
+			aliases[indexName] = alias
+		}
+	}
+	return aliases, diags
+}
+
+func (a *ApiClient) UpdateElasticsearchIndexSettings(ctx context.Context, index string, settings map[string]interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	settingsBytes, err := json.Marshal(settings)
 	if err != nil {
 		diag.FromErr(err)
 	}
-	log.Printf("[TRACE] updaing index %s settings: %s", index, settingsBytes)
+	tflog.Trace(ctx, fmt.Sprintf("updaing index %s settings: %s", index, settingsBytes))
 	req := a.es.Indices.PutSettings.WithIndex(index)
-	res, err := a.es.Indices.PutSettings(bytes.NewReader(settingsBytes), req)
+	res, err := a.es.Indices.PutSettings(bytes.NewReader(settingsBytes), req, a.es.Indices.PutSettings.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -325,11 +527,55 @@ func (a *ApiClient) UpdateElasticsearchIndexSettings(index string, settings map[
 	return diags
 }
 
-func (a *ApiClient) UpdateElasticsearchIndexMappings(index, mappings string) diag.Diagnostics {
+func (a *ApiClient) GetElasticsearchIndexSettings(ctx context.Context, index string) (map[string]map[string]interface{}, diag.Diagnostics) {
+	return a.GetElasticsearchIndicesSettings(ctx, index, false)
+}
+
+// Gets the flat settings of all the indices matching the pattern, optionally including the hidden indices,
+// e.g. the backing indices of the data streams.
+func (a *ApiClient) GetElasticsearchIndicesSettings(ctx context.Context, index string, includeHidden bool) (map[string]map[string]interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	log.Printf("[TRACE] updaing index %s mappings: %s", index, mappings)
+	opts := []func(*esapi.IndicesGetSettingsRequest){
+		a.es.Indices.GetSettings.WithIndex(index),
+		a.es.Indices.GetSettings.WithFlatSettings(true),
+		a.es.Indices.GetSettings.WithContext(ctx),
+	}
+	if includeHidden {
+		opts = append(opts, a.es.Indices.GetSettings.WithExpandWildcards("open,hidden"))
+	}
+	res, err := a.es.Indices.GetSettings(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the settings of the index '%s'", index)); diags.HasError() {
+		return nil, diags
+	}
+
+	indices := make(map[string]struct {
+		Settings map[string]interface{} `json:"settings"`
+	})
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("get settings of the index '%s' from ES API: %+v", index, indices))
+
+	// map of the index names to their flat settings
+	settings := make(map[string]map[string]interface{}, len(indices))
+	for name, index := range indices {
+		settings[name] = index.Settings
+	}
+	return settings, diags
+}
+
+func (a *ApiClient) UpdateElasticsearchIndexMappings(ctx context.Context, index, mappings string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("updaing index %s mappings: %s", index, mappings))
 	req := a.es.Indices.PutMapping.WithIndex(index)
-	res, err := a.es.Indices.PutMapping(strings.NewReader(mappings), req)
+	res, err := a.es.Indices.PutMapping(strings.NewReader(mappings), req, a.es.Indices.PutMapping.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -340,10 +586,10 @@ func (a *ApiClient) UpdateElasticsearchIndexMappings(index, mappings string) dia
 	return diags
 }
 
-func (a *ApiClient) PutElasticsearchDataStream(dataStreamName string) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchDataStream(ctx context.Context, dataStreamName string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	res, err := a.es.Indices.CreateDataStream(dataStreamName)
+	res, err := a.es.Indices.CreateDataStream(dataStreamName, a.es.Indices.CreateDataStream.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -355,10 +601,10 @@ func (a *ApiClient) PutElasticsearchDataStream(dataStreamName string) diag.Diagn
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchDataStream(dataStreamName string) (*models.DataStream, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchDataStream(ctx context.Context, dataStreamName string) (*models.DataStream, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.Indices.GetDataStream.WithName(dataStreamName)
-	res, err := a.es.Indices.GetDataStream(req)
+	res, err := a.es.Indices.GetDataStream(req, a.es.Indices.GetDataStream.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -374,16 +620,83 @@ func (a *ApiClient) GetElasticsearchDataStream(dataStreamName string) (*models.D
 	if err := json.NewDecoder(res.Body).Decode(&dStreams); err != nil {
 		return nil, diag.FromErr(err)
 	}
-	log.Printf("[TRACE] get data stream '%v' from ES api: %+v", dataStreamName, dStreams)
+	tflog.Trace(ctx, fmt.Sprintf("get data stream '%v' from ES api: %+v", dataStreamName, dStreams))
 	// if the DataStream found in must be the first index in the data_stream object
 	ds := dStreams["data_streams"][0]
 	return &ds, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchDataStream(dataStreamName string) diag.Diagnostics {
+// Gets all the data streams matching the pattern, optionally including the hidden data streams.
+func (a *ApiClient) GetElasticsearchDataStreams(ctx context.Context, pattern string, includeHidden bool) ([]models.DataStream, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.IndicesGetDataStreamRequest){
+		a.es.Indices.GetDataStream.WithName(pattern),
+		a.es.Indices.GetDataStream.WithContext(ctx),
+	}
+	if includeHidden {
+		opts = append(opts, a.es.Indices.GetDataStream.WithExpandWildcards("open,hidden"))
+	}
+	res, err := a.es.Indices.GetDataStream(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the data streams: %s", pattern)); diags.HasError() {
+		return nil, diags
+	}
+
+	dStreams := make(map[string][]models.DataStream)
+	if err := json.NewDecoder(res.Body).Decode(&dStreams); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return dStreams["data_streams"], diags
+}
+
+// Lists the indices matching the pattern with their health, status and statistics, optionally including the hidden indices
+// and only the indices with the given health.
+func (a *ApiClient) GetElasticsearchIndicesInfo(ctx context.Context, pattern string, includeHidden bool, health string) ([]models.IndexInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.CatIndicesRequest){
+		a.es.Cat.Indices.WithIndex(pattern),
+		a.es.Cat.Indices.WithFormat("json"),
+		a.es.Cat.Indices.WithBytes("b"),
+		a.es.Cat.Indices.WithH("health", "status", "index", "uuid", "pri", "rep", "docs.count", "store.size", "pri.store.size"),
+		a.es.Cat.Indices.WithS("index"),
+		a.es.Cat.Indices.WithContext(ctx),
+	}
+	if includeHidden {
+		opts = append(opts, a.es.Cat.Indices.WithExpandWildcards("all"))
+	}
+	if health != "" {
+		opts = append(opts, a.es.Cat.Indices.WithHealth(health))
+	}
+	res, err := a.es.Cat.Indices(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	// the concrete index names, which do not exist, are not found
+	if res.StatusCode == http.StatusNotFound {
+		return []models.IndexInfo{}, diags
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to list the indices: %s", pattern)); diags.HasError() {
+		return nil, diags
+	}
+
+	indices := make([]models.IndexInfo, 0)
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return indices, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchDataStream(ctx context.Context, dataStreamName string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	res, err := a.es.Indices.DeleteDataStream([]string{dataStreamName})
+	res, err := a.es.Indices.DeleteDataStream([]string{dataStreamName}, a.es.Indices.DeleteDataStream.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -395,15 +708,15 @@ func (a *ApiClient) DeleteElasticsearchDataStream(dataStreamName string) diag.Di
 	return diags
 }
 
-func (a *ApiClient) PutElasticsearchIngestPipeline(pipeline *models.IngestPipeline) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchIngestPipeline(ctx context.Context, pipeline *models.IngestPipeline) diag.Diagnostics {
 	var diags diag.Diagnostics
 	pipelineBytes, err := json.Marshal(pipeline)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] creating ingest pipeline %s: %s", pipeline.Name, pipelineBytes)
+	tflog.Trace(ctx, fmt.Sprintf("creating ingest pipeline %s: %s", pipeline.Name, pipelineBytes))
 
-	res, err := a.es.Ingest.PutPipeline(pipeline.Name, bytes.NewReader(pipelineBytes))
+	res, err := a.es.Ingest.PutPipeline(pipeline.Name, bytes.NewReader(pipelineBytes), a.es.Ingest.PutPipeline.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -415,10 +728,10 @@ func (a *ApiClient) PutElasticsearchIngestPipeline(pipeline *models.IngestPipeli
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchIngestPipeline(name *string) (*models.IngestPipeline, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchIngestPipeline(ctx context.Context, name *string) (*models.IngestPipeline, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.Ingest.GetPipeline.WithPipelineID(*name)
-	res, err := a.es.Ingest.GetPipeline(req)
+	res, err := a.es.Ingest.GetPipeline(req, a.es.Ingest.GetPipeline.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -436,15 +749,41 @@ func (a *ApiClient) GetElasticsearchIngestPipeline(name *string) (*models.Ingest
 	}
 	pipeline := pipelines[*name]
 	pipeline.Name = *name
-	log.Printf("[TRACE] get ingest pipeline %s from ES API: %#+v", *name, pipeline)
+	tflog.Trace(ctx, fmt.Sprintf("get ingest pipeline %s from ES API: %#+v", *name, pipeline))
 
 	return &pipeline, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchIngestPipeline(name *string) diag.Diagnostics {
+func (a *ApiClient) GetElasticsearchIngestPipelines(ctx context.Context) (map[string]models.IngestPipeline, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Ingest.GetPipeline(a.es.Ingest.GetPipeline.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	// there are no pipelines defined in the cluster
+	if res.StatusCode == http.StatusNotFound {
+		return map[string]models.IngestPipeline{}, diags
+	}
+	if diags := utils.CheckError(res, "Unable to get the ingest pipelines"); diags.HasError() {
+		return nil, diags
+	}
+
+	pipelines := make(map[string]models.IngestPipeline)
+	if err := json.NewDecoder(res.Body).Decode(&pipelines); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for name, pipeline := range pipelines {
+		pipeline.Name = name
+		pipelines[name] = pipeline
+	}
+	return pipelines, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchIngestPipeline(ctx context.Context, name *string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	res, err := a.es.Ingest.DeletePipeline(*name)
+	res, err := a.es.Ingest.DeletePipeline(*name, a.es.Ingest.DeletePipeline.WithContext(ctx))
 	if err != nil {
 		return diags
 	}
@@ -454,3 +793,121 @@ func (a *ApiClient) DeleteElasticsearchIngestPipeline(name *string) diag.Diagnos
 	}
 	return diags
 }
+
+// Counts the documents matching the query in the indices, the missing indices are counted as empty.
+func (a *ApiClient) CountElasticsearchDocuments(ctx context.Context, index string, query map[string]interface{}) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.CountRequest){
+		a.es.Count.WithIndex(index),
+		a.es.Count.WithIgnoreUnavailable(true),
+		a.es.Count.WithAllowNoIndices(true),
+		a.es.Count.WithContext(ctx),
+	}
+	if query != nil {
+		queryBytes, err := json.Marshal(map[string]interface{}{"query": query})
+		if err != nil {
+			return 0, diag.FromErr(err)
+		}
+		opts = append(opts, a.es.Count.WithBody(bytes.NewReader(queryBytes)))
+	}
+	res, err := a.es.Count(opts...)
+	if err != nil {
+		return 0, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to count the documents in: %s", index)); diags.HasError() {
+		return 0, diags
+	}
+
+	var count struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&count); err != nil {
+		return 0, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("counted %d documents in '%s'", count.Count, index))
+	return count.Count, diags
+}
+
+// Performs the alias actions (add, remove) atomically, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-aliases.html
+func (a *ApiClient) UpdateElasticsearchAliases(ctx context.Context, actions []map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	actionsBytes, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("updating aliases: %s", actionsBytes))
+	res, err := a.es.Indices.UpdateAliases(bytes.NewReader(actionsBytes), a.es.Indices.UpdateAliases.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update the aliases"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// Starts the reindex in the background, returning the ID of its task
+func (a *ApiClient) ReindexElasticsearch(ctx context.Context, reindex *models.Reindex, slices string, refresh bool) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	reindexBytes, err := json.Marshal(reindex)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("sending reindex request to ES API: %s", reindexBytes))
+	res, err := a.es.Reindex(
+		bytes.NewReader(reindexBytes),
+		a.es.Reindex.WithWaitForCompletion(false),
+		a.es.Reindex.WithSlices(slices),
+		a.es.Reindex.WithRefresh(refresh),
+		a.es.Reindex.WithContext(ctx),
+	)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to reindex into: %s", reindex.Dest.Index)); diags.HasError() {
+		return "", diags
+	}
+
+	var task struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&task); err != nil {
+		return "", diag.FromErr(err)
+	}
+	return task.Task, diags
+}
+
+// Rolls over the alias or the data stream into the new index, once the conditions are met if there are any
+func (a *ApiClient) RolloverElasticsearchIndex(ctx context.Context, target, newIndex string, rollover *models.Rollover, dryRun bool) (*models.RolloverResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rolloverBytes, err := json.Marshal(rollover)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("sending rollover request to ES API: %s", rolloverBytes))
+	opts := []func(*esapi.IndicesRolloverRequest){
+		a.es.Indices.Rollover.WithBody(bytes.NewReader(rolloverBytes)),
+		a.es.Indices.Rollover.WithDryRun(dryRun),
+		a.es.Indices.Rollover.WithContext(ctx),
+	}
+	if newIndex != "" {
+		opts = append(opts, a.es.Indices.Rollover.WithNewIndex(newIndex))
+	}
+	res, err := a.es.Indices.Rollover(target, opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to roll over: %s", target)); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.RolloverResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response, diags
+}
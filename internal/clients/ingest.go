@@ -0,0 +1,109 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func (a *ApiClient) PutElasticsearchGeoipDatabase(ctx context.Context, database *models.GeoipDatabase) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("creating geoip database configuration %s: %+v", database.Id, database))
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_ingest/geoip/database/%s", url.PathEscape(database.Id)), database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to create or update the geoip database configuration: %s", database.Id)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchGeoipDatabase(ctx context.Context, id string) (*models.GeoipDatabase, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_ingest/geoip/database/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the geoip database configuration: %s", id)); diags.HasError() {
+		return nil, diags
+	}
+
+	var databases struct {
+		Databases []struct {
+			Id                 string               `json:"id"`
+			Version            int64                `json:"version"`
+			ModifiedDateMillis int64                `json:"modified_date_millis"`
+			Database           models.GeoipDatabase `json:"database"`
+		} `json:"databases"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&databases); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for _, db := range databases.Databases {
+		if db.Id == id {
+			database := db.Database
+			database.Id = db.Id
+			database.Version = db.Version
+			database.ModifiedDateMillis = db.ModifiedDateMillis
+			tflog.Trace(ctx, fmt.Sprintf("get geoip database configuration '%s' from ES API: %+v", id, database))
+			return &database, diags
+		}
+	}
+	return nil, nil
+}
+
+func (a *ApiClient) DeleteElasticsearchGeoipDatabase(ctx context.Context, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_ingest/geoip/database/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the geoip database configuration: %s", id)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// Simulates the pipeline against the documents, returning the results for every document. The pipeline is parsed before
+// it's run, so the invalid processors, e.g. the scripts which do not compile, are reported as the request errors.
+func (a *ApiClient) SimulateElasticsearchIngestPipeline(ctx context.Context, pipeline *models.IngestPipeline, docs []map[string]interface{}) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	simulateDocs := make([]map[string]interface{}, len(docs))
+	for i, doc := range docs {
+		simulateDocs[i] = map[string]interface{}{"_source": doc}
+	}
+	body := map[string]interface{}{
+		"pipeline": pipeline,
+		"docs":     simulateDocs,
+	}
+	res, err := a.performRequest(ctx, http.MethodPost, "/_ingest/pipeline/_simulate", body)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to simulate the ingest pipeline"); diags.HasError() {
+		return nil, diags
+	}
+
+	var results struct {
+		Docs []map[string]interface{} `json:"docs"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&results); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return results.Docs, diags
+}
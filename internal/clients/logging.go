@@ -0,0 +1,76 @@
+package clients
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Headers, which values must never end up in the logs
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+	"X-Api-Key":     true,
+}
+
+// Logger for the Elasticsearch client, which writes the full requests and responses into the provider logs at TRACE level,
+// enabled with the `debug_requests` setting of the provider.
+// The logs are written to the logger carried by the request context, and the credentials are redacted.
+type debugLogger struct{}
+
+func (l *debugLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
+	if req == nil {
+		return nil
+	}
+	ctx := req.Context()
+
+	args := []interface{}{
+		"method", req.Method,
+		"url", req.URL.Redacted(),
+		"duration", dur.String(),
+		"request_headers", redactHeaders(req.Header),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ := io.ReadAll(req.Body)
+		args = append(args, "request_body", utils.RedactSensitiveJSON(string(body)))
+	}
+	if res != nil {
+		args = append(args, "status", res.StatusCode, "response_headers", redactHeaders(res.Header))
+		if res.Body != nil && res.Body != http.NoBody {
+			body, _ := io.ReadAll(res.Body)
+			args = append(args, "response_body", utils.RedactSensitiveJSON(string(body)))
+		}
+	}
+	if err != nil {
+		args = append(args, "error", err.Error())
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Elasticsearch API request: %s %s", req.Method, req.URL.Path), args...)
+	return nil
+}
+
+func (l *debugLogger) RequestBodyEnabled() bool {
+	return true
+}
+
+func (l *debugLogger) ResponseBodyEnabled() bool {
+	return true
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			redacted[name] = "[REDACTED]"
+			continue
+		}
+		redacted[name] = strings.Join(values, ", ")
+	}
+	return redacted
+}
@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The path segments naming the APIs rather than the managed objects, which are kept in the endpoint of the metrics
+var apiPathWords = map[string]struct{}{
+	"analytics": {}, "api_key": {}, "cross_cluster": {}, "database": {}, "desired_nodes": {}, "geoip": {},
+	"health": {}, "info": {}, "metadata": {}, "nodeattrs": {}, "pipeline": {}, "policy": {}, "privilege": {},
+	"reload_secure_settings": {}, "role": {}, "role_mapping": {}, "search_application": {}, "settings": {},
+	"shutdown": {}, "state": {}, "stats": {}, "user": {}, "voting_config_exclusions": {},
+}
+
+// The statuses of the responses the Elasticsearch client retries the request on
+var retriedStatuses = map[int]struct{}{
+	http.StatusTooManyRequests:    {},
+	http.StatusBadGateway:         {},
+	http.StatusServiceUnavailable: {},
+	http.StatusGatewayTimeout:     {},
+}
+
+// How many failed requests are tracked to detect their retries
+const maxTrackedFailedRequests = 1000
+
+// The statistics of the requests sent to one endpoint, e.g. `PUT /_ilm/policy/{name}`
+type EndpointMetrics struct {
+	Endpoint      string
+	Requests      int
+	Retries       int
+	Failures      int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+// Collects the statistics of the requests sent by the provider, shared by all the clients of the provider
+type apiMetrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointMetrics
+	// the requests which failed with a retriable error, the client sends the same request again when it's retried
+	failed map[*http.Request]struct{}
+}
+
+func newApiMetrics() *apiMetrics {
+	return &apiMetrics{
+		endpoints: make(map[string]*EndpointMetrics),
+		failed:    make(map[*http.Request]struct{}),
+	}
+}
+
+func (m *apiMetrics) record(req *http.Request, res *http.Response, err error, duration time.Duration) {
+	endpoint := fmt.Sprintf("%s %s", req.Method, metricsEndpointPath(req.URL.Path))
+	failed := err != nil
+	if res != nil {
+		if _, ok := retriedStatuses[res.StatusCode]; ok {
+			failed = true
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	em, ok := m.endpoints[endpoint]
+	if !ok {
+		em = &EndpointMetrics{Endpoint: endpoint}
+		m.endpoints[endpoint] = em
+	}
+	em.Requests++
+	em.TotalDuration += duration
+	if duration > em.MaxDuration {
+		em.MaxDuration = duration
+	}
+	if _, ok := m.failed[req]; ok {
+		em.Retries++
+	}
+	if failed {
+		em.Failures++
+		// the requests the client gave up on are never removed, so they are dropped once there are too many
+		if len(m.failed) >= maxTrackedFailedRequests {
+			m.failed = make(map[*http.Request]struct{})
+		}
+		m.failed[req] = struct{}{}
+	} else {
+		delete(m.failed, req)
+	}
+}
+
+// Returns the statistics of all the endpoints, the slowest in total first
+func (m *apiMetrics) snapshot() []EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	endpoints := make([]EndpointMetrics, 0, len(m.endpoints))
+	for _, em := range m.endpoints {
+		endpoints = append(endpoints, *em)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].TotalDuration != endpoints[j].TotalDuration {
+			return endpoints[i].TotalDuration > endpoints[j].TotalDuration
+		}
+		return endpoints[i].Endpoint < endpoints[j].Endpoint
+	})
+	return endpoints
+}
+
+// Replaces the names of the indices, policies, users, etc. in the path, so the requests to the same API are grouped together
+func metricsEndpointPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if s == "" || strings.HasPrefix(s, "_") {
+			continue
+		}
+		if _, ok := apiPathWords[s]; !ok {
+			segments[i] = "{name}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// Transport which records the duration and the outcome of every request sent to Elasticsearch
+type metricsTransport struct {
+	rt      http.RoundTripper
+	metrics *apiMetrics
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.rt.RoundTrip(req)
+	duration := time.Since(start)
+	t.metrics.record(req, res, err, duration)
+
+	status := 0
+	if res != nil {
+		status = res.StatusCode
+	}
+	tflog.Debug(req.Context(), fmt.Sprintf("%s %s completed with the status %d in %s", req.Method, req.URL.Path, status, duration))
+	return res, err
+}
+
+// Returns the statistics of the requests sent to Elasticsearch by the provider so far, the slowest endpoints in total first
+func (a *ApiClient) ApiMetrics() []EndpointMetrics {
+	return a.metrics.snapshot()
+}
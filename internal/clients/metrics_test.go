@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestMetricsEndpointPath(t *testing.T) {
+	tests := map[string]string{
+		"/_ilm/policy/logs":                     "/_ilm/policy/{name}",
+		"/my-index/_settings":                   "/{name}/_settings",
+		"/_cluster/settings":                    "/_cluster/settings",
+		"/_security/user/elastic/_enable":       "/_security/user/{name}/_enable",
+		"/_nodes/node-1/shutdown":               "/_nodes/{name}/shutdown",
+		"/_internal/desired_nodes/deployment/3": "/_internal/desired_nodes/{name}/{name}",
+		"/":                                     "/",
+	}
+	for path, expected := range tests {
+		if endpoint := metricsEndpointPath(path); endpoint != expected {
+			t.Errorf("expected the endpoint %s for %s, got %s", expected, path, endpoint)
+		}
+	}
+}
+
+func TestApiMetrics(t *testing.T) {
+	m := newApiMetrics()
+	request := func(path string) *http.Request {
+		return &http.Request{Method: http.MethodGet, URL: &url.URL{Path: path}}
+	}
+
+	retried := request("/_ilm/policy/logs")
+	m.record(retried, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil, 2*time.Second)
+	m.record(retried, &http.Response{StatusCode: http.StatusOK}, nil, time.Second)
+	m.record(request("/_ilm/policy/metrics"), &http.Response{StatusCode: http.StatusOK}, nil, time.Second)
+	m.record(request("/_cluster/settings"), nil, errors.New("connection refused"), time.Millisecond)
+
+	endpoints := m.snapshot()
+	if len(endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got: %+v", endpoints)
+	}
+	expected := EndpointMetrics{
+		Endpoint:      "GET /_ilm/policy/{name}",
+		Requests:      3,
+		Retries:       1,
+		Failures:      1,
+		TotalDuration: 4 * time.Second,
+		MaxDuration:   2 * time.Second,
+	}
+	if endpoints[0] != expected {
+		t.Errorf("expected the slowest endpoint first %+v, got: %+v", expected, endpoints[0])
+	}
+	if endpoints[1].Endpoint != "GET /_cluster/settings" || endpoints[1].Failures != 1 || endpoints[1].Retries != 0 {
+		t.Errorf("unexpected metrics of the failed endpoint: %+v", endpoints[1])
+	}
+	if len(m.failed) != 1 {
+		t.Errorf("expected only the request failed for the last time to be tracked, got: %d", len(m.failed))
+	}
+}
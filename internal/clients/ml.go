@@ -0,0 +1,188 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// the page size used to list the events of the calendars
+const mlCalendarEventsPageSize = 1000
+
+func (a *ApiClient) PutElasticsearchMlCalendar(ctx context.Context, calendar *models.MlCalendar) diag.Diagnostics {
+	var diags diag.Diagnostics
+	calendarBytes, err := json.Marshal(calendar)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("sending ML calendar definition to ES API: %s", calendarBytes))
+	res, err := a.es.ML.PutCalendar(calendar.CalendarId, a.es.ML.PutCalendar.WithBody(bytes.NewReader(calendarBytes)), a.es.ML.PutCalendar.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create the ML calendar"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchMlCalendar(ctx context.Context, calendarId string) (*models.MlCalendar, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.ML.GetCalendars(a.es.ML.GetCalendars.WithCalendarID(calendarId), a.es.ML.GetCalendars.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the ML calendar: %s", calendarId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var calendarsResponse struct {
+		Calendars []models.MlCalendar `json:"calendars"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&calendarsResponse); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for _, calendar := range calendarsResponse.Calendars {
+		if calendar.CalendarId == calendarId {
+			return &calendar, diags
+		}
+	}
+	return nil, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchMlCalendar(ctx context.Context, calendarId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.ML.DeleteCalendar(calendarId, a.es.ML.DeleteCalendar.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the ML calendar: %s", calendarId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) AddElasticsearchMlCalendarJobs(ctx context.Context, calendarId string, jobIds []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.ML.PutCalendarJob(calendarId, strings.Join(jobIds, ","), a.es.ML.PutCalendarJob.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to add the jobs to the ML calendar: %s", calendarId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) RemoveElasticsearchMlCalendarJobs(ctx context.Context, calendarId string, jobIds []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.ML.DeleteCalendarJob(calendarId, strings.Join(jobIds, ","), a.es.ML.DeleteCalendarJob.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to remove the jobs from the ML calendar: %s", calendarId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) PostElasticsearchMlCalendarEvent(ctx context.Context, calendarId string, event *models.MlCalendarEvent) (*models.MlCalendarEvent, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	eventsBytes, err := json.Marshal(map[string]interface{}{"events": []*models.MlCalendarEvent{event}})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("sending ML calendar event to ES API: %s", eventsBytes))
+	res, err := a.es.ML.PostCalendarEvents(calendarId, bytes.NewReader(eventsBytes), a.es.ML.PostCalendarEvents.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to add the event to the ML calendar: %s", calendarId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var eventsResponse struct {
+		Events []models.MlCalendarEvent `json:"events"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&eventsResponse); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if len(eventsResponse.Events) != 1 {
+		return nil, diag.Errorf(`Expected a single event of the ML calendar "%s" in the response, got %d`, calendarId, len(eventsResponse.Events))
+	}
+	return &eventsResponse.Events[0], diags
+}
+
+// Returns the event of the calendar, the events are listed page by page since they cannot be fetched by their ID
+func (a *ApiClient) GetElasticsearchMlCalendarEvent(ctx context.Context, calendarId, eventId string) (*models.MlCalendarEvent, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	for from := 0; ; from += mlCalendarEventsPageSize {
+		res, err := a.es.ML.GetCalendarEvents(calendarId,
+			a.es.ML.GetCalendarEvents.WithFrom(from),
+			a.es.ML.GetCalendarEvents.WithSize(mlCalendarEventsPageSize),
+			a.es.ML.GetCalendarEvents.WithContext(ctx),
+		)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if res.StatusCode == http.StatusNotFound {
+			res.Body.Close()
+			return nil, nil
+		}
+		if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the events of the ML calendar: %s", calendarId)); diags.HasError() {
+			res.Body.Close()
+			return nil, diags
+		}
+
+		var eventsResponse struct {
+			Count  int                      `json:"count"`
+			Events []models.MlCalendarEvent `json:"events"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&eventsResponse)
+		res.Body.Close()
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		for _, event := range eventsResponse.Events {
+			if event.EventId == eventId {
+				return &event, diags
+			}
+		}
+		if len(eventsResponse.Events) < mlCalendarEventsPageSize || from+len(eventsResponse.Events) >= eventsResponse.Count {
+			return nil, diags
+		}
+	}
+}
+
+func (a *ApiClient) DeleteElasticsearchMlCalendarEvent(ctx context.Context, calendarId, eventId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.ML.DeleteCalendarEvent(calendarId, eventId, a.es.ML.DeleteCalendarEvent.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return diags
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the event of the ML calendar: %s", calendarId)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
@@ -0,0 +1,279 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func (a *ApiClient) PutElasticsearchSearchApplication(ctx context.Context, app *models.SearchApplication) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("sending search application '%s' to ES API: %+v", app.Name, app))
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_application/search_application/%s", url.PathEscape(app.Name)), app)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create or update the search application"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchSearchApplication(ctx context.Context, name string) (*models.SearchApplication, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_application/search_application/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the search application: %s", name)); diags.HasError() {
+		return nil, diags
+	}
+
+	var app models.SearchApplication
+	if err := json.NewDecoder(res.Body).Decode(&app); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("get search application '%s' from ES API: %+v", name, app))
+	return &app, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchSearchApplication(ctx context.Context, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_application/search_application/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the search application: %s", name)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) PutElasticsearchAnalyticsCollection(ctx context.Context, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_application/analytics/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create the analytics collection"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchAnalyticsCollection(ctx context.Context, name string) (*models.AnalyticsCollection, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_application/analytics/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the analytics collection: %s", name)); diags.HasError() {
+		return nil, diags
+	}
+
+	collections := make(map[string]models.AnalyticsCollection)
+	if err := json.NewDecoder(res.Body).Decode(&collections); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if collection, ok := collections[name]; ok {
+		collection.Name = name
+		return &collection, diags
+	}
+	return nil, nil
+}
+
+func (a *ApiClient) DeleteElasticsearchAnalyticsCollection(ctx context.Context, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_application/analytics/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the analytics collection: %s", name)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// Creates or replaces the synonyms set. The search analyzers using the set are reloaded by Elasticsearch,
+// the result contains the details of the reload.
+func (a *ApiClient) PutElasticsearchSynonymsSet(ctx context.Context, set *models.SynonymsSet) (*models.SynonymsUpdateResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("sending synonyms set '%s' to ES API: %+v", set.Id, set))
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_synonyms/%s", url.PathEscape(set.Id)), set)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create or update the synonyms set"); diags.HasError() {
+		return nil, diags
+	}
+
+	var result models.SynonymsUpdateResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &result, diags
+}
+
+func (a *ApiClient) GetElasticsearchSynonymsSet(ctx context.Context, id string) (*models.SynonymsSet, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	// the rules are paginated, 10 000 is the maximum number of the rules in the set
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_synonyms/%s?size=10000", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the synonyms set: %s", id)); diags.HasError() {
+		return nil, diags
+	}
+
+	set := models.SynonymsSet{Id: id}
+	if err := json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &set, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchSynonymsSet(ctx context.Context, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_synonyms/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the synonyms set: %s", id)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) PutElasticsearchSynonymRule(ctx context.Context, setId string, rule *models.SynonymRule) (*models.SynonymsUpdateResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("sending synonym rule '%s' of the set '%s' to ES API: %+v", rule.Id, setId, rule))
+	body := map[string]interface{}{"synonyms": rule.Synonyms}
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_synonyms/%s/%s", url.PathEscape(setId), url.PathEscape(rule.Id)), body)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create or update the synonym rule"); diags.HasError() {
+		return nil, diags
+	}
+
+	var result models.SynonymsUpdateResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &result, diags
+}
+
+func (a *ApiClient) GetElasticsearchSynonymRule(ctx context.Context, setId, ruleId string) (*models.SynonymRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_synonyms/%s/%s", url.PathEscape(setId), url.PathEscape(ruleId)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the synonym rule: %s", ruleId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var rule models.SynonymRule
+	if err := json.NewDecoder(res.Body).Decode(&rule); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &rule, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchSynonymRule(ctx context.Context, setId, ruleId string) (*models.SynonymsUpdateResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_synonyms/%s/%s", url.PathEscape(setId), url.PathEscape(ruleId)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the synonym rule: %s", ruleId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var result models.SynonymsUpdateResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &result, diags
+}
+
+func (a *ApiClient) PutElasticsearchQueryRuleset(ctx context.Context, ruleset *models.QueryRuleset) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("sending query ruleset '%s' to ES API: %+v", ruleset.Id, ruleset))
+	body := map[string]interface{}{"rules": ruleset.Rules}
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_query_rules/%s", url.PathEscape(ruleset.Id)), body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create or update the query ruleset"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchQueryRuleset(ctx context.Context, id string) (*models.QueryRuleset, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodGet, fmt.Sprintf("/_query_rules/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the query ruleset: %s", id)); diags.HasError() {
+		return nil, diags
+	}
+
+	var ruleset models.QueryRuleset
+	if err := json.NewDecoder(res.Body).Decode(&ruleset); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("get query ruleset '%s' from ES API: %+v", id, ruleset))
+	return &ruleset, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchQueryRuleset(ctx context.Context, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.performRequest(ctx, http.MethodDelete, fmt.Sprintf("/_query_rules/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the query ruleset: %s", id)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
@@ -0,0 +1,84 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// How long the secrets sink command may run
+const secretsSinkTimeout = time.Minute
+
+// The secret generated by the resource, e.g. the encoded API key, which is handed to the secrets sink instead of being stored in the state.
+type Secret struct {
+	// the type of the resource which generated the secret, e.g. `elasticstack_elasticsearch_cross_cluster_search`
+	Resource string `json:"resource"`
+	// the ID of the resource
+	Id string `json:"id"`
+	// the attribute of the resource holding the secret, e.g. `encoded_api_key`
+	Attribute string `json:"attribute"`
+	Value     string `json:"value"`
+}
+
+// Writes the secret into the external store, e.g. Vault.
+type SecretsSink func(ctx context.Context, secret Secret) error
+
+// Returns the sink running the external command, which receives the secret as JSON on the standard input.
+func newProcessSecretsSink(command []string) SecretsSink {
+	return func(ctx context.Context, secret Secret) error {
+		ctx, cancel := context.WithTimeout(ctx, secretsSinkTimeout)
+		defer cancel()
+		input, err := json.Marshal(secret)
+		if err != nil {
+			return err
+		}
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Stdin = bytes.NewReader(input)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("the secrets sink %s failed: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+}
+
+func secretsSinkFromConfig(conf map[string]interface{}) SecretsSink {
+	if sink, ok := conf["secrets_sink"].([]interface{}); ok && len(sink) > 0 {
+		command := make([]string, len(sink))
+		for i, c := range sink {
+			command[i] = c.(string)
+		}
+		return newProcessSecretsSink(command)
+	}
+	return nil
+}
+
+// Replaces the secrets sink of the client, e.g. to write the secrets into the store the provider is embedded with.
+func (a *ApiClient) SetSecretsSink(sink SecretsSink) {
+	a.secretsSink = sink
+}
+
+// Hands the secret to the configured secrets sink. Returns false if there is no sink, and the secret has to be kept in the state.
+func (a *ApiClient) WriteSecret(ctx context.Context, secret Secret) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if a.secretsSink == nil {
+		return false, diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("writing the %s secret of %s %s into the secrets sink", secret.Attribute, secret.Resource, secret.Id))
+	if err := a.secretsSink(ctx, secret); err != nil {
+		return false, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to write the secret into the secrets sink",
+			Detail:   err.Error(),
+		}}
+	}
+	return true, diags
+}
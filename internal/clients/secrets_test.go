@@ -0,0 +1,50 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProcessSecretsSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.json")
+	client := &ApiClient{secretsSink: newProcessSecretsSink([]string{"sh", "-c", "cat > " + path})}
+
+	secret := Secret{
+		Resource:  "elasticstack_elasticsearch_cross_cluster_search",
+		Id:        "cluster/remote",
+		Attribute: "encoded_api_key",
+		Value:     "a2V5",
+	}
+	stored, diags := client.WriteSecret(context.Background(), secret)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !stored {
+		t.Fatal("expected the secret to be stored by the sink")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received Secret
+	if err := json.Unmarshal(content, &received); err != nil {
+		t.Fatal(err)
+	}
+	if received != secret {
+		t.Errorf("unexpected secret received by the sink: %+v", received)
+	}
+
+	client.SetSecretsSink(newProcessSecretsSink([]string{"sh", "-c", "echo denied >&2; exit 1"}))
+	if _, diags := client.WriteSecret(context.Background(), secret); !diags.HasError() || !strings.Contains(diags[0].Detail, "denied") {
+		t.Errorf("expected the failure of the sink to be reported, got: %v", diags)
+	}
+
+	client.SetSecretsSink(nil)
+	if stored, diags := client.WriteSecret(context.Background(), secret); stored || diags.HasError() {
+		t.Errorf("expected the secret to be kept in the state without the sink, got: %v %v", stored, diags)
+	}
+}
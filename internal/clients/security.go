@@ -2,51 +2,48 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
-func (a *ApiClient) PutElasticsearchUser(user *models.User) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchUser(ctx context.Context, user *models.User) diag.Diagnostics {
 	var diags diag.Diagnostics
 	userBytes, err := json.Marshal(user)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] sending request to ES: %s", userBytes)
-	res, err := a.es.Security.PutUser(user.Username, bytes.NewReader(userBytes))
+	tflog.Trace(ctx, fmt.Sprintf("sending request to ES: %s", utils.RedactSensitiveJSON(string(userBytes))))
+	res, err := a.es.Security.PutUser(user.Username, bytes.NewReader(userBytes), a.es.Security.PutUser.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to create or update a user"); diags.HasError() {
+	if diags := a.checkSecurityError(ctx, res, "Unable to create or update a user"); diags.HasError() {
 		return diags
 	}
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchUser(username string) (*models.User, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchUser(ctx context.Context, username string) (*models.User, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := a.es.Security.GetUser.WithUsername(username)
-	res, err := a.es.Security.GetUser(req)
+	res, err := a.es.Security.GetUser(req, a.es.Security.GetUser.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 	defer res.Body.Close()
 	if res.StatusCode == http.StatusNotFound {
-		diags := append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to find a user in the cluster.",
-			Detail:   fmt.Sprintf("Unable to get user: '%s' from the cluster.", username),
-		})
-		return nil, diags
+		return nil, nil
 	}
-	if diags := utils.CheckError(res, "Unable to get a user."); diags.HasError() {
+	if diags := a.checkSecurityError(ctx, res, "Unable to get a user."); diags.HasError() {
 		return nil, diags
 	}
 
@@ -55,7 +52,7 @@ func (a *ApiClient) GetElasticsearchUser(username string) (*models.User, diag.Di
 	if err := json.NewDecoder(res.Body).Decode(&users); err != nil {
 		return nil, diag.FromErr(err)
 	}
-	log.Printf("[TRACE] Fetch users from ES API: %#+v", users)
+	tflog.Trace(ctx, fmt.Sprintf("Fetch users from ES API: %#+v", users))
 
 	if user, ok := users[username]; ok {
 		return &user, diags
@@ -69,44 +66,114 @@ func (a *ApiClient) GetElasticsearchUser(username string) (*models.User, diag.Di
 	return nil, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchUser(username string) diag.Diagnostics {
+// Returns all the users of the native realm and the reserved users, by their usernames
+func (a *ApiClient) GetElasticsearchUsers(ctx context.Context) (map[string]models.User, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Security.GetUser(a.es.Security.GetUser.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to get the users."); diags.HasError() {
+		return nil, diags
+	}
+
+	users := make(map[string]models.User)
+	if err := json.NewDecoder(res.Body).Decode(&users); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for username, user := range users {
+		user.Username = username
+		users[username] = user
+	}
+	return users, diags
+}
+
+// Changes the password of the user, either to the password or to the password hash, e.g. of the built-in users which cannot be updated otherwise
+func (a *ApiClient) ChangeElasticsearchUserPassword(ctx context.Context, username string, password, passwordHash *string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body, err := json.Marshal(struct {
+		Password     *string `json:"password,omitempty"`
+		PasswordHash *string `json:"password_hash,omitempty"`
+	}{password, passwordHash})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := a.es.Security.ChangePassword(bytes.NewReader(body), a.es.Security.ChangePassword.WithUsername(username), a.es.Security.ChangePassword.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, fmt.Sprintf("Unable to change the password of the user: %s", username)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) EnableElasticsearchUser(ctx context.Context, username string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Security.EnableUser(username, a.es.Security.EnableUser.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, fmt.Sprintf("Unable to enable the user: %s", username)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) DisableElasticsearchUser(ctx context.Context, username string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Security.DisableUser(username, a.es.Security.DisableUser.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, fmt.Sprintf("Unable to disable the user: %s", username)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func (a *ApiClient) DeleteElasticsearchUser(ctx context.Context, username string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := a.es.Security.DeleteUser(username)
+	res, err := a.es.Security.DeleteUser(username, a.es.Security.DeleteUser.WithContext(ctx))
 	if err != nil && res.IsError() {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to delete a user"); diags.HasError() {
+	if diags := a.checkSecurityError(ctx, res, "Unable to delete a user"); diags.HasError() {
 		return diags
 	}
 	return diags
 }
 
-func (a *ApiClient) PutElasticsearchRole(role *models.Role) diag.Diagnostics {
+func (a *ApiClient) PutElasticsearchRole(ctx context.Context, role *models.Role) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	roleBytes, err := json.Marshal(role)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] sending request to ES: %s", roleBytes)
-	res, err := a.es.Security.PutRole(role.Name, bytes.NewReader(roleBytes))
+	tflog.Trace(ctx, fmt.Sprintf("sending request to ES: %s", roleBytes))
+	res, err := a.es.Security.PutRole(role.Name, bytes.NewReader(roleBytes), a.es.Security.PutRole.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to create role"); diags.HasError() {
+	if diags := a.checkSecurityError(ctx, res, "Unable to create role"); diags.HasError() {
 		return diags
 	}
 
 	return diags
 }
 
-func (a *ApiClient) GetElasticsearchRole(rolename string) (*models.Role, diag.Diagnostics) {
+func (a *ApiClient) GetElasticsearchRole(ctx context.Context, rolename string) (*models.Role, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	req := a.es.Security.GetRole.WithName(rolename)
-	res, err := a.es.Security.GetRole(req)
+	res, err := a.es.Security.GetRole(req, a.es.Security.GetRole.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -114,7 +181,7 @@ func (a *ApiClient) GetElasticsearchRole(rolename string) (*models.Role, diag.Di
 	if res.StatusCode == http.StatusNotFound {
 		return nil, nil
 	}
-	if diags := utils.CheckError(res, "Unable to get a role."); diags.HasError() {
+	if diags := a.checkSecurityError(ctx, res, "Unable to get a role."); diags.HasError() {
 		return nil, diags
 	}
 	roles := make(map[string]models.Role)
@@ -133,16 +200,328 @@ func (a *ApiClient) GetElasticsearchRole(rolename string) (*models.Role, diag.Di
 	return nil, diags
 }
 
-func (a *ApiClient) DeleteElasticsearchRole(rolename string) diag.Diagnostics {
+// Returns all the roles of the native realm and the reserved roles, by their names
+func (a *ApiClient) GetElasticsearchRoles(ctx context.Context) (map[string]models.Role, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Security.GetRole(a.es.Security.GetRole.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to get the roles."); diags.HasError() {
+		return nil, diags
+	}
+
+	roles := make(map[string]models.Role)
+	if err := json.NewDecoder(res.Body).Decode(&roles); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for name, role := range roles {
+		role.Name = name
+		roles[name] = role
+	}
+	return roles, diags
+}
+
+// Returns the cluster and index privileges supported by the version of the cluster
+func (a *ApiClient) GetElasticsearchBuiltinPrivileges(ctx context.Context) (*models.BuiltinPrivileges, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Security.GetBuiltinPrivileges(a.es.Security.GetBuiltinPrivileges.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to get the built-in privileges."); diags.HasError() {
+		return nil, diags
+	}
+	var privileges models.BuiltinPrivileges
+	if err := json.NewDecoder(res.Body).Decode(&privileges); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &privileges, diags
+}
+
+func (a *ApiClient) DeleteElasticsearchRole(ctx context.Context, rolename string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := a.es.Security.DeleteRole(rolename, a.es.Security.DeleteRole.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to delete role"); diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
+// How many API keys are fetched with a single request
+const apiKeysPageSize = 1000
+
+// Returns all the API keys matching the query, which are fetched page by page in the order of their creation
+func (a *ApiClient) QueryElasticsearchApiKeys(ctx context.Context, query map[string]interface{}) ([]models.ApiKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	apiKeys := make([]models.ApiKey, 0)
+	var searchAfter []interface{}
+	for {
+		page, diags := a.queryElasticsearchApiKeysPage(ctx, query, searchAfter)
+		if diags.HasError() {
+			return nil, diags
+		}
+		apiKeys = append(apiKeys, page...)
+		if len(page) < apiKeysPageSize {
+			break
+		}
+		searchAfter = page[len(page)-1].Sort
+	}
+	return apiKeys, diags
+}
+
+func (a *ApiClient) queryElasticsearchApiKeysPage(ctx context.Context, query map[string]interface{}, searchAfter []interface{}) ([]models.ApiKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body := map[string]interface{}{
+		"query": query,
+		"size":  apiKeysPageSize,
+		// the index order breaks the ties of the API keys created at the same time
+		"sort": []interface{}{"creation", "_doc"},
+	}
+	if searchAfter != nil {
+		body["search_after"] = searchAfter
+	}
+	queryBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("querying API keys: %s", queryBytes))
+	res, err := a.es.Security.QueryAPIKeys(a.es.Security.QueryAPIKeys.WithBody(bytes.NewReader(queryBytes)), a.es.Security.QueryAPIKeys.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to query API keys."); diags.HasError() {
+		return nil, diags
+	}
+
+	var page struct {
+		ApiKeys []models.ApiKey `json:"api_keys"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return page.ApiKeys, diags
+}
+
+// Returns the user profiles best matching the name, i.e. the username, the full name or the email of the user,
+// or all the profiles when the name is empty, up to the given number of the profiles
+func (a *ApiClient) SuggestElasticsearchUserProfiles(ctx context.Context, name string, size int) ([]models.UserProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body := map[string]interface{}{"size": size}
+	if name != "" {
+		body["name"] = name
+	}
+	res, err := a.performRequest(ctx, http.MethodPost, "/_security/profile/_suggest", body)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to suggest the user profiles."); diags.HasError() {
+		return nil, diags
+	}
+
+	var suggestion struct {
+		Profiles []models.UserProfile `json:"profiles"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&suggestion); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return suggestion.Profiles, diags
+}
+
+// Finds out when the API keys were used for the last time, based on the authentication events in the audit logs
+// indexed into the provided index. Returns the map of the API key IDs to the latest authentication timestamp.
+func (a *ApiClient) GetElasticsearchApiKeysLastUsed(ctx context.Context, index, idField, timestampField string, ids []string) (map[string]time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	search := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"terms": map[string]interface{}{idField: ids},
+		},
+		"aggs": map[string]interface{}{
+			"api_keys": map[string]interface{}{
+				"terms": map[string]interface{}{"field": idField, "size": len(ids)},
+				"aggs": map[string]interface{}{
+					"last_used": map[string]interface{}{"max": map[string]interface{}{"field": timestampField}},
+				},
+			},
+		},
+	}
+	searchBytes, err := json.Marshal(search)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("searching the audit logs in '%s': %s", index, searchBytes))
+	res, err := a.es.Search(a.es.Search.WithIndex(index), a.es.Search.WithBody(bytes.NewReader(searchBytes)), a.es.Search.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to search the audit logs in '%s'.", index)); diags.HasError() {
+		return nil, diags
+	}
+
+	var searchRes struct {
+		Aggregations struct {
+			ApiKeys struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					LastUsed struct {
+						Value *float64 `json:"value"`
+					} `json:"last_used"`
+				} `json:"buckets"`
+			} `json:"api_keys"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&searchRes); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	lastUsed := make(map[string]time.Time)
+	for _, bucket := range searchRes.Aggregations.ApiKeys.Buckets {
+		if bucket.LastUsed.Value != nil {
+			lastUsed[bucket.Key] = time.UnixMilli(int64(*bucket.LastUsed.Value)).UTC()
+		}
+	}
+	return lastUsed, diags
+}
+
+func (a *ApiClient) CreateElasticsearchCrossClusterApiKey(ctx context.Context, apiKey *models.CrossClusterApiKey) (*models.CreatedApiKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tflog.Trace(ctx, fmt.Sprintf("creating cross-cluster API key '%s'", apiKey.Name))
+	res, err := a.performRequest(ctx, http.MethodPost, "/_security/cross_cluster/api_key", apiKey)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to create the cross-cluster API key"); diags.HasError() {
+		return nil, diags
+	}
+
+	var created models.CreatedApiKey
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &created, diags
+}
+
+func (a *ApiClient) UpdateElasticsearchCrossClusterApiKey(ctx context.Context, apiKey *models.CrossClusterApiKey) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := a.es.Security.DeleteRole(rolename)
+	// only the access and the metadata can be updated
+	update := models.CrossClusterApiKey{Access: apiKey.Access, Metadata: apiKey.Metadata}
+	tflog.Trace(ctx, fmt.Sprintf("updating cross-cluster API key '%s'", apiKey.Id))
+	res, err := a.performRequest(ctx, http.MethodPut, fmt.Sprintf("/_security/cross_cluster/api_key/%s", apiKey.Id), update)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to delete role"); diags.HasError() {
+	if diags := a.checkSecurityError(ctx, res, "Unable to update the cross-cluster API key"); diags.HasError() {
 		return diags
 	}
+	return diags
+}
+
+func (a *ApiClient) GetElasticsearchCrossClusterApiKey(ctx context.Context, id string) (*models.CrossClusterApiKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := a.es.Security.GetAPIKey(a.es.Security.GetAPIKey.WithID(id), a.es.Security.GetAPIKey.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := a.checkSecurityError(ctx, res, fmt.Sprintf("Unable to get the API key: %s", id)); diags.HasError() {
+		return nil, diags
+	}
 
+	var apiKeys struct {
+		ApiKeys []struct {
+			Id          string                          `json:"id"`
+			Name        string                          `json:"name"`
+			Invalidated bool                            `json:"invalidated"`
+			Access      models.CrossClusterApiKeyAccess `json:"access"`
+			Metadata    map[string]interface{}          `json:"metadata"`
+		} `json:"api_keys"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&apiKeys); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if len(apiKeys.ApiKeys) == 0 {
+		return nil, nil
+	}
+	key := apiKeys.ApiKeys[0]
+	return &models.CrossClusterApiKey{
+		Id:          key.Id,
+		Name:        key.Name,
+		Access:      key.Access,
+		Metadata:    key.Metadata,
+		Invalidated: key.Invalidated,
+	}, diags
+}
+
+func (a *ApiClient) InvalidateElasticsearchApiKeys(ctx context.Context, ids []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body, err := json.Marshal(map[string]interface{}{"ids": ids})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("invalidating API keys: %v", ids))
+	res, err := a.es.Security.InvalidateAPIKey(bytes.NewReader(body), a.es.Security.InvalidateAPIKey.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to invalidate the API keys"); diags.HasError() {
+		return diags
+	}
 	return diags
 }
+
+// Invalidates the API keys matching the IDs, the name, the realm or the user, or owned by the current user.
+// The keys which could not be invalidated are reported as errors.
+func (a *ApiClient) InvalidateElasticsearchApiKeysMatching(ctx context.Context, invalidation *models.ApiKeyInvalidation) (*models.ApiKeyInvalidationResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body, err := json.Marshal(invalidation)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("invalidating API keys: %s", body))
+	res, err := a.es.Security.InvalidateAPIKey(bytes.NewReader(body), a.es.Security.InvalidateAPIKey.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := a.checkSecurityError(ctx, res, "Unable to invalidate the API keys"); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.ApiKeyInvalidationResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for _, detail := range response.ErrorDetails {
+		reason, _ := detail["reason"].(string)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to invalidate the API key",
+			Detail:   reason,
+		})
+	}
+	if response.ErrorCount > len(response.ErrorDetails) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to invalidate the API keys",
+			Detail:   fmt.Sprintf("%d API keys could not be invalidated.", response.ErrorCount-len(response.ErrorDetails)),
+		})
+	}
+	return &response, diags
+}
@@ -0,0 +1,88 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Whether the security features are available on the cluster, detected once per connection on the first failure of the security APIs
+type securityAvailability struct {
+	once sync.Once
+	// the diagnostic explaining why the security features are not available, empty when they are or when it's unknown
+	diags diag.Diagnostics
+}
+
+type xpackInfo struct {
+	License struct {
+		Type string `json:"type"`
+	} `json:"license"`
+	Features struct {
+		Security struct {
+			Available bool `json:"available"`
+			Enabled   bool `json:"enabled"`
+		} `json:"security"`
+	} `json:"features"`
+}
+
+// Checks the response of the security API. When it failed, the error is replaced with the clear one
+// if the security features are disabled on the cluster or not available with its license.
+func (a *ApiClient) checkSecurityError(ctx context.Context, res *esapi.Response, errMsg string) diag.Diagnostics {
+	diags := utils.CheckError(res, errMsg)
+	if !diags.HasError() {
+		return diags
+	}
+	if unavailable := a.securityUnavailable(ctx); unavailable.HasError() {
+		return unavailable
+	}
+	return diags
+}
+
+func (a *ApiClient) securityUnavailable(ctx context.Context) diag.Diagnostics {
+	a.security.once.Do(func() {
+		res, err := a.es.XPack.Info(a.es.XPack.Info.WithCategories("license", "features"), a.es.XPack.Info.WithContext(ctx))
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+		// the distributions without X-Pack have no security features to detect
+		if res.IsError() {
+			return
+		}
+		var info xpackInfo
+		if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("security features: %+v, license: %s", info.Features.Security, info.License.Type))
+		a.security.diags = securityAvailabilityDiags(&info)
+	})
+	return a.security.diags
+}
+
+func securityAvailabilityDiags(info *xpackInfo) diag.Diagnostics {
+	var diags diag.Diagnostics
+	security := info.Features.Security
+	switch {
+	case !security.Available:
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Security is not available on the target cluster",
+			Detail: fmt.Sprintf("The security features are not available with the %s license of the cluster, so the users, roles, role mappings and API keys cannot be managed. "+
+				"Update the license of the cluster, e.g. with elasticstack_elasticsearch_license.", info.License.Type),
+		})
+	case !security.Enabled:
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Security is disabled on the target cluster",
+			Detail: "The security features are disabled on the cluster, so the users, roles, role mappings and API keys cannot be managed. " +
+				"Set `xpack.security.enabled: true` in the elasticsearch.yml of every node and restart them.",
+		})
+	}
+	return diags
+}
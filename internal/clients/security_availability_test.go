@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSecurityUnavailableError(t *testing.T) {
+	tests := []struct {
+		name     string
+		features string
+		summary  string
+	}{
+		{"disabled", `{"license":{"type":"basic"},"features":{"security":{"available":true,"enabled":false}}}`, "Security is disabled on the target cluster"},
+		{"unavailable", `{"license":{"type":"basic"},"features":{"security":{"available":false,"enabled":true}}}`, "Security is not available on the target cluster"},
+		{"available", `{"license":{"type":"trial"},"features":{"security":{"available":true,"enabled":true}}}`, "Unable to get a role."},
+	}
+
+	for _, tc := range tests {
+		xpackRequests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			switch {
+			case r.URL.Path == "/_xpack":
+				xpackRequests++
+				w.Write([]byte(tc.features))
+			case strings.HasPrefix(r.URL.Path, "/_security/"):
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error":{"type":"exception","reason":"Security must be explicitly enabled when using a [basic] license"},"status":500}`))
+			default:
+				w.Write([]byte(`{"version":{"number":"7.16.0"}}`))
+			}
+		}))
+
+		client, err := newApiClientFromConnection(map[string]interface{}{
+			"endpoints": []interface{}{server.URL},
+		}, &ApiClient{version: "test", metrics: newApiMetrics()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			_, diags := client.GetElasticsearchRole(context.Background(), "test")
+			if len(diags) != 1 || diags[0].Summary != tc.summary {
+				t.Errorf("%s: expected the error %q, got %v", tc.name, tc.summary, diags)
+			}
+		}
+		if xpackRequests != 1 {
+			t.Errorf("%s: expected the security features to be detected once, got %d requests", tc.name, xpackRequests)
+		}
+		server.Close()
+	}
+}
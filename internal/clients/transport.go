@@ -0,0 +1,168 @@
+package clients
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Transport which records the performed request on every response, so the failing request can be reported back
+// in the diagnostics, see utils.CheckError
+type requestRecordingTransport struct {
+	rt http.RoundTripper
+}
+
+func (t *requestRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.rt.RoundTrip(req)
+	if res != nil {
+		if res.Header == nil {
+			res.Header = make(http.Header)
+		}
+		res.Header.Set(utils.RequestPathHeader, fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+	}
+	return res, err
+}
+
+// The major version of the REST API the provider is built for, i.e. the one of the client
+const compatibleWithVersion = 7
+
+// Transport which asks the servers of the newer major versions for the REST API compatible with the one of the provider,
+// with the `compatible-with` media types, so the provider keeps working during and after the upgrade to the next major version.
+// The version of the server is detected from the response to `GET /`, which the client always sends first to check the product.
+type compatibilityTransport struct {
+	rt http.RoundTripper
+	// set once a server of a newer major version is detected, any of the nodes of the cluster when it's being upgraded
+	enabled int32
+}
+
+func (t *compatibilityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.LoadInt32(&t.enabled) == 1 {
+		req = req.Clone(req.Context())
+		setCompatibilityHeaders(req.Header)
+	}
+	res, err := t.rt.RoundTrip(req)
+	if err != nil || res == nil || req.Method != http.MethodGet || req.URL.Path != "/" || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if major := serverMajorVersion(body); major > compatibleWithVersion && atomic.CompareAndSwapInt32(&t.enabled, 0, 1) {
+		tflog.Info(req.Context(), fmt.Sprintf("Elasticsearch %d.x detected, the requests ask for the REST API compatible with %d.x", major, compatibleWithVersion))
+	}
+	return res, nil
+}
+
+// Replaces the JSON and NDJSON media types of the request with the compatible ones
+func setCompatibilityHeaders(header http.Header) {
+	header.Set("Accept", fmt.Sprintf("application/vnd.elasticsearch+json;compatible-with=%d", compatibleWithVersion))
+	contentType := header.Get("Content-Type")
+	switch {
+	case contentType == "":
+	case strings.Contains(contentType, "ndjson"):
+		header.Set("Content-Type", fmt.Sprintf("application/vnd.elasticsearch+x-ndjson;compatible-with=%d", compatibleWithVersion))
+	case strings.Contains(contentType, "json"):
+		header.Set("Content-Type", fmt.Sprintf("application/vnd.elasticsearch+json;compatible-with=%d", compatibleWithVersion))
+	}
+}
+
+// Returns the major version of the server from the response to `GET /`, or 0 when it cannot be parsed
+func serverMajorVersion(body []byte) int {
+	var info struct {
+		Version struct {
+			Number string `json:"number"`
+		} `json:"version"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return 0
+	}
+	major, err := strconv.Atoi(strings.SplitN(info.Version.Number, ".", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return major
+}
+
+// Sets up the transport of the client configuration: applies the insecure flag, the CA certificate and the proxy to the
+// HTTP transport and wraps it into the request recording transport, the compatibility transport, the metrics transport, into the drift report
+// transport if the drift report is configured, and into the credentials transport if the credentials are loaded dynamically.
+// Without the explicit proxy URL the proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func configureTransport(config *elasticsearch.Config, insecure bool, proxy string, creds *credentialsProvider, metrics *apiMetrics, report *driftReport) error {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		tr.Proxy = http.ProxyURL(proxyURL)
+	}
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{}
+	}
+	if insecure {
+		tr.TLSClientConfig.InsecureSkipVerify = true
+	}
+	// the CA certificate can be set by the client only on *http.Transport, so it must be applied before wrapping it
+	if config.CACert != nil {
+		tr.TLSClientConfig.RootCAs = x509.NewCertPool()
+		if ok := tr.TLSClientConfig.RootCAs.AppendCertsFromPEM(config.CACert); !ok {
+			return errors.New("unable to add CA certificate")
+		}
+		config.CACert = nil
+	}
+	var rt http.RoundTripper = tr
+	if creds != nil {
+		rt = &credentialsTransport{rt, creds}
+	}
+	if report != nil {
+		rt = &driftReportTransport{rt, report}
+	}
+	// the metrics recognise the retries by the request, which the client sends again as is, so they must wrap the transports cloning it
+	rt = &metricsTransport{&compatibilityTransport{rt: rt}, metrics}
+	config.Transport = &requestRecordingTransport{rt}
+	return nil
+}
+
+// Adds the custom headers of the connection to the headers sent with every request, e.g. the `X-Found-Cluster` header
+// routing the requests through the Elastic Cloud proxy. The custom headers replace the default ones with the same name.
+func configureHeaders(config *elasticsearch.Config, headers map[string]interface{}) {
+	if len(headers) == 0 {
+		return
+	}
+	if config.Header == nil {
+		config.Header = make(http.Header)
+	}
+	for name, value := range headers {
+		config.Header.Set(name, value.(string))
+	}
+}
+
+const opaqueIdHeader = "X-Opaque-Id"
+
+// Generates the X-Opaque-Id of the run, unique for every run of the provider, i.e. every plan and apply
+func newOpaqueId() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "terraform"
+	}
+	return "terraform-" + hex.EncodeToString(b)
+}
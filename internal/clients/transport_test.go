@@ -0,0 +1,171 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConnectionProxyAndHeaders(t *testing.T) {
+	var proxied *http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"version":{"number":"7.16.0"}}`))
+	}))
+	defer proxy.Close()
+
+	defaultClient := &ApiClient{version: "test", metrics: newApiMetrics(), opaqueId: "terraform-run"}
+	client, err := newApiClientFromConnection(map[string]interface{}{
+		"endpoints": []interface{}{"http://elasticsearch.invalid:9200"},
+		"proxy_url": proxy.URL,
+		"headers":   map[string]interface{}{"X-Found-Cluster": "deployment"},
+	}, defaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetESClient().Info(client.GetESClient().Info.WithContext(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if proxied == nil {
+		t.Fatal("expected the request to be sent through the proxy")
+	}
+	if proxied.Host != "elasticsearch.invalid:9200" {
+		t.Errorf("expected the request to the endpoint, got: %s", proxied.Host)
+	}
+	if h := proxied.Header.Get("X-Found-Cluster"); h != "deployment" {
+		t.Errorf("expected the custom header to be sent, got: %s", h)
+	}
+	if h := proxied.Header.Get("User-Agent"); h != "elasticstack-terraform-provider/test" {
+		t.Errorf("expected the default headers to be kept, got: %s", h)
+	}
+	if h := proxied.Header.Get("X-Opaque-Id"); h != "terraform-run" {
+		t.Errorf("expected the opaque ID of the run to be sent, got: %s", h)
+	}
+}
+
+func TestNewOpaqueId(t *testing.T) {
+	first, second := newOpaqueId(), newOpaqueId()
+	if !strings.HasPrefix(first, "terraform-") || len(first) != len("terraform-")+16 {
+		t.Errorf("unexpected opaque ID: %s", first)
+	}
+	if first == second {
+		t.Errorf("expected a new opaque ID for every run, got %s twice", first)
+	}
+}
+
+func TestConfigureTransportInvalidProxy(t *testing.T) {
+	client := &ApiClient{version: "test", metrics: newApiMetrics()}
+	if _, err := newApiClientFromConnection(map[string]interface{}{"proxy_url": "http://proxy:port"}, client); err == nil {
+		t.Error("expected the invalid proxy URL to be rejected")
+	}
+}
+
+func TestCompatibilityHeaders(t *testing.T) {
+	tests := []struct {
+		serverVersion string
+		accept        string
+		contentType   string
+	}{
+		{"7.17.0", "", "application/json"},
+		{"8.1.0", "application/vnd.elasticsearch+json;compatible-with=7", "application/vnd.elasticsearch+json;compatible-with=7"},
+	}
+
+	for _, tc := range tests {
+		var last *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			last = r
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"version":{"number":"` + tc.serverVersion + `"}}`))
+		}))
+
+		client, err := newApiClientFromConnection(map[string]interface{}{
+			"endpoints": []interface{}{server.URL},
+		}, &ApiClient{version: "test", metrics: newApiMetrics()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		es := client.GetESClient()
+		res, err := es.Indices.Create("test", es.Indices.Create.WithBody(strings.NewReader(`{}`)), es.Indices.Create.WithContext(context.Background()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		server.Close()
+
+		if last == nil || last.Method != http.MethodPut {
+			t.Fatalf("%s: expected the request to create the index", tc.serverVersion)
+		}
+		if h := last.Header.Get("Accept"); h != tc.accept {
+			t.Errorf("%s: expected the Accept header %q, got %q", tc.serverVersion, tc.accept, h)
+		}
+		if h := last.Header.Get("Content-Type"); h != tc.contentType {
+			t.Errorf("%s: expected the Content-Type header %q, got %q", tc.serverVersion, tc.contentType, h)
+		}
+	}
+}
+
+func TestRetriesThroughTransport(t *testing.T) {
+	creates := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		if r.Method == http.MethodPut {
+			creates++
+			// the first attempt fails, and is retried by the client
+			if creates == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"acknowledged":true}`))
+			return
+		}
+		// the compatibility headers are sent to the newer major versions, which clones the requests
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"version":{"number":"8.1.0"}}`))
+	}))
+	defer server.Close()
+
+	client, err := newApiClientFromConnection(map[string]interface{}{
+		"endpoints": []interface{}{server.URL},
+	}, &ApiClient{version: "test", metrics: newApiMetrics()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := client.GetESClient()
+	res, err := es.Indices.Create("test", es.Indices.Create.WithBody(strings.NewReader(`{}`)), es.Indices.Create.WithContext(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected the retried request to succeed, got the status %d", res.StatusCode)
+	}
+
+	var create *EndpointMetrics
+	for _, em := range client.ApiMetrics() {
+		if em.Endpoint == "PUT /{name}" {
+			create = &em
+		}
+	}
+	if create == nil {
+		t.Fatalf("expected the metrics of the index creation, got %+v", client.ApiMetrics())
+	}
+	if create.Requests != 2 || create.Failures != 1 || create.Retries != 1 {
+		t.Errorf("expected 2 requests, 1 failure and 1 retry, got %+v", *create)
+	}
+	if len(client.metrics.failed) != 0 {
+		t.Errorf("expected no failed request left once it's retried successfully, got %d", len(client.metrics.failed))
+	}
+}
@@ -0,0 +1,111 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceApiMetrics() *schema.Resource {
+	metricsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"requests": {
+			Description: "The total number of the requests sent to Elasticsearch, including the retries.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"retries": {
+			Description: "The total number of the retried requests.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"endpoints": {
+			Description: "The statistics of the requests per endpoint, the slowest endpoints in total first.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"endpoint": {
+						Description: "The method and the path of the endpoint, with the names of the indices, policies, etc. replaced by `{name}`, e.g. `PUT /_ilm/policy/{name}`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"requests": {
+						Description: "The number of the requests sent to the endpoint, including the retries.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"retries": {
+						Description: "The number of the retried requests, i.e. sent again after the previous attempt failed.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"failures": {
+						Description: "The number of the requests failed with the connection error or with the status the client retries on, i.e. 429, 502, 503 or 504.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"total_duration_ms": {
+						Description: "The total duration of the requests in milliseconds.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"max_duration_ms": {
+						Description: "The duration of the slowest request in milliseconds.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Reports the statistics of the requests the provider has sent to Elasticsearch so far in the current run, e.g. to find the slowest APIs of the large configurations.",
+
+		ReadContext: dataSourceApiMetricsRead,
+
+		Schema: metricsSchema,
+	}
+}
+
+func dataSourceApiMetricsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// the statistics are collected by the provider, across all the connections
+	client := meta.(*clients.ApiClient)
+
+	requests, retries := 0, 0
+	metrics := client.ApiMetrics()
+	endpoints := make([]interface{}, len(metrics))
+	for i, m := range metrics {
+		requests += m.Requests
+		retries += m.Retries
+		endpoints[i] = map[string]interface{}{
+			"endpoint":          m.Endpoint,
+			"requests":          m.Requests,
+			"retries":           m.Retries,
+			"failures":          m.Failures,
+			"total_duration_ms": int(m.TotalDuration.Milliseconds()),
+			"max_duration_ms":   int(m.MaxDuration.Milliseconds()),
+		}
+	}
+
+	if err := d.Set("requests", requests); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("retries", retries); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("endpoints", endpoints); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("api-metrics")
+	return diags
+}
@@ -0,0 +1,36 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceApiMetrics(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceApiMetrics,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_api_metrics.test", "requests"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_api_metrics.test", "endpoints.0.endpoint"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceApiMetrics = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_license" "test" {}
+
+data "elasticstack_elasticsearch_api_metrics" "test" {
+  depends_on = [data.elasticstack_elasticsearch_license.test]
+}
+`
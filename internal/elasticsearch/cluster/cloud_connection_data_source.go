@@ -0,0 +1,151 @@
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// the port of the Elastic Cloud endpoints when the Cloud ID doesn't include one
+const defaultCloudPort = "443"
+
+func DataSourceCloudConnection() *schema.Resource {
+	connectionSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"cloud_id": {
+			Description:  "The Cloud ID of the deployment, e.g. `ec_deployment.example.elasticsearch.cloud_id`, from which the endpoint of Elasticsearch is decoded.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"cloud_id", "https_endpoint"},
+		},
+		"https_endpoint": {
+			Description:  "The HTTPS endpoint of Elasticsearch, e.g. `ec_deployment.example.elasticsearch.https_endpoint`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"cloud_id", "https_endpoint"},
+			ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+		},
+		"username": {
+			Description: "The username of the deployment, e.g. `ec_deployment.example.elasticsearch_username`.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"password": {
+			Description: "The password of the deployment, e.g. `ec_deployment.example.elasticsearch_password`.",
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+		},
+		"endpoints": {
+			Description: "The endpoints of Elasticsearch, in the format of the `endpoints` of the `elasticsearch` block of the provider.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"elasticsearch": {
+			Description: "The connection to the deployment with the same attributes as the `elasticsearch` block of the provider and the `elasticsearch_connection_alias` blocks, which can be configured with a `dynamic` block.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Sensitive:   true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"endpoints": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"username": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"password": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Helper data source to build the connection to an Elasticsearch deployment of Elastic Cloud from the outputs of the `ec_deployment` resource of the `elastic/ec` provider.",
+
+		ReadContext: dataSourceCloudConnectionRead,
+
+		Schema: connectionSchema,
+	}
+}
+
+func dataSourceCloudConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	endpoint := strings.TrimSuffix(d.Get("https_endpoint").(string), "/")
+	if cloudId := d.Get("cloud_id").(string); cloudId != "" {
+		var err error
+		if endpoint, err = endpointFromCloudId(cloudId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	endpoints := []interface{}{endpoint}
+	if err := d.Set("endpoints", endpoints); err != nil {
+		return diag.FromErr(err)
+	}
+	connection := []interface{}{map[string]interface{}{
+		"endpoints": endpoints,
+		"username":  d.Get("username").(string),
+		"password":  d.Get("password").(string),
+	}}
+	if err := d.Set("elasticsearch", connection); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash, err := utils.StringToHash(endpoint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(*hash)
+	return diags
+}
+
+// Decodes the endpoint of Elasticsearch from the Cloud ID, which is in the format `<name>:<base64 of host$es_id$kibana_id>`.
+// The port is either part of the host or of the Elasticsearch ID, e.g. `host:9243` or `es_id:9243`, and is 443 otherwise.
+func endpointFromCloudId(cloudId string) (string, error) {
+	encoded := cloudId
+	if i := strings.LastIndex(cloudId, ":"); i >= 0 {
+		encoded = cloudId[i+1:]
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid Cloud ID: %w", err)
+	}
+	parts := strings.Split(string(decoded), "$")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid Cloud ID: expected the host and the Elasticsearch ID separated by $")
+	}
+
+	host, port := parts[0], defaultCloudPort
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		host, port = h, p
+	}
+	esId := parts[1]
+	if id, p, err := net.SplitHostPort(esId); err == nil {
+		esId, port = id, p
+	}
+	return fmt.Sprintf("https://%s.%s:%s", esId, host, port), nil
+}
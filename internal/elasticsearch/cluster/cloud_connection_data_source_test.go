@@ -0,0 +1,62 @@
+package cluster_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCloudConnection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCloudConnection,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_cloud_connection.cloud_id", "endpoints.0", "https://abcd1234.us-east-1.aws.found.io:443"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_cloud_connection.cloud_id", "elasticsearch.0.endpoints.0", "https://abcd1234.us-east-1.aws.found.io:443"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_cloud_connection.cloud_id", "elasticsearch.0.username", "elastic"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_cloud_connection.endpoint", "endpoints.0", "https://abcd1234.us-east-1.aws.found.io:9243"),
+				),
+			},
+			{
+				Config:      testAccDataSourceCloudConnectionInvalid,
+				ExpectError: regexp.MustCompile(`invalid Cloud ID`),
+			},
+		},
+	})
+}
+
+// the Cloud ID encodes "us-east-1.aws.found.io$abcd1234$efgh5678"
+const testAccDataSourceCloudConnection = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_cloud_connection" "cloud_id" {
+  cloud_id = "my-deployment:dXMtZWFzdC0xLmF3cy5mb3VuZC5pbyRhYmNkMTIzNCRlZmdoNTY3OA=="
+  username = "elastic"
+  password = "changeme"
+}
+
+data "elasticstack_elasticsearch_cloud_connection" "endpoint" {
+  https_endpoint = "https://abcd1234.us-east-1.aws.found.io:9243/"
+  username       = "elastic"
+  password       = "changeme"
+}
+`
+
+const testAccDataSourceCloudConnectionInvalid = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_cloud_connection" "cloud_id" {
+  cloud_id = "my-deployment:bm90LWEtY2xvdWQtaWQ="
+  username = "elastic"
+  password = "changeme"
+}
+`
@@ -0,0 +1,32 @@
+package cluster
+
+import "testing"
+
+func TestEndpointFromCloudId(t *testing.T) {
+	tests := []struct {
+		cloudId  string
+		expected string
+	}{
+		// us-east-1.aws.found.io$abcd1234$efgh5678
+		{"my-deployment:dXMtZWFzdC0xLmF3cy5mb3VuZC5pbyRhYmNkMTIzNCRlZmdoNTY3OA==", "https://abcd1234.us-east-1.aws.found.io:443"},
+		// us-east-1.aws.found.io:9243$abcd1234$efgh5678
+		{"my-deployment:dXMtZWFzdC0xLmF3cy5mb3VuZC5pbzo5MjQzJGFiY2QxMjM0JGVmZ2g1Njc4", "https://abcd1234.us-east-1.aws.found.io:9243"},
+		// us-east-1.aws.found.io$abcd1234:9243$efgh5678
+		{"dXMtZWFzdC0xLmF3cy5mb3VuZC5pbyRhYmNkMTIzNDo5MjQzJGVmZ2g1Njc4", "https://abcd1234.us-east-1.aws.found.io:9243"},
+	}
+
+	for _, tc := range tests {
+		endpoint, err := endpointFromCloudId(tc.cloudId)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.cloudId, err)
+			continue
+		}
+		if endpoint != tc.expected {
+			t.Errorf("%s: expected the endpoint %s, got %s", tc.cloudId, tc.expected, endpoint)
+		}
+	}
+
+	if _, err := endpointFromCloudId("my-deployment:bm90LWEtY2xvdWQtaWQ="); err == nil {
+		t.Error("expected an error for the invalid Cloud ID")
+	}
+}
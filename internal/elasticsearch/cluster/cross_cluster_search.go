@@ -0,0 +1,603 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceCrossClusterSearch() *schema.Resource {
+	searchAccessSchema := map[string]*schema.Schema{
+		"names": {
+			Description: "A list of indices or name patterns the API key grants the access to.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"query": {
+			Description:      "A search query that defines the documents the API key grants the access to.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"field_security": {
+			Description: "The document fields the API key grants the access to.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"grant": {
+						Description: "List of the fields to grant the access to.",
+						Type:        schema.TypeSet,
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"except": {
+						Description: "List of the fields to which the grants will not be applied.",
+						Type:        schema.TypeSet,
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"allow_restricted_indices": {
+			Description: "Include matching restricted indices in names parameter.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+	}
+
+	apiKeySchema := map[string]*schema.Schema{
+		"name": {
+			Description: "The name of the cross-cluster API key.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"expiration": {
+			Description:  "The expiration time of the cross-cluster API key, e.g. `90d`. By default the API key never expires.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"metadata": {
+			Description:      "Arbitrary metadata of the API key.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"search": {
+			Description: "The indices of the remote cluster the local cluster can search.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: searchAccessSchema,
+			},
+		},
+		"replication": {
+			Description: "The indices of the remote cluster the local cluster can replicate with the cross-cluster replication.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"names": {
+						Description: "A list of indices or name patterns the API key grants the access to.",
+						Type:        schema.TypeSet,
+						Required:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	remoteConnection := utils.ConnectionSchema("remote_elasticsearch_connection", "Used to establish connection to the remote Elasticsearch cluster, which the cross-cluster API key is created in.")
+	remoteConnection.Optional = false
+	remoteConnection.Required = true
+
+	ccsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"alias": {
+			Description: "The alias of the remote cluster on the local cluster.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"mode": {
+			Description:  "The mode of the remote cluster connection: `sniff` or `proxy`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "sniff",
+			ValidateFunc: validation.StringInSlice([]string{"sniff", "proxy"}, false),
+		},
+		"seeds": {
+			Description: "The list of the seed nodes of the remote cluster, used in the `sniff` mode. The addresses must point to the remote cluster interface of the nodes (port 9443 by default).",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"proxy_address": {
+			Description: "The address of the remote cluster used in the `proxy` mode. The address must point to the remote cluster interface (port 9443 by default).",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"server_name": {
+			Description: "The server name sent in the TLS handshake in the `proxy` mode.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"skip_unavailable": {
+			Description: "Whether the remote cluster is skipped in the searches when it's unavailable.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"api_key": {
+			Description: "The cross-cluster API key created in the remote cluster, which authenticates the local cluster.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: apiKeySchema,
+			},
+		},
+		"remote_elasticsearch_connection": remoteConnection,
+		"api_key_id": {
+			Description: "The ID of the cross-cluster API key.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"encoded_api_key": {
+			Description: "The encoded cross-cluster API key, which must be added to the keystore of every node of the local cluster as `cluster.remote.<alias>.credentials` secure setting. Left empty when the provider `secrets_sink` is configured, which receives the key instead.",
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+		},
+		"connected": {
+			Description: "Whether the local cluster is connected to the remote cluster.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"credentials_configured": {
+			Description: "Whether the credentials of the remote cluster are configured in the keystore of the local cluster.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(ccsSchema)
+
+	return &schema.Resource{
+		Description: "Configures the cross-cluster search with the API key based security model: the remote cluster connection on the local cluster and the cross-cluster API key on the remote cluster. Requires Elasticsearch 8.10 or higher. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/remote-clusters-api-key.html",
+
+		CreateContext: resourceCrossClusterSearchCreate,
+		UpdateContext: resourceCrossClusterSearchUpdate,
+		ReadContext:   resourceCrossClusterSearchRead,
+		DeleteContext: resourceCrossClusterSearchDelete,
+
+		CustomizeDiff: validateRemoteClusterMode,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: ccsSchema,
+	}
+}
+
+func validateRemoteClusterMode(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	seeds := d.Get("seeds").([]interface{})
+	proxyAddress := d.Get("proxy_address").(string)
+	switch d.Get("mode").(string) {
+	case "sniff":
+		if len(seeds) == 0 {
+			return fmt.Errorf("`seeds` must be set in the `sniff` mode")
+		}
+		if proxyAddress != "" || d.Get("server_name").(string) != "" {
+			return fmt.Errorf("`proxy_address` and `server_name` can only be set in the `proxy` mode")
+		}
+	case "proxy":
+		if proxyAddress == "" {
+			return fmt.Errorf("`proxy_address` must be set in the `proxy` mode")
+		}
+		if len(seeds) > 0 {
+			return fmt.Errorf("`seeds` can only be set in the `sniff` mode")
+		}
+	}
+	return nil
+}
+
+func resourceCrossClusterSearchCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteClient, err := clients.NewApiClientFromConnectionBlock(d, "remote_elasticsearch_connection", meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	alias := d.Get("alias").(string)
+	id, diags := client.ID(ctx, alias)
+	if diags.HasError() {
+		return diags
+	}
+
+	apiKey, diags := expandCrossClusterApiKey(d)
+	if diags.HasError() {
+		return diags
+	}
+	created, diags := remoteClient.CreateElasticsearchCrossClusterApiKey(ctx, apiKey)
+	if diags.HasError() {
+		return diags
+	}
+	// track the API key right away, so it's invalidated if the rest of the setup fails
+	d.SetId(id.String())
+	if err := d.Set("api_key_id", created.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	// the encoded API key is only returned once, it's kept in the state unless the secrets sink takes it
+	stored, diags := client.WriteSecret(ctx, clients.Secret{
+		Resource:  "elasticstack_elasticsearch_cross_cluster_search",
+		Id:        id.String(),
+		Attribute: "encoded_api_key",
+		Value:     created.Encoded,
+	})
+	if diags.HasError() {
+		return diags
+	}
+	if !stored {
+		if err := d.Set("encoded_api_key", created.Encoded); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if diags := client.PutElasticsearchSettings(ctx, remoteClusterSettings(d)); diags.HasError() {
+		return diags
+	}
+
+	diags = resourceCrossClusterSearchRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, checkRemoteClusterCredentials(d)...)
+}
+
+func resourceCrossClusterSearchUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteClient, err := clients.NewApiClientFromConnectionBlock(d, "remote_elasticsearch_connection", meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("api_key") {
+		apiKey, diags := expandCrossClusterApiKey(d)
+		if diags.HasError() {
+			return diags
+		}
+		apiKey.Id = d.Get("api_key_id").(string)
+		if diags := remoteClient.UpdateElasticsearchCrossClusterApiKey(ctx, apiKey); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChanges("mode", "seeds", "proxy_address", "server_name", "skip_unavailable") {
+		if diags := client.PutElasticsearchSettings(ctx, remoteClusterSettings(d)); diags.HasError() {
+			return diags
+		}
+	}
+
+	diags := resourceCrossClusterSearchRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, checkRemoteClusterCredentials(d)...)
+}
+
+func resourceCrossClusterSearchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteClient, err := clients.NewApiClientFromConnectionBlock(d, "remote_elasticsearch_connection", meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	alias := compId.ResourceId
+
+	apiKey, diags := remoteClient.GetElasticsearchCrossClusterApiKey(ctx, d.Get("api_key_id").(string))
+	if diags.HasError() {
+		return diags
+	}
+	if apiKey == nil || apiKey.Invalidated {
+		// without the API key the local cluster cannot connect anymore, the whole setup must be re-created
+		d.SetId("")
+		return diags
+	}
+
+	clusterSettings, diags := client.GetElasticsearchSettings(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	persistent, _ := clusterSettings["persistent"].(map[string]interface{})
+	prefix := fmt.Sprintf("cluster.remote.%s.", alias)
+	mode, hasMode := persistent[prefix+"mode"]
+	seeds, hasSeeds := persistent[prefix+"seeds"]
+	proxyAddress, hasProxy := persistent[prefix+"proxy_address"]
+	if !hasMode && !hasSeeds && !hasProxy {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("alias", alias); err != nil {
+		return diag.FromErr(err)
+	}
+	if hasMode {
+		if err := d.Set("mode", mode); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := d.Set("mode", "sniff"); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("seeds", seeds); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("proxy_address", proxyAddress); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("server_name", persistent[prefix+"server_name"]); err != nil {
+		return diag.FromErr(err)
+	}
+	skipUnavailable := false
+	if v, ok := persistent[prefix+"skip_unavailable"].(string); ok {
+		skipUnavailable, _ = strconv.ParseBool(v)
+	}
+	if err := d.Set("skip_unavailable", skipUnavailable); err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattenedKey, diags := flattenCrossClusterApiKey(d, apiKey)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("api_key", flattenedKey); err != nil {
+		return diag.FromErr(err)
+	}
+
+	remotes, diags := client.GetElasticsearchRemoteInfo(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	remote := remotes[alias]
+	if err := d.Set("connected", remote.Connected); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("credentials_configured", remote.ClusterCredentials != ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceCrossClusterSearchDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteClient, err := clients.NewApiClientFromConnectionBlock(d, "remote_elasticsearch_connection", meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	prefix := fmt.Sprintf("cluster.remote.%s.", compId.ResourceId)
+	settings := map[string]interface{}{
+		"persistent": map[string]interface{}{
+			prefix + "mode":             nil,
+			prefix + "seeds":            nil,
+			prefix + "proxy_address":    nil,
+			prefix + "server_name":      nil,
+			prefix + "skip_unavailable": nil,
+		},
+	}
+	if diags := client.PutElasticsearchSettings(ctx, settings); diags.HasError() {
+		return diags
+	}
+
+	if apiKeyId := d.Get("api_key_id").(string); apiKeyId != "" {
+		if diags := remoteClient.InvalidateElasticsearchApiKeys(ctx, []string{apiKeyId}); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// The settings of the remote cluster connection, the settings of the unused mode are removed
+func remoteClusterSettings(d *schema.ResourceData) map[string]interface{} {
+	prefix := fmt.Sprintf("cluster.remote.%s.", d.Get("alias").(string))
+	settings := map[string]interface{}{
+		prefix + "mode":             d.Get("mode").(string),
+		prefix + "skip_unavailable": d.Get("skip_unavailable").(bool),
+		prefix + "seeds":            nil,
+		prefix + "proxy_address":    nil,
+		prefix + "server_name":      nil,
+	}
+	if d.Get("mode").(string) == "proxy" {
+		settings[prefix+"proxy_address"] = d.Get("proxy_address").(string)
+		if v, ok := d.GetOk("server_name"); ok {
+			settings[prefix+"server_name"] = v.(string)
+		}
+	} else {
+		settings[prefix+"seeds"] = d.Get("seeds").([]interface{})
+	}
+	return map[string]interface{}{"persistent": settings}
+}
+
+// The encoded API key can only be stored in the keystore of the local cluster nodes, which is out of reach of the API,
+// so the user is told how to finish the setup until the credentials show up in the remote cluster info.
+func checkRemoteClusterCredentials(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if d.Get("credentials_configured").(bool) {
+		return diags
+	}
+	alias := d.Get("alias").(string)
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The credentials of the remote cluster '%s' are not configured", alias),
+		Detail: fmt.Sprintf(`The local cluster cannot authenticate to the remote cluster until the cross-cluster API key is added to its keystore.
+Add the value of the "encoded_api_key" attribute, or the key written into the secrets sink, to the keystore of every node of the local cluster:
+  bin/elasticsearch-keystore add cluster.remote.%s.credentials
+and reload the secure settings with: POST _nodes/reload_secure_settings
+The remote cluster must have the remote cluster server enabled (remote_cluster_server.enabled: true).`, alias),
+	})
+	return diags
+}
+
+func expandCrossClusterApiKey(d *schema.ResourceData) (*models.CrossClusterApiKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var apiKey models.CrossClusterApiKey
+
+	// there is always exactly one API key block
+	key := d.Get("api_key").([]interface{})[0].(map[string]interface{})
+	apiKey.Name = key["name"].(string)
+	apiKey.Expiration = key["expiration"].(string)
+	if v := key["metadata"].(string); v != "" {
+		metadata := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(v), &metadata); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		apiKey.Metadata = metadata
+	}
+
+	search := make([]models.CrossClusterApiKeyIndices, 0)
+	for _, s := range key["search"].([]interface{}) {
+		entry := s.(map[string]interface{})
+		indices := models.CrossClusterApiKeyIndices{}
+		for _, n := range entry["names"].(*schema.Set).List() {
+			indices.Names = append(indices.Names, n.(string))
+		}
+		if q := entry["query"].(string); q != "" {
+			indices.Query = &q
+		}
+		if fs := entry["field_security"].([]interface{}); len(fs) > 0 && fs[0] != nil {
+			fieldSecurity := fs[0].(map[string]interface{})
+			indices.FieldSecurity = &models.FieldSecurity{}
+			for _, g := range fieldSecurity["grant"].(*schema.Set).List() {
+				indices.FieldSecurity.Grant = append(indices.FieldSecurity.Grant, g.(string))
+			}
+			for _, e := range fieldSecurity["except"].(*schema.Set).List() {
+				indices.FieldSecurity.Except = append(indices.FieldSecurity.Except, e.(string))
+			}
+		}
+		if allow := entry["allow_restricted_indices"].(bool); allow {
+			indices.AllowRestrictedIndices = &allow
+		}
+		search = append(search, indices)
+	}
+	apiKey.Access.Search = search
+
+	replication := make([]models.CrossClusterApiKeyIndices, 0)
+	for _, r := range key["replication"].([]interface{}) {
+		entry := r.(map[string]interface{})
+		indices := models.CrossClusterApiKeyIndices{}
+		for _, n := range entry["names"].(*schema.Set).List() {
+			indices.Names = append(indices.Names, n.(string))
+		}
+		replication = append(replication, indices)
+	}
+	apiKey.Access.Replication = replication
+
+	if len(search) == 0 && len(replication) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing the access of the cross-cluster API key",
+			Detail:   "At least one `search` or `replication` block must be defined in the `api_key` block.",
+		})
+		return nil, diags
+	}
+
+	return &apiKey, diags
+}
+
+func flattenCrossClusterApiKey(d *schema.ResourceData, apiKey *models.CrossClusterApiKey) ([]interface{}, diag.Diagnostics) {
+	key := make(map[string]interface{})
+	key["name"] = apiKey.Name
+	// the expiration is only known as the absolute timestamp, keep the configured duration
+	key["expiration"] = d.Get("api_key.0.expiration")
+	if len(apiKey.Metadata) > 0 {
+		metadata, err := json.Marshal(apiKey.Metadata)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		key["metadata"] = string(metadata)
+	}
+
+	search := make([]interface{}, len(apiKey.Access.Search))
+	for i, s := range apiKey.Access.Search {
+		entry := make(map[string]interface{})
+		entry["names"] = s.Names
+		if s.Query != nil {
+			entry["query"] = *s.Query
+		}
+		if s.FieldSecurity != nil {
+			entry["field_security"] = []interface{}{
+				map[string]interface{}{
+					"grant":  s.FieldSecurity.Grant,
+					"except": s.FieldSecurity.Except,
+				},
+			}
+		}
+		if s.AllowRestrictedIndices != nil {
+			entry["allow_restricted_indices"] = *s.AllowRestrictedIndices
+		}
+		search[i] = entry
+	}
+	key["search"] = search
+
+	replication := make([]interface{}, len(apiKey.Access.Replication))
+	for i, r := range apiKey.Access.Replication {
+		replication[i] = map[string]interface{}{"names": r.Names}
+	}
+	key["replication"] = replication
+
+	return []interface{}{key}, nil
+}
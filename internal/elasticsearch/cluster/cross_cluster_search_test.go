@@ -0,0 +1,139 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceCrossClusterSearch(t *testing.T) {
+	// generate a random name
+	alias := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceCrossClusterSearchDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceCrossClusterSearchCreate(alias),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "alias", alias),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "mode", "sniff"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "api_key.0.search.#", "1"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_cross_cluster_search.test", "api_key_id"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_cross_cluster_search.test", "encoded_api_key"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "credentials_configured", "false"),
+				),
+			},
+			{
+				Config: testAccResourceCrossClusterSearchUpdate(alias),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "mode", "proxy"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "proxy_address", "localhost:9443"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "skip_unavailable", "true"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "api_key.0.search.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cross_cluster_search.test", "api_key.0.replication.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// the same cluster plays the role of both the local and the remote cluster
+func testAccRemoteConnection() string {
+	endpoints := strings.Split(os.Getenv("ELASTICSEARCH_ENDPOINTS"), ",")
+	return fmt.Sprintf(`
+  remote_elasticsearch_connection {
+    endpoints = ["%s"]
+    username  = "%s"
+    password  = "%s"
+  }`, strings.Join(endpoints, `", "`), os.Getenv("ELASTICSEARCH_USERNAME"), os.Getenv("ELASTICSEARCH_PASSWORD"))
+}
+
+func testAccResourceCrossClusterSearchCreate(alias string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_cross_cluster_search" "test" {
+  alias = "%[1]s"
+  seeds = ["localhost:9443"]
+
+  api_key {
+    name = "%[1]s-ccs"
+
+    search {
+      names = ["logs-*"]
+    }
+  }
+%[2]s
+}
+	`, alias, testAccRemoteConnection())
+}
+
+func testAccResourceCrossClusterSearchUpdate(alias string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_cross_cluster_search" "test" {
+  alias            = "%[1]s"
+  mode             = "proxy"
+  proxy_address    = "localhost:9443"
+  skip_unavailable = true
+
+  api_key {
+    name = "%[1]s-ccs"
+
+    search {
+      names = ["logs-*", "metrics-*"]
+      query = jsonencode({ term = { "host.name" = "web-1" } })
+    }
+
+    replication {
+      names = ["archive-*"]
+    }
+  }
+%[2]s
+}
+	`, alias, testAccRemoteConnection())
+}
+
+func checkResourceCrossClusterSearchDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_cross_cluster_search" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		remotes, diags := client.GetElasticsearchRemoteInfo(context.Background())
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get remote clusters info: %v", diags)
+		}
+		if _, ok := remotes[compId.ResourceId]; ok {
+			return fmt.Errorf("Remote cluster (%s) still exists", compId.ResourceId)
+		}
+
+		apiKey, diags := client.GetElasticsearchCrossClusterApiKey(context.Background(), rs.Primary.Attributes["api_key_id"])
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get API key: %v", diags)
+		}
+		if apiKey != nil && !apiKey.Invalidated {
+			return fmt.Errorf("Cross-cluster API key (%s) is still valid", rs.Primary.Attributes["api_key_id"])
+		}
+	}
+	return nil
+}
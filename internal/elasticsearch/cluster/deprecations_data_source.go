@@ -0,0 +1,184 @@
+package cluster
+
+import (
+	"context"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// the categories of the deprecations returned by the API
+var deprecationCategories = []string{"cluster_settings", "node_settings", "ml_settings", "index_settings", "data_streams", "templates", "ilm_policies"}
+
+func DataSourceDeprecations() *schema.Resource {
+	deprecationsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Only check the matching indices, wildcards are supported, e.g. `logs-*`. All the indices are checked by default.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"categories": {
+			Description: "Only return the deprecations of the categories, all of them by default.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(deprecationCategories, false),
+			},
+		},
+		"deprecations": {
+			Description: "The deprecated features in use, sorted by category and name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"category": {
+						Description: "The category of the deprecation, one of: `cluster_settings`, `node_settings`, `ml_settings`, `index_settings`, `data_streams`, `templates` or `ilm_policies`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"name": {
+						Description: "The name of the index, data stream, template or ILM policy using the deprecated feature, empty for the settings of the cluster, the nodes and ML.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"level": {
+						Description: "The severity of the deprecation, `critical` when the upgrade fails until it's resolved, otherwise `warning`, `info` or `none`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"message": {
+						Description: "The description of the deprecation.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"url": {
+						Description: "The link to the documentation of the deprecation.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"details": {
+						Description: "The details of the deprecation, e.g. the deprecated settings in use.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"resolve_during_rolling_upgrade": {
+						Description: "Whether the deprecation can be resolved during the rolling upgrade.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"critical_count": {
+			Description: "The number of the critical deprecations, which must be resolved before the upgrade.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"warning_count": {
+			Description: "The number of the warning deprecations.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(deprecationsSchema)
+
+	return &schema.Resource{
+		Description: "Lists the deprecated features used by the cluster, which are removed or changed in the next major version, e.g. to gate the upgrade on no critical deprecation. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/migration-api-deprecation.html",
+
+		ReadContext: dataSourceDeprecationsRead,
+
+		Schema: deprecationsSchema,
+	}
+}
+
+func dataSourceDeprecationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	index := d.Get("index").(string)
+	id, diags := client.ID(ctx, "deprecations-"+index)
+	if diags.HasError() {
+		return diags
+	}
+
+	response, diags := client.GetElasticsearchDeprecations(ctx, index)
+	if diags.HasError() {
+		return diags
+	}
+
+	categories := make(map[string]bool)
+	for _, c := range d.Get("categories").(*schema.Set).List() {
+		categories[c.(string)] = true
+	}
+	deprecations := flattenDeprecations(response, categories)
+
+	counts := make(map[string]int)
+	for _, dep := range deprecations {
+		counts[dep.(map[string]interface{})["level"].(string)]++
+	}
+	if err := d.Set("deprecations", deprecations); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("critical_count", counts["critical"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("warning_count", counts["warning"]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// Flattens the deprecations of the categories, all of them when none is given, in the order of the categories and of the names
+func flattenDeprecations(response *models.DeprecationsResponse, categories map[string]bool) []interface{} {
+	byCategory := map[string]map[string][]models.Deprecation{
+		"cluster_settings": {"": response.ClusterSettings},
+		"node_settings":    {"": response.NodeSettings},
+		"ml_settings":      {"": response.MlSettings},
+		"index_settings":   response.IndexSettings,
+		"data_streams":     response.DataStreams,
+		"templates":        response.Templates,
+		"ilm_policies":     response.IlmPolicies,
+	}
+
+	result := make([]interface{}, 0)
+	for _, category := range deprecationCategories {
+		if len(categories) > 0 && !categories[category] {
+			continue
+		}
+		byName := byCategory[category]
+		names := make([]string, 0, len(byName))
+		for name := range byName {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, dep := range byName[name] {
+				result = append(result, map[string]interface{}{
+					"category":                       category,
+					"name":                           name,
+					"level":                          dep.Level,
+					"message":                        dep.Message,
+					"url":                            dep.Url,
+					"details":                        dep.Details,
+					"resolve_during_rolling_upgrade": dep.ResolveDuringRollingUpgrade,
+				})
+			}
+		}
+	}
+	return result
+}
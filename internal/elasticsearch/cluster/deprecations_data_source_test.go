@@ -0,0 +1,39 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDeprecations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDeprecations,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_deprecations.test", "id"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_deprecations.test", "deprecations.#"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_deprecations.test", "critical_count"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_deprecations.test", "warning_count"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_deprecations.cluster", "categories.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDeprecations = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_deprecations" "test" {}
+
+data "elasticstack_elasticsearch_deprecations" "cluster" {
+  categories = ["cluster_settings"]
+}
+`
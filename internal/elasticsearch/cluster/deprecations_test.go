@@ -0,0 +1,41 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+)
+
+func TestFlattenDeprecations(t *testing.T) {
+	response := &models.DeprecationsResponse{
+		ClusterSettings: []models.Deprecation{{Level: "warning", Message: "cluster"}},
+		IndexSettings: map[string][]models.Deprecation{
+			"logs-b": {{Level: "critical", Message: "index b"}},
+			"logs-a": {{Level: "warning", Message: "index a"}},
+		},
+		IlmPolicies: map[string][]models.Deprecation{
+			"policy": {{Level: "critical", Message: "policy"}},
+		},
+	}
+
+	tests := []struct {
+		categories map[string]bool
+		expected   []string
+	}{
+		{map[string]bool{}, []string{"cluster", "index a", "index b", "policy"}},
+		{map[string]bool{"index_settings": true}, []string{"index a", "index b"}},
+		{map[string]bool{"templates": true}, []string{}},
+	}
+
+	for _, tc := range tests {
+		deprecations := flattenDeprecations(response, tc.categories)
+		if len(deprecations) != len(tc.expected) {
+			t.Fatalf("categories %v: expected %d deprecations, got %v", tc.categories, len(tc.expected), deprecations)
+		}
+		for i, dep := range deprecations {
+			if message := dep.(map[string]interface{})["message"]; message != tc.expected[i] {
+				t.Errorf("categories %v: expected the deprecation %d to be %s, got %s", tc.categories, i, tc.expected[i], message)
+			}
+		}
+	}
+}
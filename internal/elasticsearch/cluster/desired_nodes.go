@@ -0,0 +1,290 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceDesiredNodes() *schema.Resource {
+	nodeSchema := map[string]*schema.Schema{
+		"settings": {
+			Description:      "The settings of the node as JSON, it must contain at least the `node.name`, e.g. `jsonencode({ \"node.name\" = \"instance-1\", \"node.roles\" = [\"data_hot\"] })`.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: diffDesiredNodeSettingsSuppress,
+		},
+		"processors": {
+			Description: "The number of processors of the node. Either `processors` or `processors_range` must be set.",
+			Type:        schema.TypeFloat,
+			Optional:    true,
+		},
+		"processors_range": {
+			Description: "The range of the number of processors of the node.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"min": {
+						Description: "The minimum number of processors.",
+						Type:        schema.TypeFloat,
+						Required:    true,
+					},
+					"max": {
+						Description: "The maximum number of processors.",
+						Type:        schema.TypeFloat,
+						Optional:    true,
+					},
+				},
+			},
+		},
+		"memory": {
+			Description: "The memory of the node, e.g. `64gb`.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"storage": {
+			Description: "The storage of the node, e.g. `1tb`.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"node_version": {
+			Description: "The version of Elasticsearch of the node. Required before Elasticsearch 8.13, deprecated since.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	}
+
+	desiredNodesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"history_id": {
+			Description: "The identifier of the history of the desired nodes, e.g. the ID of the deployment. The desired nodes are versioned within the history.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"node": {
+			Description: "The nodes the cluster is expected to consist of.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: nodeSchema,
+			},
+		},
+		"version": {
+			Description: "The version of the desired nodes within the history, incremented on every update.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(desiredNodesSchema)
+
+	return &schema.Resource{
+		Description: "Manages the desired nodes of the cluster, which let Elasticsearch plan the shard allocation ahead of the nodes joining or leaving the cluster, e.g. during the controlled decommissioning. This is an internal API intended for the orchestration systems. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/update-desired-nodes.html",
+
+		CreateContext: resourceDesiredNodesPut,
+		UpdateContext: resourceDesiredNodesPut,
+		ReadContext:   resourceDesiredNodesRead,
+		DeleteContext: resourceDesiredNodesDelete,
+
+		CustomizeDiff: validateDesiredNodesProcessors,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: desiredNodesSchema,
+	}
+}
+
+func validateDesiredNodesProcessors(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("node") {
+		return nil
+	}
+	for i, n := range d.Get("node").([]interface{}) {
+		node := n.(map[string]interface{})
+		hasProcessors := node["processors"].(float64) != 0
+		hasRange := len(node["processors_range"].([]interface{})) > 0
+		if hasProcessors == hasRange {
+			return fmt.Errorf("exactly one of processors or processors_range must be set in node.%d", i)
+		}
+	}
+	return nil
+}
+
+// The settings are returned in the nested form, with all the values as strings
+func diffDesiredNodeSettingsSuppress(k, old, new string, d *schema.ResourceData) bool {
+	var o, n map[string]interface{}
+	if err := json.Unmarshal([]byte(old), &o); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &n); err != nil {
+		return false
+	}
+	return utils.MapsEqual(stringifySettings(utils.FlattenMap(o)), stringifySettings(utils.FlattenMap(n)))
+}
+
+func stringifySettings(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = fmt.Sprintf("%v", v)
+	}
+	return out
+}
+
+func resourceDesiredNodesPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	historyId := d.Get("history_id").(string)
+	id, diags := client.ID(ctx, historyId)
+	if diags.HasError() {
+		return diags
+	}
+
+	desiredNodes := models.DesiredNodes{HistoryId: historyId, Version: 1}
+	// the version must be increased on every update within the same history
+	current, diags := client.GetElasticsearchDesiredNodes(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if current != nil && current.HistoryId == historyId {
+		desiredNodes.Version = current.Version + 1
+	}
+
+	for _, n := range d.Get("node").([]interface{}) {
+		node, diags := expandDesiredNode(n.(map[string]interface{}))
+		if diags.HasError() {
+			return diags
+		}
+		desiredNodes.Nodes = append(desiredNodes.Nodes, node)
+	}
+
+	if diags := client.PutElasticsearchDesiredNodes(ctx, &desiredNodes); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceDesiredNodesRead(ctx, d, meta)
+}
+
+func resourceDesiredNodesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	desiredNodes, diags := client.GetElasticsearchDesiredNodes(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	// the desired nodes were deleted or replaced by another history
+	if desiredNodes == nil || desiredNodes.HistoryId != compId.ResourceId {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("history_id", desiredNodes.HistoryId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", desiredNodes.Version); err != nil {
+		return diag.FromErr(err)
+	}
+	nodes := make([]interface{}, len(desiredNodes.Nodes))
+	for i, n := range desiredNodes.Nodes {
+		node, diags := flattenDesiredNode(n)
+		if diags.HasError() {
+			return diags
+		}
+		nodes[i] = node
+	}
+	if err := d.Set("node", nodes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDesiredNodesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := client.DeleteElasticsearchDesiredNodes(ctx); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandDesiredNode(node map[string]interface{}) (models.DesiredNode, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	n := models.DesiredNode{
+		Memory:      node["memory"].(string),
+		Storage:     node["storage"].(string),
+		NodeVersion: node["node_version"].(string),
+	}
+	if err := json.Unmarshal([]byte(node["settings"].(string)), &n.Settings); err != nil {
+		return n, diag.FromErr(err)
+	}
+	if p := node["processors"].(float64); p != 0 {
+		n.Processors = &p
+	}
+	if r := node["processors_range"].([]interface{}); len(r) > 0 && r[0] != nil {
+		processorsRange := r[0].(map[string]interface{})
+		n.ProcessorsRange = &models.DesiredNodeProcessors{Min: processorsRange["min"].(float64)}
+		if max := processorsRange["max"].(float64); max != 0 {
+			n.ProcessorsRange.Max = &max
+		}
+	}
+	return n, diags
+}
+
+func flattenDesiredNode(n models.DesiredNode) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	settings, err := json.Marshal(n.Settings)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	node := map[string]interface{}{
+		"settings":     string(settings),
+		"memory":       n.Memory,
+		"storage":      n.Storage,
+		"node_version": n.NodeVersion,
+	}
+	if n.Processors != nil {
+		node["processors"] = *n.Processors
+	}
+	if n.ProcessorsRange != nil {
+		processorsRange := map[string]interface{}{"min": n.ProcessorsRange.Min}
+		if n.ProcessorsRange.Max != nil {
+			processorsRange["max"] = *n.ProcessorsRange.Max
+		}
+		node["processors_range"] = []interface{}{processorsRange}
+	}
+	return node, diags
+}
@@ -0,0 +1,84 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceDesiredNodes(t *testing.T) {
+	historyId := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceDesiredNodesDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceDesiredNodes(historyId, `processors = 8`+"\n"+`processors_range { min = 4 }`),
+				ExpectError: regexp.MustCompile("exactly one of processors or processors_range must be set"),
+			},
+			{
+				Config: testAccResourceDesiredNodes(historyId, `processors = 8`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_desired_nodes.test", "history_id", historyId),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_desired_nodes.test", "version", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_desired_nodes.test", "node.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_desired_nodes.test", "node.0.processors", "8"),
+				),
+			},
+			{
+				Config: testAccResourceDesiredNodes(historyId, `processors_range {`+"\n"+`min = 4`+"\n"+`max = 8`+"\n"+`}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_desired_nodes.test", "version", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_desired_nodes.test", "node.0.processors_range.0.min", "4"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_desired_nodes.test", "node.0.processors_range.0.max", "8"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceDesiredNodes(historyId, processors string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_desired_nodes" "test" {
+  history_id = "%s"
+
+  node {
+    settings = jsonencode({
+      node = {
+        name  = "instance-1"
+        roles = ["data_hot", "master"]
+      }
+    })
+    memory  = "64gb"
+    storage = "1tb"
+    %s
+  }
+}
+	`, historyId, processors)
+}
+
+func checkResourceDesiredNodesDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	desiredNodes, diags := client.GetElasticsearchDesiredNodes(context.Background())
+	if diags.HasError() {
+		return fmt.Errorf("Failed to get the desired nodes: %v", diags)
+	}
+	if desiredNodes != nil {
+		return fmt.Errorf("The desired nodes (%s) still exist", desiredNodes.HistoryId)
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// the resources of the provider, which can be imported, keyed by the short names used by the data source
+var importableResourceTypes = map[string]string{
+	"index_lifecycle":    "elasticstack_elasticsearch_index_lifecycle",
+	"index_template":     "elasticstack_elasticsearch_index_template",
+	"component_template": "elasticstack_elasticsearch_component_template",
+	"security_role":      "elasticstack_elasticsearch_security_role",
+	"security_user":      "elasticstack_elasticsearch_security_user",
+	"ingest_pipeline":    "elasticstack_elasticsearch_ingest_pipeline",
+}
+
+// The object found in the cluster, which may be imported into the resource of the type
+type importableObject struct {
+	resourceType string
+	name         string
+	managed      bool
+}
+
+func DataSourceImportBlocks() *schema.Resource {
+	importBlocksSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"resource_types": {
+			Description: "Only import the objects of the resource types, all of them by default: " + strings.Join(sortedResourceTypes(), ", ") + ".",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(sortedResourceTypes(), false),
+			},
+		},
+		"name_pattern": {
+			Description: "Only import the objects with the matching name, wildcards (`*` and `?`) are supported, e.g. `team-*`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "*",
+		},
+		"include_managed": {
+			Description: "Also import the objects managed by Elasticsearch or the Elastic stack, i.e. the reserved roles and users, the hidden objects with the names starting with `.`, and the objects with `managed: true` in their metadata.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"imports": {
+			Description: "The objects to import, sorted by the resource type and the name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"resource_type": {
+						Description: "The type of the resource to import the object into, e.g. `elasticstack_elasticsearch_index_lifecycle`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"name": {
+						Description: "The name of the object.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"address": {
+						Description: "The address of the resource to import the object to, its name is the name of the object turned into a unique identifier.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"import_id": {
+						Description: "The ID to import the object with.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"hcl": {
+			Description: "The `import` blocks of all the objects, to be written to a `.tf` file.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(importBlocksSchema)
+
+	return &schema.Resource{
+		Description: "Generates the `import` blocks of the existing objects of the cluster, i.e. the lifecycle policies, the index and component templates, the roles, the users and the ingest pipelines, to bring them under the management of Terraform.",
+
+		ReadContext: dataSourceImportBlocksRead,
+
+		Schema: importBlocksSchema,
+	}
+}
+
+func dataSourceImportBlocksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pattern := d.Get("name_pattern").(string)
+	id, diags := client.ID(ctx, "import-blocks-"+pattern)
+	if diags.HasError() {
+		return diags
+	}
+
+	resourceTypes := make(map[string]bool)
+	if v, ok := d.GetOk("resource_types"); ok {
+		for _, t := range v.(*schema.Set).List() {
+			resourceTypes[t.(string)] = true
+		}
+	} else {
+		for t := range importableResourceTypes {
+			resourceTypes[t] = true
+		}
+	}
+
+	objects, diags := getImportableObjects(ctx, client, resourceTypes)
+	if diags.HasError() {
+		return diags
+	}
+	includeManaged := d.Get("include_managed").(bool)
+	selected := make([]importableObject, 0, len(objects))
+	for _, object := range objects {
+		if !utils.MatchesWildcard(pattern, object.name) || (object.managed && !includeManaged) {
+			continue
+		}
+		selected = append(selected, object)
+	}
+
+	// the import IDs share the cluster UUID of the data source ID, so the cluster is requested once
+	imports := importBlocks(selected, id.ClusterId)
+	if err := d.Set("imports", imports); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("hcl", importBlocksHcl(imports)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// Lists the objects of the resource types found in the cluster
+func getImportableObjects(ctx context.Context, client *clients.ApiClient, resourceTypes map[string]bool) ([]importableObject, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	objects := make([]importableObject, 0)
+	if resourceTypes["index_lifecycle"] {
+		policies, diags := client.GetElasticsearchIlms(ctx)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for name, policy := range policies {
+			objects = append(objects, importableObject{"index_lifecycle", name, isManagedObject(name, policy.Policy.Metadata)})
+		}
+	}
+	if resourceTypes["index_template"] {
+		templates, diags := client.GetElasticsearchIndexTemplates(ctx)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for _, template := range templates {
+			objects = append(objects, importableObject{"index_template", template.Name, isManagedObject(template.Name, template.IndexTemplate.Meta)})
+		}
+	}
+	if resourceTypes["component_template"] {
+		templates, diags := client.GetElasticsearchComponentTemplates(ctx)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for _, template := range templates {
+			objects = append(objects, importableObject{"component_template", template.Name, isManagedObject(template.Name, template.ComponentTemplate.Meta)})
+		}
+	}
+	if resourceTypes["security_role"] {
+		roles, diags := client.GetElasticsearchRoles(ctx)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for name, role := range roles {
+			objects = append(objects, importableObject{"security_role", name, isManagedObject(name, role.Metadata)})
+		}
+	}
+	if resourceTypes["security_user"] {
+		users, diags := client.GetElasticsearchUsers(ctx)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for name, user := range users {
+			objects = append(objects, importableObject{"security_user", name, isManagedObject(name, user.Metadata)})
+		}
+	}
+	if resourceTypes["ingest_pipeline"] {
+		pipelines, diags := client.GetElasticsearchIngestPipelines(ctx)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for name, pipeline := range pipelines {
+			objects = append(objects, importableObject{"ingest_pipeline", name, isManagedObject(name, pipeline.Metadata)})
+		}
+	}
+	return objects, diags
+}
+
+// The hidden objects, the ones marked as managed by the stack, and the reserved roles and users are not meant to be changed
+func isManagedObject(name string, metadata map[string]interface{}) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+	managed, _ := metadata["managed"].(bool)
+	reserved, _ := metadata["_reserved"].(bool)
+	return managed || reserved
+}
+
+// Builds the imports of the objects sorted by the resource type and the name, the names of the resources are unique per type
+func importBlocks(objects []importableObject, clusterId string) []interface{} {
+	sorted := append([]importableObject{}, objects...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].resourceType != sorted[j].resourceType {
+			return importableResourceTypes[sorted[i].resourceType] < importableResourceTypes[sorted[j].resourceType]
+		}
+		return sorted[i].name < sorted[j].name
+	})
+
+	imports := make([]interface{}, 0, len(sorted))
+	addresses := make(map[string]bool)
+	for _, object := range sorted {
+		resourceType := importableResourceTypes[object.resourceType]
+		label := resourceLabel(object.name)
+		address := resourceType + "." + label
+		for i := 2; addresses[address]; i++ {
+			address = fmt.Sprintf("%s.%s_%d", resourceType, label, i)
+		}
+		addresses[address] = true
+		importId := clients.CompositeId{ClusterId: clusterId, ResourceId: object.name}
+		imports = append(imports, map[string]interface{}{
+			"resource_type": resourceType,
+			"name":          object.name,
+			"address":       address,
+			"import_id":     importId.String(),
+		})
+	}
+	return imports
+}
+
+// Turns the name of the object into the name of the resource, which starts with a letter or an underscore
+// and contains only letters, digits, underscores and dashes
+func resourceLabel(name string) string {
+	var label strings.Builder
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			label.WriteRune(r)
+		} else {
+			label.WriteRune('_')
+		}
+	}
+	l := label.String()
+	if l == "" || !((l[0] >= 'a' && l[0] <= 'z') || (l[0] >= 'A' && l[0] <= 'Z') || l[0] == '_') {
+		l = "_" + l
+	}
+	return l
+}
+
+func importBlocksHcl(imports []interface{}) string {
+	var hcl strings.Builder
+	for i, imp := range imports {
+		block := imp.(map[string]interface{})
+		if i > 0 {
+			hcl.WriteString("\n")
+		}
+		fmt.Fprintf(&hcl, "import {\n  to = %s\n  id = %s\n}\n", block["address"], hclString(block["import_id"].(string)))
+	}
+	return hcl.String()
+}
+
+// Quotes the string, escaping the template sequences too, so the names are never interpolated
+func hclString(s string) string {
+	quoted := fmt.Sprintf("%q", s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+func sortedResourceTypes() []string {
+	types := make([]string, 0, len(importableResourceTypes))
+	for t := range importableResourceTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
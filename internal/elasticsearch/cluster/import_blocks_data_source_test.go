@@ -0,0 +1,54 @@
+package cluster_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceImportBlocks(t *testing.T) {
+	policyName := "test_" + sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceImportBlocks(policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_import_blocks.test", "imports.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_import_blocks.test", "imports.0.resource_type", "elasticstack_elasticsearch_index_lifecycle"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_import_blocks.test", "imports.0.name", policyName),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_import_blocks.test", "imports.0.address", "elasticstack_elasticsearch_index_lifecycle."+policyName),
+					resource.TestCheckResourceAttrPair("data.elasticstack_elasticsearch_import_blocks.test", "imports.0.import_id", "elasticstack_elasticsearch_index_lifecycle.test", "id"),
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_import_blocks.test", "hcl", regexp.MustCompile(`to = elasticstack_elasticsearch_index_lifecycle\.`+policyName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceImportBlocks(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  delete {
+    min_age = "30d"
+    delete {}
+  }
+}
+
+data "elasticstack_elasticsearch_import_blocks" "test" {
+  resource_types = ["index_lifecycle"]
+  name_pattern   = elasticstack_elasticsearch_index_lifecycle.test.name
+}
+`, name)
+}
@@ -0,0 +1,51 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportBlocks(t *testing.T) {
+	objects := []importableObject{
+		{resourceType: "security_role", name: "team-a"},
+		{resourceType: "index_lifecycle", name: "logs.warm"},
+		{resourceType: "index_lifecycle", name: "logs_warm"},
+		{resourceType: "ingest_pipeline", name: "1-parse"},
+	}
+	imports := importBlocks(objects, "cluster-uuid")
+
+	expected := []interface{}{
+		map[string]interface{}{"resource_type": "elasticstack_elasticsearch_index_lifecycle", "name": "logs.warm", "address": "elasticstack_elasticsearch_index_lifecycle.logs_warm", "import_id": "cluster-uuid/logs.warm"},
+		map[string]interface{}{"resource_type": "elasticstack_elasticsearch_index_lifecycle", "name": "logs_warm", "address": "elasticstack_elasticsearch_index_lifecycle.logs_warm_2", "import_id": "cluster-uuid/logs_warm"},
+		map[string]interface{}{"resource_type": "elasticstack_elasticsearch_ingest_pipeline", "name": "1-parse", "address": "elasticstack_elasticsearch_ingest_pipeline._1-parse", "import_id": "cluster-uuid/1-parse"},
+		map[string]interface{}{"resource_type": "elasticstack_elasticsearch_security_role", "name": "team-a", "address": "elasticstack_elasticsearch_security_role.team-a", "import_id": "cluster-uuid/team-a"},
+	}
+	if !reflect.DeepEqual(imports, expected) {
+		t.Errorf("expected the imports %v, got %v", expected, imports)
+	}
+
+	hcl := importBlocksHcl(imports[:1])
+	if expectedHcl := "import {\n  to = elasticstack_elasticsearch_index_lifecycle.logs_warm\n  id = \"cluster-uuid/logs.warm\"\n}\n"; hcl != expectedHcl {
+		t.Errorf("expected the HCL %q, got %q", expectedHcl, hcl)
+	}
+}
+
+func TestIsManagedObject(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		expected bool
+	}{
+		{"logs", nil, false},
+		{".kibana", nil, true},
+		{"logs@lifecycle", map[string]interface{}{"managed": true}, true},
+		{"superuser", map[string]interface{}{"_reserved": true}, true},
+		{"team", map[string]interface{}{"managed": "no"}, false},
+	}
+
+	for _, tc := range tests {
+		if managed := isManagedObject(tc.name, tc.metadata); managed != tc.expected {
+			t.Errorf("%s: expected managed %v, got %v", tc.name, tc.expected, managed)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceLicense() *schema.Resource {
+	licenseSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"license": {
+			Description:      "The license issued by Elastic as JSON, with either the `license` or the `licenses` key, e.g. `file(\"license.json\")`.",
+			Type:             schema.TypeString,
+			Required:         true,
+			Sensitive:        true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"acknowledge": {
+			Description: "Acknowledge the changes of the features available with the new license, e.g. when the license is downgraded. Without it, Elasticsearch doesn't install such license.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+	}
+	for k, v := range licenseInfoSchema() {
+		licenseSchema[k] = v
+	}
+
+	utils.AddConnectionSchema(licenseSchema)
+
+	return &schema.Resource{
+		Description: "Installs the license of the cluster. Destroying the resource deletes the license, and the cluster falls back to the basic license. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/update-license.html",
+
+		CreateContext: resourceLicensePut,
+		UpdateContext: resourceLicensePut,
+		ReadContext:   resourceLicenseRead,
+		DeleteContext: resourceLicenseDelete,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: licenseSchema,
+	}
+}
+
+// The details of the installed license, shared with the data source
+func licenseInfoSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"uid": {
+			Description: "The identifier of the license.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"type": {
+			Description: "The type of the license, e.g. `basic`, `trial`, `platinum` or `enterprise`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"status": {
+			Description: "The status of the license: `active`, `valid`, `invalid` or `expired`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"issue_date": {
+			Description: "The date the license was issued.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"expiry_date": {
+			Description: "The date the license expires, empty if the license never expires.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"max_nodes": {
+			Description: "The maximum number of nodes the license allows, `0` for the licenses limiting the resource units instead.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"max_resource_units": {
+			Description: "The maximum number of the resource units the enterprise license allows.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"issued_to": {
+			Description: "The name of the organization the license was issued to.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func resourceLicensePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "license")
+	if diags.HasError() {
+		return diags
+	}
+
+	// the license has no version to replace it conditionally, so it's compared with the license read last time
+	if !d.IsNewResource() {
+		current, diags := client.GetElasticsearchLicense(ctx)
+		if diags.HasError() {
+			return diags
+		}
+		if uid := d.Get("uid").(string); current != nil && current.Uid != uid {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "The license was changed concurrently",
+				Detail:   fmt.Sprintf(`The license "%s" was replaced with "%s" since it was last read, e.g. by another Terraform workspace, and it is not overwritten. Refresh the state and review the plan again.`, uid, current.Uid),
+			}}
+		}
+	}
+
+	response, diags := client.PutElasticsearchLicense(ctx, d.Get("license").(string), d.Get("acknowledge").(bool))
+	if diags.HasError() {
+		return diags
+	}
+	if !response.Acknowledged {
+		messages, _ := json.Marshal(response.Acknowledge)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "The license must be acknowledged",
+			Detail:   fmt.Sprintf("The license changes the features available in the cluster, set acknowledge = true to install it anyway: %s", messages),
+		}}
+	}
+	if response.LicenseStatus != "valid" {
+		return diag.Errorf("The license was not installed, its status is: %s", response.LicenseStatus)
+	}
+
+	d.SetId(id.String())
+	return resourceLicenseRead(ctx, d, meta)
+}
+
+func resourceLicenseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	license, diags := client.GetElasticsearchLicense(ctx)
+	if license == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+	// the license was replaced outside of Terraform
+	if uid := configuredLicenseUid(d.Get("license").(string)); uid != "" && uid != license.Uid {
+		d.SetId("")
+		return diags
+	}
+
+	return setLicenseInfo(d, license)
+}
+
+func resourceLicenseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := client.DeleteElasticsearchLicense(ctx); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// Returns the UID of the license document, which has either the single `license` or the `licenses` list
+func configuredLicenseUid(license string) string {
+	var doc struct {
+		License  *models.License  `json:"license"`
+		Licenses []models.License `json:"licenses"`
+	}
+	if err := json.Unmarshal([]byte(license), &doc); err != nil {
+		return ""
+	}
+	if doc.License != nil {
+		return doc.License.Uid
+	}
+	if len(doc.Licenses) > 0 {
+		return doc.Licenses[0].Uid
+	}
+	return ""
+}
+
+func setLicenseInfo(d *schema.ResourceData, license *models.License) diag.Diagnostics {
+	var diags diag.Diagnostics
+	maxNodes, maxResourceUnits := 0, 0
+	if license.MaxNodes != nil {
+		maxNodes = *license.MaxNodes
+	}
+	if license.MaxResourceUnits != nil {
+		maxResourceUnits = *license.MaxResourceUnits
+	}
+	for attr, value := range map[string]interface{}{
+		"uid":                license.Uid,
+		"type":               license.Type,
+		"status":             license.Status,
+		"issue_date":         license.IssueDate,
+		"expiry_date":        license.ExpiryDate,
+		"max_nodes":          maxNodes,
+		"max_resource_units": maxResourceUnits,
+		"issued_to":          license.IssuedTo,
+	} {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceLicense() *schema.Resource {
+	licenseSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+	for k, v := range licenseInfoSchema() {
+		licenseSchema[k] = v
+	}
+
+	utils.AddConnectionSchema(licenseSchema)
+
+	return &schema.Resource{
+		Description: "Gets the license of the cluster, e.g. to create the resources requiring the paid features only when the license allows them. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/get-license.html",
+
+		ReadContext: dataSourceLicenseRead,
+
+		Schema: licenseSchema,
+	}
+}
+
+func dataSourceLicenseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "license")
+	if diags.HasError() {
+		return diags
+	}
+
+	license, diags := client.GetElasticsearchLicense(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if license == nil {
+		return diag.Errorf("The cluster has no license")
+	}
+	if diags := setLicenseInfo(d, license); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return diags
+}
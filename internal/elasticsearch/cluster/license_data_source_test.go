@@ -0,0 +1,33 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLicense(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceLicense,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_license.test", "uid"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_license.test", "type"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_license.test", "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceLicense = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_license" "test" {}
+`
@@ -0,0 +1,44 @@
+package cluster_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceLicense(t *testing.T) {
+	// only the license signed by Elastic can be installed, so only the rejection of the invalid one is tested
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceLicenseInvalid,
+				ExpectError: regexp.MustCompile(`Unable to install the license`),
+			},
+		},
+	})
+}
+
+const testAccResourceLicenseInvalid = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_license" "test" {
+  license = jsonencode({
+    license = {
+      uid                   = "893361dc-9749-4997-93cb-802e3d7fa4a8"
+      type                  = "platinum"
+      issue_date_in_millis  = 1411948800000
+      expiry_date_in_millis = 1914278399999
+      max_nodes             = 1
+      issued_to             = "terraform"
+      issuer                = "terraform"
+      signature             = "invalid"
+    }
+  })
+}
+`
@@ -0,0 +1,154 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maps the resource attributes to the cluster settings they manage
+var lifecycleScheduleSettings = map[string]string{
+	"ilm_poll_interval":      "indices.lifecycle.poll_interval",
+	"slm_retention_schedule": "slm.retention_schedule",
+	"slm_retention_duration": "slm.retention_duration",
+}
+
+func ResourceLifecycleSchedule() *schema.Resource {
+	attributes := []string{"ilm_poll_interval", "slm_retention_schedule", "slm_retention_duration"}
+
+	scheduleSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ilm_poll_interval": {
+			Description:  "How often index lifecycle management checks for indices that meet policy criteria (`indices.lifecycle.poll_interval`), e.g. `10m`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: attributes,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"slm_retention_schedule": {
+			Description:  "Periodic or absolute cron schedule of the SLM retention task (`slm.retention_schedule`), e.g. `0 30 1 * * ?` to run it every day at 1:30AM.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: attributes,
+			ValidateFunc: utils.StringIsCronExpression,
+		},
+		"slm_retention_duration": {
+			Description:  "Limits how long SLM should spend deleting old snapshots (`slm.retention_duration`), e.g. `1h`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: attributes,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+	}
+
+	utils.AddConnectionSchema(scheduleSchema)
+
+	return &schema.Resource{
+		Description: "Manages the cluster-wide schedules of the index lifecycle management and the snapshot lifecycle management retention, so the maintenance-heavy operations can be pinned to off-peak hours. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-settings.html and https://www.elastic.co/guide/en/elasticsearch/reference/current/snapshot-settings.html",
+
+		CreateContext: resourceLifecycleSchedulePut,
+		UpdateContext: resourceLifecycleSchedulePut,
+		ReadContext:   resourceLifecycleScheduleRead,
+		DeleteContext: resourceLifecycleScheduleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: scheduleSchema,
+	}
+}
+
+func resourceLifecycleSchedulePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "lifecycle-schedule")
+	if diags.HasError() {
+		return diags
+	}
+
+	persistent := make(map[string]interface{})
+	for attr, setting := range lifecycleScheduleSettings {
+		if v, ok := d.GetOk(attr); ok {
+			persistent[setting] = v.(string)
+		} else {
+			// make sure the setting removed from the configuration is reset to its default value
+			persistent[setting] = nil
+		}
+	}
+
+	if !d.IsNewResource() {
+		expected := make(map[string]interface{})
+		for attr, setting := range lifecycleScheduleSettings {
+			expected[setting], _ = d.GetChange(attr)
+		}
+		if diags := checkClusterSettingsConflict(ctx, client, map[string]map[string]interface{}{"persistent": expected}); diags.HasError() {
+			return diags
+		}
+	}
+
+	if diags := client.PutElasticsearchSettings(ctx, map[string]interface{}{"persistent": persistent}); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceLifecycleScheduleRead(ctx, d, meta)
+}
+
+func resourceLifecycleScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	clusterSettings, diags := client.GetElasticsearchSettings(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	persistent := make(map[string]interface{})
+	if v, ok := clusterSettings["persistent"].(map[string]interface{}); ok {
+		persistent = v
+	}
+	for attr, setting := range lifecycleScheduleSettings {
+		value := ""
+		if v, ok := persistent[setting].(string); ok {
+			value = v
+		}
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diags
+}
+
+func resourceLifecycleScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	persistent := make(map[string]interface{})
+	for _, setting := range lifecycleScheduleSettings {
+		persistent[setting] = nil
+	}
+	if diags := client.PutElasticsearchSettings(ctx, map[string]interface{}{"persistent": persistent}); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,98 @@
+package cluster_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceLifecycleSchedule(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceLifecycleScheduleDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceLifecycleScheduleCreate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_lifecycle_schedule.test", "ilm_poll_interval", "10m"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_lifecycle_schedule.test", "slm_retention_schedule", "0 30 1 * * ?"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_lifecycle_schedule.test", "slm_retention_duration", "1h"),
+				),
+			},
+			{
+				Config: testAccResourceLifecycleScheduleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_lifecycle_schedule.test", "ilm_poll_interval", "15m"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_lifecycle_schedule.test", "slm_retention_schedule", "0 0 3 * * ?"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_lifecycle_schedule.test", "slm_retention_duration", ""),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceLifecycleScheduleCreate = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_lifecycle_schedule" "test" {
+  ilm_poll_interval      = "10m"
+  slm_retention_schedule = "0 30 1 * * ?"
+  slm_retention_duration = "1h"
+}
+`
+
+const testAccResourceLifecycleScheduleUpdate = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_lifecycle_schedule" "test" {
+  ilm_poll_interval      = "15m"
+  slm_retention_schedule = "0 0 3 * * ?"
+}
+`
+
+func checkResourceLifecycleScheduleDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	listOfSettings := []string{
+		"indices.lifecycle.poll_interval",
+		"slm.retention_schedule",
+		"slm.retention_duration",
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_lifecycle_schedule" {
+			continue
+		}
+
+		req := client.GetESClient().Cluster.GetSettings.WithFlatSettings(true)
+		res, err := client.GetESClient().Cluster.GetSettings(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		clusterSettings := make(map[string]interface{})
+		if err := json.NewDecoder(res.Body).Decode(&clusterSettings); err != nil {
+			return err
+		}
+
+		if settings, ok := clusterSettings["persistent"].(map[string]interface{}); ok {
+			for _, s := range listOfSettings {
+				if v, ok := settings[s]; ok {
+					return fmt.Errorf(`Setting "%s=%s" still in the cluster, but it should be removed`, s, v)
+				}
+			}
+		}
+	}
+	return nil
+}
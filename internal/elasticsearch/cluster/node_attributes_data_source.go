@@ -0,0 +1,181 @@
+package cluster
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// the prefixes of the attributes set by Elasticsearch itself, rather than by the `node.attr.*` settings
+var builtinNodeAttributePrefixes = []string{"ml.", "xpack.", "transform."}
+
+func DataSourceNodeAttributes() *schema.Resource {
+	attributesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"include_builtin": {
+			Description: "Include the attributes set by Elasticsearch itself, e.g. `ml.machine_memory` or `xpack.installed`.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"attributes": {
+			Description: "The distinct attributes of the nodes, sorted by their name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "The name of the attribute, e.g. `data` for the `node.attr.data` setting. It's used as `index.routing.allocation.require.<name>` or in the ILM `allocate` action.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"values": {
+						Description: "The sorted distinct values of the attribute.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"nodes": {
+			Description: "The nodes with their attributes.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"node_id": {
+						Description: "The ID of the node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"node_name": {
+						Description: "The name of the node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"attributes": {
+						Description: "The attributes of the node.",
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(attributesSchema)
+
+	return &schema.Resource{
+		Description: "Lists the custom attributes of the nodes, to validate or generate the shard allocation filtering of the indices and the ILM `allocate` actions from the actual topology of the cluster. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/cat-nodeattrs.html",
+
+		ReadContext: dataSourceNodeAttributesRead,
+
+		Schema: attributesSchema,
+	}
+}
+
+func dataSourceNodeAttributesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "node-attributes")
+	if diags.HasError() {
+		return diags
+	}
+
+	nodeAttributes, diags := client.GetElasticsearchNodeAttributes(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	attributes, nodes := flattenNodeAttributes(nodeAttributes, d.Get("include_builtin").(bool))
+
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("nodes", nodes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// Groups the node attributes by the attribute and by the node, both sorted
+func flattenNodeAttributes(nodeAttributes []models.NodeAttribute, includeBuiltin bool) ([]interface{}, []interface{}) {
+	values := make(map[string]map[string]struct{})
+	nodeNames := make(map[string]string)
+	byNode := make(map[string]map[string]interface{})
+	for _, a := range nodeAttributes {
+		if _, ok := byNode[a.NodeId]; !ok {
+			nodeNames[a.NodeId] = a.NodeName
+			byNode[a.NodeId] = make(map[string]interface{})
+		}
+		if !includeBuiltin && isBuiltinNodeAttribute(a.Name) {
+			continue
+		}
+		if _, ok := values[a.Name]; !ok {
+			values[a.Name] = make(map[string]struct{})
+		}
+		values[a.Name][a.Value] = struct{}{}
+		byNode[a.NodeId][a.Name] = a.Value
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	attributes := make([]interface{}, len(names))
+	for i, name := range names {
+		vals := make([]string, 0, len(values[name]))
+		for v := range values[name] {
+			vals = append(vals, v)
+		}
+		sort.Strings(vals)
+		attributes[i] = map[string]interface{}{
+			"name":   name,
+			"values": vals,
+		}
+	}
+
+	nodeIds := make([]string, 0, len(byNode))
+	for nodeId := range byNode {
+		nodeIds = append(nodeIds, nodeId)
+	}
+	sort.Slice(nodeIds, func(i, j int) bool { return nodeNames[nodeIds[i]] < nodeNames[nodeIds[j]] })
+	nodes := make([]interface{}, len(nodeIds))
+	for i, nodeId := range nodeIds {
+		nodes[i] = map[string]interface{}{
+			"node_id":    nodeId,
+			"node_name":  nodeNames[nodeId],
+			"attributes": byNode[nodeId],
+		}
+	}
+	return attributes, nodes
+}
+
+func isBuiltinNodeAttribute(name string) bool {
+	for _, prefix := range builtinNodeAttributePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNodeAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNodeAttributes,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_node_attributes.test", "nodes.0.node_id"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_node_attributes.test", "nodes.0.node_name"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_node_attributes.test", "nodes.0.attributes.xpack.installed", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceNodeAttributes = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_node_attributes" "test" {
+  include_builtin = true
+}
+`
@@ -0,0 +1,186 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceNodeShutdown() *schema.Resource {
+	shutdownSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"node_id": {
+			Description: "The ID of the node to prepare for shutdown, it does not have to be part of the cluster yet.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"type": {
+			Description:  "The type of the shutdown: `restart` to keep the shards of the node while it's restarted, `remove` to move all the shards away from the node, or `replace` to move the shards to the replacement node.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"restart", "remove", "replace"}, false),
+		},
+		"reason": {
+			Description: "The reason of the shutdown, e.g. the ticket of the maintenance.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"allocation_delay": {
+			Description:  "How long to wait for the node to restart before reassigning its shards to the other nodes, e.g. `20m`. Only valid with the `restart` type.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"target_node_name": {
+			Description: "The name of the node replacing the node being shut down. Required with the `replace` type.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"status": {
+			Description: "The status of the shutdown preparation: `NOT_STARTED`, `IN_PROGRESS`, `STALLED` or `COMPLETE`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"shard_migration_status": {
+			Description: "The status of the migration of the shards away from the node.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(shutdownSchema)
+
+	return &schema.Resource{
+		Description: "Prepares the node to be shut down, e.g. to replace it or restart it without reallocating its shards. Destroying the resource cancels the shutdown preparation, which must be done once the node is back or removed. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/put-shutdown.html",
+
+		CreateContext: resourceNodeShutdownPut,
+		UpdateContext: resourceNodeShutdownPut,
+		ReadContext:   resourceNodeShutdownRead,
+		DeleteContext: resourceNodeShutdownDelete,
+
+		CustomizeDiff: validateNodeShutdownType,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: shutdownSchema,
+	}
+}
+
+func validateNodeShutdownType(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	shutdownType := d.Get("type").(string)
+	if d.Get("allocation_delay").(string) != "" && shutdownType != "restart" {
+		return fmt.Errorf("allocation_delay is only valid with the restart type, got: %s", shutdownType)
+	}
+	if !d.NewValueKnown("target_node_name") {
+		return nil
+	}
+	hasTarget := d.Get("target_node_name").(string) != ""
+	if shutdownType == "replace" && !hasTarget {
+		return fmt.Errorf("target_node_name is required with the replace type")
+	}
+	if shutdownType != "replace" && hasTarget {
+		return fmt.Errorf("target_node_name is only valid with the replace type, got: %s", shutdownType)
+	}
+	return nil
+}
+
+func resourceNodeShutdownPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	nodeId := d.Get("node_id").(string)
+	id, diags := client.ID(ctx, nodeId)
+	if diags.HasError() {
+		return diags
+	}
+
+	shutdown := models.NodeShutdown{
+		NodeId:          nodeId,
+		Type:            d.Get("type").(string),
+		Reason:          d.Get("reason").(string),
+		AllocationDelay: d.Get("allocation_delay").(string),
+		TargetNodeName:  d.Get("target_node_name").(string),
+	}
+	if diags := client.PutElasticsearchNodeShutdown(ctx, &shutdown); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceNodeShutdownRead(ctx, d, meta)
+}
+
+func resourceNodeShutdownRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	shutdown, diags := client.GetElasticsearchNodeShutdown(ctx, compId.ResourceId)
+	if shutdown == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	shardMigrationStatus := ""
+	if shutdown.ShardMigration != nil {
+		shardMigrationStatus = shutdown.ShardMigration.Status
+	}
+
+	// the types are returned in the upper case
+	for attr, value := range map[string]interface{}{
+		"node_id":                shutdown.NodeId,
+		"type":                   strings.ToLower(shutdown.Type),
+		"reason":                 shutdown.Reason,
+		"allocation_delay":       shutdown.AllocationDelay,
+		"target_node_name":       shutdown.TargetNodeName,
+		"status":                 shutdown.Status,
+		"shard_migration_status": shardMigrationStatus,
+	} {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceNodeShutdownDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchNodeShutdown(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
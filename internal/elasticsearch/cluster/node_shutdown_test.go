@@ -0,0 +1,82 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceNodeShutdown(t *testing.T) {
+	// the node does not have to be part of the cluster, so the test cluster is not affected
+	nodeId := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceNodeShutdownDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceNodeShutdown(nodeId, "replace", `allocation_delay = "20m"`),
+				ExpectError: regexp.MustCompile("allocation_delay is only valid with the restart type"),
+			},
+			{
+				Config: testAccResourceNodeShutdown(nodeId, "restart", `allocation_delay = "20m"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_node_shutdown.test", "node_id", nodeId),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_node_shutdown.test", "type", "restart"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_node_shutdown.test", "allocation_delay", "20m"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_node_shutdown.test", "status"),
+				),
+			},
+			{
+				Config: testAccResourceNodeShutdown(nodeId, "replace", `target_node_name = "replacement"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_node_shutdown.test", "type", "replace"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_node_shutdown.test", "target_node_name", "replacement"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_node_shutdown.test", "allocation_delay", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceNodeShutdown(nodeId, shutdownType, extra string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_node_shutdown" "test" {
+  node_id = "%s"
+  type    = "%s"
+  reason  = "acceptance test"
+  %s
+}
+	`, nodeId, shutdownType, extra)
+}
+
+func checkResourceNodeShutdownDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_node_shutdown" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+		shutdown, diags := client.GetElasticsearchNodeShutdown(context.Background(), compId.ResourceId)
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get the node shutdown: %v", diags)
+		}
+		if shutdown != nil {
+			return fmt.Errorf("Node shutdown (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The secure settings, which are only read from the keystores of the nodes. The `*` matches one part of the name,
+// besides them all the settings with the part prefixed with `secure_` are secure, e.g. `xpack.notification.slack.account.*.secure_url`.
+var secureSettingPatterns = []string{
+	"bootstrap.password",
+	"s3.client.*.access_key",
+	"s3.client.*.secret_key",
+	"s3.client.*.session_token",
+	"azure.client.*.account",
+	"azure.client.*.key",
+	"azure.client.*.sas_token",
+	"gcs.client.*.credentials_file",
+	"cluster.remote.*.credentials",
+	"xpack.security.authc.realms.oidc.*.rp.client_secret",
+	"xpack.security.authc.realms.jwt.*.client_authentication.shared_secret",
+	"xpack.security.authc.realms.jwt.*.hmac_key",
+	"xpack.security.authc.realms.jwt.*.hmac_jwkset",
+}
+
+// Fails at plan time when the secure setting is set in the cluster settings, which Elasticsearch rejects only once they are applied
+func validateClusterSettingName(v interface{}, k string) ([]string, []error) {
+	name, ok := v.(string)
+	if !ok || !isSecureSetting(name) {
+		return nil, nil
+	}
+	return nil, []error{fmt.Errorf(`%s: "%s" is a secure setting, which cannot be set in the cluster settings. Add it to the keystore of every node with "bin/elasticsearch-keystore add %s", then reload the secure settings, e.g. with the elasticstack_elasticsearch_secure_settings_reload resource`, k, name, name)}
+}
+
+func isSecureSetting(name string) bool {
+	parts := strings.Split(name, ".")
+	for _, part := range parts {
+		if strings.HasPrefix(part, "secure_") {
+			return true
+		}
+	}
+	for _, pattern := range secureSettingPatterns {
+		if matchSettingPattern(strings.Split(pattern, "."), parts) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSettingPattern(pattern, parts []string) bool {
+	if len(pattern) != len(parts) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != parts[i] {
+			return false
+		}
+	}
+	return true
+}
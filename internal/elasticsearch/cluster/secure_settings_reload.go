@@ -0,0 +1,130 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceSecureSettingsReload() *schema.Resource {
+	reloadSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"node_ids": {
+			Description: "The IDs or the names of the nodes to reload the secure settings on, all the nodes by default.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"secure_settings_password": {
+			Description: "The password of the keystores, when they are password protected.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			ForceNew:    true,
+		},
+		"triggers": {
+			Description: "Arbitrary map of values that, when changed, will reload the secure settings again, e.g. the version of the keystore entries.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"reloaded_nodes": {
+			Description: "The names of the nodes the secure settings were reloaded on.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(reloadSchema)
+
+	return &schema.Resource{
+		Description: "Reloads the reloadable secure settings, e.g. the credentials of the S3 repositories, from the keystores of the nodes, once they were added to the keystores. Destroying the resource doesn't change the keystores. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-nodes-reload-secure-settings.html",
+
+		CreateContext: resourceSecureSettingsReloadCreate,
+		// the reload cannot be changed, only the connection can
+		UpdateContext: resourceSecureSettingsReloadRead,
+		ReadContext:   resourceSecureSettingsReloadRead,
+		DeleteContext: resourceSecureSettingsReloadDelete,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: reloadSchema,
+	}
+}
+
+func resourceSecureSettingsReloadCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeIds := make([]string, 0)
+	for _, n := range d.Get("node_ids").([]interface{}) {
+		nodeIds = append(nodeIds, n.(string))
+	}
+	response, diags := client.ReloadElasticsearchSecureSettings(ctx, nodeIds, d.Get("secure_settings_password").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	// the reload fails on the single nodes, e.g. when the password of their keystore is wrong, while the request succeeds
+	reloaded := make([]string, 0)
+	failed := make([]string, 0)
+	for _, node := range response.Nodes {
+		if node.ReloadException != nil {
+			failed = append(failed, fmt.Sprintf(`"%s": %v`, node.Name, node.ReloadException["reason"]))
+			continue
+		}
+		reloaded = append(reloaded, node.Name)
+	}
+	sort.Strings(reloaded)
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to reload the secure settings on all the nodes",
+			Detail:   fmt.Sprintf("The secure settings were not reloaded on the nodes %s. Check the keystores of the nodes and their password.", strings.Join(failed, ", ")),
+		})
+	}
+
+	id, diags := client.ID(ctx, "secure-settings-reload")
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("reloaded_nodes", reloaded); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id.String())
+	return resourceSecureSettingsReloadRead(ctx, d, meta)
+}
+
+// The reload is done once the request succeeds, there is nothing to read back
+func resourceSecureSettingsReloadRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+func resourceSecureSettingsReloadDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,36 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSecureSettingsReload(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecureSettingsReload,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_secure_settings_reload.test", "id"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_secure_settings_reload.test", "reloaded_nodes.0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceSecureSettingsReload = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_secure_settings_reload" "test" {
+  triggers = {
+    keystore = "1"
+  }
+}
+`
@@ -21,9 +21,10 @@ func ResourceSettings() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
-							Description: "The name of the setting to set and track.",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "The name of the setting to set and track. The secure settings, e.g. `s3.client.*.secret_key`, cannot be set, they must be added to the keystore of the nodes.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateClusterSettingName,
 						},
 						"value": {
 							Description: "The value of the setting to set and track.",
@@ -58,12 +59,18 @@ func ResourceSettings() *schema.Resource {
 			Elem:        settingSchema,
 		},
 		"transient": {
-			Description: "Settings do not survive a full cluster restart.",
+			Description: "Settings do not survive a full cluster restart. The transient settings are deprecated since Elasticsearch 7.16, see `migrate_transient`.",
 			Type:        schema.TypeList,
 			MaxItems:    1,
 			Optional:    true,
 			Elem:        settingSchema,
 		},
+		"migrate_transient": {
+			Description: "Set the settings of the `transient` block as persistent settings, and remove them from the transient settings, on the next apply.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
 	}
 
 	utils.AddConnectionSchema(settingsSchema)
@@ -80,6 +87,8 @@ func ResourceSettings() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
 		Schema: settingsSchema,
 	}
 }
@@ -90,7 +99,7 @@ func resourceClusterSettingsPut(ctx context.Context, d *schema.ResourceData, met
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	id, diags := client.ID("cluster-settings")
+	id, diags := client.ID(ctx, "cluster-settings")
 	if diags.HasError() {
 		return diags
 	}
@@ -108,7 +117,33 @@ func resourceClusterSettingsPut(ctx context.Context, d *schema.ResourceData, met
 			}
 		}
 	}
-	if diags := client.PutElasticsearchSettings(settings); diags.HasError() {
+	if d.Get("migrate_transient").(bool) {
+		if diags := migrateTransientSettings(settings); diags.HasError() {
+			return diags
+		}
+	}
+	if !d.IsNewResource() {
+		expected := make(map[string]map[string]interface{})
+		for _, v := range []string{"persistent", "transient"} {
+			if old, _ := d.GetChange(v); len(old.([]interface{})) > 0 {
+				expected[v], _ = expandSettings(old)
+			}
+		}
+		// the transient settings already migrated were read from the persistent settings
+		if migrated, _ := d.GetChange("migrate_transient"); migrated.(bool) && expected["transient"] != nil {
+			if expected["persistent"] == nil {
+				expected["persistent"] = make(map[string]interface{})
+			}
+			for setting, value := range expected["transient"] {
+				expected["persistent"][setting] = value
+			}
+			delete(expected, "transient")
+		}
+		if diags := checkClusterSettingsConflict(ctx, client, expected); diags.HasError() {
+			return diags
+		}
+	}
+	if diags := client.PutElasticsearchSettings(ctx, settings); diags.HasError() {
 		return diags
 	}
 	d.SetId(id.String())
@@ -209,12 +244,17 @@ func resourceClusterSettingsRead(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	clusterSettings, diags := client.GetElasticsearchSettings()
+	clusterSettings, diags := client.GetElasticsearchSettings(ctx)
 	if diags.HasError() {
 		return diags
 	}
 	configuredSettings, _ := getConfiguredSettings(d)
 	persitent := flattenSettings("persistent", configuredSettings, clusterSettings)
+	migrate := d.Get("migrate_transient").(bool)
+	if migrate {
+		// the migrated transient settings are found in the persistent settings
+		clusterSettings = map[string]interface{}{"transient": clusterSettings["persistent"]}
+	}
 	transient := flattenSettings("transient", configuredSettings, clusterSettings)
 
 	if err := d.Set("persistent", persitent); err != nil {
@@ -223,6 +263,9 @@ func resourceClusterSettingsRead(ctx context.Context, d *schema.ResourceData, me
 	if err := d.Set("transient", transient); err != nil {
 		return diag.FromErr(err)
 	}
+	if configuredSettings["transient"] != nil && !migrate {
+		return checkTransientSettingsDeprecation(ctx, client)
+	}
 	return diags
 }
 
@@ -276,6 +319,10 @@ func resourceClusterSettingsDelete(ctx context.Context, d *schema.ResourceData,
 	if v := configuredSettings["transient"]; v != nil {
 		for k := range v.(map[string]interface{}) {
 			tSettings[k] = nil
+			// the migrated transient settings are persistent ones
+			if d.Get("migrate_transient").(bool) {
+				pSettings[k] = nil
+			}
 		}
 	}
 
@@ -283,7 +330,7 @@ func resourceClusterSettingsDelete(ctx context.Context, d *schema.ResourceData,
 		"persistent": pSettings,
 		"transient":  tSettings,
 	}
-	if diags := client.PutElasticsearchSettings(settings); diags.HasError() {
+	if diags := client.PutElasticsearchSettings(ctx, settings); diags.HasError() {
 		return diags
 	}
 
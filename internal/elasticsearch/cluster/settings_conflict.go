@@ -0,0 +1,50 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Compares the cluster settings with the values the resource read last time, right before they are updated.
+// The cluster settings have no version to update them conditionally, so the settings changed since the last refresh,
+// e.g. by the concurrent apply in another workspace, are reported as the conflict instead of being silently overwritten.
+// The expected values are grouped by the type of the settings, `persistent` or `transient`.
+func checkClusterSettingsConflict(ctx context.Context, client *clients.ApiClient, expected map[string]map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	clusterSettings, diags := client.GetElasticsearchSettings(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	conflicts := make([]string, 0)
+	for settingsType, settings := range expected {
+		current, _ := clusterSettings[settingsType].(map[string]interface{})
+		for setting, value := range settings {
+			if settingValueString(current[setting]) != settingValueString(value) {
+				conflicts = append(conflicts, fmt.Sprintf(`%s "%s" is "%s" instead of "%s"`, settingsType, setting, settingValueString(current[setting]), settingValueString(value)))
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return diags
+	}
+	sort.Strings(conflicts)
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "The cluster settings were changed concurrently",
+		Detail:   fmt.Sprintf("The cluster settings were changed since they were last read, e.g. by another Terraform workspace, and they are not overwritten: %s. Refresh the state and review the plan again.", strings.Join(conflicts, ", ")),
+	})
+}
+
+// Returns the value of the setting as it's compared, the unset setting is empty
+func settingValueString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
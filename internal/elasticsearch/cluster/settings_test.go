@@ -3,6 +3,7 @@ package cluster_test
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -69,6 +70,105 @@ func TestAccResourceClusterSettings(t *testing.T) {
 	})
 }
 
+func TestAccResourceClusterSettingsSecureSetting(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceClusterSettingsSecureSetting,
+				ExpectError: regexp.MustCompile(`"s3.client.default.secret_key" is a secure setting`),
+			},
+		},
+	})
+}
+
+const testAccResourceClusterSettingsSecureSetting = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_cluster_settings" "test" {
+  persistent {
+    setting {
+      name  = "s3.client.default.secret_key"
+      value = "secret"
+    }
+  }
+}
+`
+
+func TestAccResourceClusterSettingsMigrateTransient(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceClusterSettingsDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceClusterSettingsTransient(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cluster_settings.test", "migrate_transient", "false"),
+					checkClusterSetting("transient", "indices.recovery.max_bytes_per_sec", "50mb"),
+				),
+			},
+			{
+				Config: testAccResourceClusterSettingsTransient(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cluster_settings.test", "migrate_transient", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_cluster_settings.test", "transient.0.setting.*",
+						map[string]string{
+							"name":  "indices.recovery.max_bytes_per_sec",
+							"value": "50mb",
+						}),
+					checkClusterSetting("persistent", "indices.recovery.max_bytes_per_sec", "50mb"),
+					checkClusterSetting("transient", "indices.recovery.max_bytes_per_sec", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceClusterSettingsTransient(migrate bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_cluster_settings" "test" {
+  transient {
+    setting {
+      name  = "indices.recovery.max_bytes_per_sec"
+      value = "50mb"
+    }
+  }
+
+  migrate_transient = %t
+}
+`, migrate)
+}
+
+// Checks the value of the setting in the cluster, the empty value when the setting is not set
+func checkClusterSetting(settingsType, name, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := acctest.Provider.Meta().(*clients.ApiClient)
+		res, err := client.GetESClient().Cluster.GetSettings(client.GetESClient().Cluster.GetSettings.WithFlatSettings(true))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		clusterSettings := make(map[string]interface{})
+		if err := json.NewDecoder(res.Body).Decode(&clusterSettings); err != nil {
+			return err
+		}
+		settings, _ := clusterSettings[settingsType].(map[string]interface{})
+		if value, _ := settings[name].(string); value != expected {
+			return fmt.Errorf(`expected the %s setting "%s" to be "%s", got "%s"`, settingsType, name, expected, value)
+		}
+		return nil
+	}
+}
+
 func testAccResourceClusterSettingsCreate() string {
 	return `
 provider "elasticstack" {
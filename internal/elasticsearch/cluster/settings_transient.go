@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Warns about the transient settings on Elasticsearch 8, which deprecates them. The warning is shown by the refresh of the plan.
+func checkTransientSettingsDeprecation(ctx context.Context, client *clients.ApiClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+	major, diags := client.ServerMajorVersion(ctx)
+	if diags.HasError() || major < 8 {
+		return diags
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "The transient cluster settings are deprecated",
+		Detail: fmt.Sprintf("Elasticsearch %d.x deprecates the transient cluster settings, which may be removed in a future version. "+
+			"Move the settings to the `persistent` block, or set `migrate_transient = true` to set them as the persistent settings on the next apply.", major),
+	}}
+}
+
+// Moves the transient settings to the persistent ones in place, and removes them from the transient settings.
+// The removed transient settings are removed from the persistent settings too, unless they are configured there.
+func migrateTransientSettings(settings map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	transient, _ := settings["transient"].(map[string]interface{})
+	if len(transient) == 0 {
+		return diags
+	}
+	persistent, _ := settings["persistent"].(map[string]interface{})
+	if persistent == nil {
+		persistent = make(map[string]interface{})
+		settings["persistent"] = persistent
+	}
+	for setting, value := range transient {
+		configured, inPersistent := persistent[setting]
+		if value == nil {
+			if !inPersistent {
+				persistent[setting] = nil
+			}
+			continue
+		}
+		if inPersistent && configured != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf(`Unable to migrate the transient setting "%s".`, setting),
+				Detail:   fmt.Sprintf(`The setting "%s" is configured both in the persistent and in the transient settings, remove it from one of them before migrating the transient settings.`, setting),
+			})
+		}
+		persistent[setting] = value
+		transient[setting] = nil
+	}
+	return diags
+}
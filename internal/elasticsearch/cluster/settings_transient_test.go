@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMigrateTransientSettings(t *testing.T) {
+	tests := []struct {
+		name               string
+		settings           map[string]interface{}
+		expectedPersistent map[string]interface{}
+		expectedTransient  map[string]interface{}
+		expectError        bool
+	}{
+		{
+			name: "moves the transient settings",
+			settings: map[string]interface{}{
+				"persistent": map[string]interface{}{"indices.lifecycle.poll_interval": "10m"},
+				"transient":  map[string]interface{}{"indices.recovery.max_bytes_per_sec": "50mb"},
+			},
+			expectedPersistent: map[string]interface{}{"indices.lifecycle.poll_interval": "10m", "indices.recovery.max_bytes_per_sec": "50mb"},
+			expectedTransient:  map[string]interface{}{"indices.recovery.max_bytes_per_sec": nil},
+		},
+		{
+			name: "removes the removed transient settings",
+			settings: map[string]interface{}{
+				"transient": map[string]interface{}{"indices.recovery.max_bytes_per_sec": nil},
+			},
+			expectedPersistent: map[string]interface{}{"indices.recovery.max_bytes_per_sec": nil},
+			expectedTransient:  map[string]interface{}{"indices.recovery.max_bytes_per_sec": nil},
+		},
+		{
+			name: "keeps the removed transient settings configured as persistent",
+			settings: map[string]interface{}{
+				"persistent": map[string]interface{}{"indices.recovery.max_bytes_per_sec": "50mb"},
+				"transient":  map[string]interface{}{"indices.recovery.max_bytes_per_sec": nil},
+			},
+			expectedPersistent: map[string]interface{}{"indices.recovery.max_bytes_per_sec": "50mb"},
+			expectedTransient:  map[string]interface{}{"indices.recovery.max_bytes_per_sec": nil},
+		},
+		{
+			name: "rejects the settings both persistent and transient",
+			settings: map[string]interface{}{
+				"persistent": map[string]interface{}{"indices.breaker.total.limit": "65%"},
+				"transient":  map[string]interface{}{"indices.breaker.total.limit": "60%"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		diags := migrateTransientSettings(tc.settings)
+		if diags.HasError() != tc.expectError {
+			t.Fatalf("%s: unexpected diagnostics %v", tc.name, diags)
+		}
+		if tc.expectError {
+			continue
+		}
+		if !reflect.DeepEqual(tc.settings["persistent"], tc.expectedPersistent) {
+			t.Errorf("%s: expected the persistent settings %v, got %v", tc.name, tc.expectedPersistent, tc.settings["persistent"])
+		}
+		if !reflect.DeepEqual(tc.settings["transient"], tc.expectedTransient) {
+			t.Errorf("%s: expected the transient settings %v, got %v", tc.name, tc.expectedTransient, tc.settings["transient"])
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
@@ -132,6 +133,42 @@ func ResourceSlm() *schema.Resource {
 			Type:        schema.TypeString,
 			Required:    true,
 		},
+		"execute_on_create": {
+			Description: "Take a snapshot with the policy as soon as it's created, instead of waiting for the schedule.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"last_success_snapshot": {
+			Description: "The name of the last snapshot taken successfully by the policy.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"last_success_time": {
+			Description: "The time of the last snapshot taken successfully by the policy, in RFC 3339 format.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"last_failure_snapshot": {
+			Description: "The name of the last snapshot the policy failed to take.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"last_failure_time": {
+			Description: "The time of the last snapshot the policy failed to take, in RFC 3339 format.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"last_failure_details": {
+			Description: "The reason the last snapshot failed.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"next_execution": {
+			Description: "The time the policy takes the next snapshot, in RFC 3339 format.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
 	}
 
 	utils.AddConnectionSchema(slmSchema)
@@ -148,6 +185,8 @@ func ResourceSlm() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
 		Schema: slmSchema,
 	}
 }
@@ -159,7 +198,7 @@ func resourceSlmPut(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.FromErr(err)
 	}
 	slmId := d.Get("name").(string)
-	id, diags := client.ID(slmId)
+	id, diags := client.ID(ctx, slmId)
 	if diags.HasError() {
 		return diags
 	}
@@ -230,10 +269,16 @@ func resourceSlmPut(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	slm.Config = &slmConfig
 
-	if diags := client.PutElasticsearchSlm(&slm); diags.HasError() {
+	if diags := client.PutElasticsearchSlm(ctx, &slm); diags.HasError() {
 		return diags
 	}
 	d.SetId(id.String())
+
+	if d.IsNewResource() && d.Get("execute_on_create").(bool) {
+		if _, diags := client.ExecuteElasticsearchSlm(ctx, slmId); diags.HasError() {
+			return diags
+		}
+	}
 	return resourceSlmRead(ctx, d, meta)
 }
 
@@ -248,14 +293,15 @@ func resourceSlmRead(ctx context.Context, d *schema.ResourceData, meta interface
 		return diags
 	}
 
-	slm, diags := client.GetElasticsearchSlm(id.ResourceId)
-	if slm == nil && diags == nil {
+	slmInfo, diags := client.GetElasticsearchSlm(ctx, id.ResourceId)
+	if slmInfo == nil && diags == nil {
 		d.SetId("")
 		return diags
 	}
 	if diags.HasError() {
 		return diags
 	}
+	slm := slmInfo.Policy
 
 	if err := d.Set("snapshot_name", slm.Name); err != nil {
 		return diag.FromErr(err)
@@ -323,9 +369,48 @@ func resourceSlmRead(ctx context.Context, d *schema.ResourceData, meta interface
 		}
 	}
 
+	if diags := setSlmStats(d, slmInfo); diags.HasError() {
+		return diags
+	}
+
 	return diags
 }
 
+// Sets the outcome of the last snapshots taken by the policy, the attributes are empty until the policy runs
+func setSlmStats(d *schema.ResourceData, slmInfo *models.SnapshotPolicyInfo) diag.Diagnostics {
+	var diags diag.Diagnostics
+	stats := map[string]interface{}{
+		"last_success_snapshot": "",
+		"last_success_time":     "",
+		"last_failure_snapshot": "",
+		"last_failure_time":     "",
+		"last_failure_details":  "",
+		"next_execution":        formatSlmTime(slmInfo.NextExecutionMillis),
+	}
+	if s := slmInfo.LastSuccess; s != nil {
+		stats["last_success_snapshot"] = s.SnapshotName
+		stats["last_success_time"] = formatSlmTime(s.Time)
+	}
+	if f := slmInfo.LastFailure; f != nil {
+		stats["last_failure_snapshot"] = f.SnapshotName
+		stats["last_failure_time"] = formatSlmTime(f.Time)
+		stats["last_failure_details"] = f.Details
+	}
+	for attr, value := range stats {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diags
+}
+
+func formatSlmTime(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
 func resourceSlmDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client, err := clients.NewApiClient(d, meta)
@@ -336,7 +421,7 @@ func resourceSlmDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 	if diags.HasError() {
 		return diags
 	}
-	if diags := client.DeleteElasticsearchSlm(id.ResourceId); diags.HasError() {
+	if diags := client.DeleteElasticsearchSlm(ctx, id.ResourceId); diags.HasError() {
 		return diags
 	}
 	d.SetId("")
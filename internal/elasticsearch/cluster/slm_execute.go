@@ -0,0 +1,102 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceSlmExecute() *schema.Resource {
+	executeSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"policy": {
+			Description: "The name of the snapshot lifecycle policy to execute.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"execute_retention": {
+			Description: "Also delete the snapshots expired according to the retention of the snapshot lifecycle policies, once the snapshot is started.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+		},
+		"triggers": {
+			Description: "Arbitrary map of values that, when changed, will execute the policy again.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"snapshot_name": {
+			Description: "The name of the snapshot started by the policy.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(executeSchema)
+
+	return &schema.Resource{
+		Description: "Executes a snapshot lifecycle policy immediately, starting a snapshot without waiting for the schedule of the policy. Destroying the resource doesn't delete the snapshot. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/slm-api-execute-lifecycle.html",
+
+		CreateContext: resourceSlmExecuteCreate,
+		// the execution cannot be changed, only the connection can
+		UpdateContext: resourceSlmExecuteRead,
+		ReadContext:   resourceSlmExecuteRead,
+		DeleteContext: resourceSlmExecuteDelete,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: executeSchema,
+	}
+}
+
+func resourceSlmExecuteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshotName, diags := client.ExecuteElasticsearchSlm(ctx, d.Get("policy").(string))
+	if diags.HasError() {
+		return diags
+	}
+	if d.Get("execute_retention").(bool) {
+		if diags := client.ExecuteElasticsearchSlmRetention(ctx); diags.HasError() {
+			return diags
+		}
+	}
+	id, diags := client.ID(ctx, snapshotName)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("snapshot_name", snapshotName); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id.String())
+	return resourceSlmExecuteRead(ctx, d, meta)
+}
+
+// The execution is done once the snapshot is started, the snapshot itself is tracked by the policy
+func resourceSlmExecuteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+func resourceSlmExecuteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
@@ -2,6 +2,7 @@ package cluster_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -72,6 +73,60 @@ resource "elasticstack_elasticsearch_snapshot_lifecycle" "test_slm" {
 	`, name, name)
 }
 
+func TestAccResourceSLMExecute(t *testing.T) {
+	// generate a random policy name
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkSlmDestroy(name),
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlmExecute(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_snapshot_lifecycle.test_slm", "execute_on_create", "true"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_snapshot_lifecycle.test_slm", "next_execution"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_snapshot_lifecycle_execute.test_execute", "policy", name),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_snapshot_lifecycle_execute.test_execute", "snapshot_name", regexp.MustCompile(`^snap-`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccSlmExecute(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_snapshot_repository" "repo" {
+  name = "%s-repo"
+
+  fs {
+    location = "/tmp/snapshots"
+  }
+}
+
+resource "elasticstack_elasticsearch_snapshot_lifecycle" "test_slm" {
+  name = "%s"
+
+  schedule          = "0 30 1 * * ?"
+  snapshot_name     = "<snap-{now/d}>"
+  repository        = elasticstack_elasticsearch_snapshot_repository.repo.name
+  execute_on_create = true
+
+  expire_after = "30d"
+}
+
+resource "elasticstack_elasticsearch_snapshot_lifecycle_execute" "test_execute" {
+  policy            = elasticstack_elasticsearch_snapshot_lifecycle.test_slm.name
+  execute_retention = true
+}
+	`, name, name)
+}
+
 func checkSlmDestroy(name string) func(s *terraform.State) error {
 	return func(s *terraform.State) error {
 		client := acctest.Provider.Meta().(*clients.ApiClient)
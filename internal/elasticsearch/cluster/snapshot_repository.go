@@ -96,7 +96,7 @@ func ResourceSnapshotRepository() *schema.Resource {
 			Required:    true,
 		},
 		"client": {
-			Description: "The name of the client to use to connect to Google Cloud Storage.",
+			Description: "The name of the client to use to connect to Google Cloud Storage, configured by the `gcs.client.<name>.*` settings and the keystores of the nodes.",
 			Type:        schema.TypeString,
 			Optional:    true,
 			Default:     "default",
@@ -116,7 +116,7 @@ func ResourceSnapshotRepository() *schema.Resource {
 			Required:    true,
 		},
 		"client": {
-			Description: "Azure named client to use.",
+			Description: "Azure named client to use, configured by the `azure.client.<name>.*` settings and the keystores of the nodes.",
 			Type:        schema.TypeString,
 			Optional:    true,
 			Default:     "default",
@@ -143,7 +143,7 @@ func ResourceSnapshotRepository() *schema.Resource {
 			Required:    true,
 		},
 		"client": {
-			Description: "The name of the S3 client to use to connect to S3.",
+			Description: "The name of the S3 client to use to connect to S3, configured by the `s3.client.<name>.*` settings and the keystores of the nodes.",
 			Type:        schema.TypeString,
 			Optional:    true,
 			Default:     "default",
@@ -309,6 +309,8 @@ func ResourceSnapshotRepository() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
 		Schema: snapRepoSchema,
 	}
 }
@@ -320,7 +322,7 @@ func resourceSnapRepoPut(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.FromErr(err)
 	}
 	repoId := d.Get("name").(string)
-	id, diags := client.ID(repoId)
+	id, diags := client.ID(ctx, repoId)
 	if diags.HasError() {
 		return diags
 	}
@@ -344,11 +346,19 @@ func resourceSnapRepoPut(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 	snapRepo.Settings = snapRepoSettings
 
-	if diags := client.PutElasticsearchSnapshotRepository(&snapRepo); diags.HasError() {
+	// the nodes check the client themselves when the repository is verified
+	if !snapRepo.Verify {
+		clientName, _ := snapRepoSettings["client"].(string)
+		if diags = checkRepositoryClient(ctx, client, snapRepo.Type, clientName); diags.HasError() {
+			return diags
+		}
+	}
+
+	if diags := client.PutElasticsearchSnapshotRepository(ctx, &snapRepo); diags.HasError() {
 		return diags
 	}
 	d.SetId(id.String())
-	return resourceSnapRepoRead(ctx, d, meta)
+	return append(diags, resourceSnapRepoRead(ctx, d, meta)...)
 }
 
 func expandFsSettings(source, target map[string]interface{}) {
@@ -372,7 +382,7 @@ func resourceSnapRepoRead(ctx context.Context, d *schema.ResourceData, meta inte
 		return diags
 	}
 
-	currentRepo, diags := client.GetElasticsearchSnapshotRepository(compId.ResourceId)
+	currentRepo, diags := client.GetElasticsearchSnapshotRepository(ctx, compId.ResourceId)
 	if currentRepo == nil && diags == nil {
 		d.SetId("")
 		return diags
@@ -451,7 +461,7 @@ func resourceSnapRepoDelete(ctx context.Context, d *schema.ResourceData, meta in
 		return diags
 	}
 
-	if diags := client.DeleteElasticsearchSnapshotRepository(compId.ResourceId); diags.HasError() {
+	if diags := client.DeleteElasticsearchSnapshotRepository(ctx, compId.ResourceId); diags.HasError() {
 		return diags
 	}
 	d.SetId("")
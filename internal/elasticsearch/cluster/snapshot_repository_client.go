@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// the repository types, which connect to the storage with the clients named in the settings and the keystores of the nodes
+var repositoryClientTypes = map[string]bool{"s3": true, "azure": true, "gcs": true}
+
+// Warns when the client of the repository is not configured on the master and data nodes. Only the settings of the elasticsearch.yml
+// are visible, the keystores are never exposed, so the client may still exist there.
+func checkRepositoryClient(ctx context.Context, client *clients.ApiClient, repoType, clientName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// the default client is always defined by the plugins
+	if !repositoryClientTypes[repoType] || clientName == "" || clientName == "default" {
+		return diags
+	}
+	nodes, diags := client.GetElasticsearchNodesInfo(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	missing := nodesWithoutRepositoryClient(nodes, repoType, clientName)
+	if len(missing) == 0 {
+		return diags
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf(`The %s client "%s" may not exist on every node`, repoType, clientName),
+		Detail: fmt.Sprintf("No `%s.client.%s.*` setting is set on the nodes: %s. The client exists only if its credentials are in the keystores of these nodes, otherwise the first snapshot fails. "+
+			"Set `verify = true` to let the nodes check the client once the repository is registered.", repoType, clientName, strings.Join(missing, ", ")),
+	}}
+}
+
+// Returns the sorted names of the master and data nodes, which have no setting of the named client of the repository type
+func nodesWithoutRepositoryClient(nodes map[string]models.NodeInfo, repoType, clientName string) []string {
+	prefix := fmt.Sprintf("%s.client.%s.", repoType, clientName)
+	missing := make([]string, 0)
+	for _, node := range nodes {
+		if !isRepositoryNode(node.Roles) {
+			continue
+		}
+		configured := false
+		for setting := range node.Settings {
+			if strings.HasPrefix(setting, prefix) {
+				configured = true
+				break
+			}
+		}
+		if !configured {
+			missing = append(missing, node.Name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// the repositories are accessed by the master and the data nodes of every tier
+func isRepositoryNode(roles []string) bool {
+	for _, role := range roles {
+		if role == "master" || role == "data" || strings.HasPrefix(role, "data_") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+)
+
+func TestNodesWithoutRepositoryClient(t *testing.T) {
+	nodes := map[string]models.NodeInfo{
+		"a": {Name: "master-1", Roles: []string{"master"}, Settings: map[string]interface{}{"s3.client.backup.endpoint": "s3.eu-west-1.amazonaws.com"}},
+		"b": {Name: "hot-1", Roles: []string{"data_hot", "ingest"}, Settings: map[string]interface{}{"s3.client.other.endpoint": "s3.eu-west-1.amazonaws.com"}},
+		"c": {Name: "cold-1", Roles: []string{"data_cold"}},
+		"d": {Name: "coordinating-1", Roles: []string{}},
+	}
+
+	tests := []struct {
+		repoType   string
+		clientName string
+		expected   []string
+	}{
+		{"s3", "backup", []string{"cold-1", "hot-1"}},
+		{"s3", "other", []string{"cold-1", "master-1"}},
+		{"azure", "backup", []string{"cold-1", "hot-1", "master-1"}},
+	}
+
+	for _, tc := range tests {
+		if missing := nodesWithoutRepositoryClient(nodes, tc.repoType, tc.clientName); !reflect.DeepEqual(missing, tc.expected) {
+			t.Errorf("%s client %s: expected the nodes %v, got %v", tc.repoType, tc.clientName, tc.expected, missing)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTaskCancel() *schema.Resource {
+	cancelSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"task_id": {
+			Description:  "The ID of the task to cancel, `<node_id>:<task_number>`, e.g. the `task_id` of `elasticstack_elasticsearch_reindex`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"task_id", "actions"},
+		},
+		"actions": {
+			Description: "Cancel all the tasks with the actions, wildcards are supported, e.g. `*reindex` or `*byquery`.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"nodes": {
+			Description: "Cancel only the tasks running on the nodes, given by their IDs or names.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"wait_for_completion": {
+			Description: "Wait until the cancelled tasks, and their child tasks, finish.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+		},
+		"triggers": {
+			Description: "Arbitrary map of values that, when changed, will cancel the tasks again.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"cancelled_tasks": {
+			Description: "The IDs of the cancelled tasks.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(cancelSchema)
+
+	return &schema.Resource{
+		Description: "Cancels the running task, or all the tasks with the actions, e.g. the reindex or the update by query started by mistake. The tasks which are not running anymore are skipped. Destroying the resource doesn't change the tasks. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/tasks.html#task-cancellation",
+
+		CreateContext: resourceTaskCancelCreate,
+		// the cancellation cannot be changed, only the connection can
+		UpdateContext: resourceTaskCancelRead,
+		ReadContext:   resourceTaskCancelRead,
+		DeleteContext: resourceTaskCancelDelete,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: cancelSchema,
+	}
+}
+
+func resourceTaskCancelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	taskIdToCancel := d.Get("task_id").(string)
+
+	tasks, diags := client.CancelElasticsearchTasks(ctx, taskIdToCancel, expandStringList(d.Get("actions").([]interface{})), expandStringList(d.Get("nodes").([]interface{})), d.Get("wait_for_completion").(bool))
+	if diags.HasError() {
+		return diags
+	}
+	cancelled := make([]string, len(tasks))
+	for i, task := range tasks {
+		cancelled[i] = taskId(task)
+	}
+	if taskIdToCancel != "" && len(cancelled) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The task is not running",
+			Detail:   fmt.Sprintf(`The task "%s" was not cancelled, since it's not running anymore.`, taskIdToCancel),
+		})
+	}
+
+	name := taskIdToCancel
+	if name == "" {
+		name = "task-cancel"
+	}
+	id, idDiags := client.ID(ctx, name)
+	if idDiags.HasError() {
+		return idDiags
+	}
+	if err := d.Set("cancelled_tasks", cancelled); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id.String())
+	return append(diags, resourceTaskCancelRead(ctx, d, meta)...)
+}
+
+// The cancellation is done once the request succeeds, there is nothing to read back
+func resourceTaskCancelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+func resourceTaskCancelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
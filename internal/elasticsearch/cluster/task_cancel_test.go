@@ -0,0 +1,38 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceTaskCancel(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTaskCancel,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_task_cancel.test", "id"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_task_cancel.test", "cancelled_tasks.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceTaskCancel = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_task_cancel" "test" {
+  actions = ["*byquery"]
+
+  triggers = {
+    run = "1"
+  }
+}
+`
@@ -0,0 +1,172 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceTasks() *schema.Resource {
+	tasksSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"actions": {
+			Description: "The actions of the tasks to list, wildcards are supported, e.g. `*reindex` or `indices:admin/forcemerge*`. All the tasks by default.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"nodes": {
+			Description: "The IDs or the names of the nodes to list the tasks of, all the nodes by default.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"parent_task_id": {
+			Description: "List only the child tasks of the task, e.g. the slices of the reindex, given as `<node_id>:<task_number>`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"tasks": {
+			Description: "The running tasks, ordered by the node and the task number.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"task_id": {
+						Description: "The ID of the task, `<node_id>:<task_number>`, e.g. the `task_id` of `elasticstack_elasticsearch_reindex`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"node_id": {
+						Description: "The ID of the node running the task.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"node_name": {
+						Description: "The name of the node running the task.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"action": {
+						Description: "The action of the task, e.g. `indices:data/write/reindex`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"type": {
+						Description: "The type of the task, e.g. `transport` or `persistent`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"description": {
+						Description: "The description of the task, e.g. the source and the destination of the reindex.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"start_time": {
+						Description: "When the task started, in RFC 3339 format.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"running_time": {
+						Description: "How long the task has been running, e.g. `1m30s`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"cancellable": {
+						Description: "Whether the task can be cancelled.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"cancelled": {
+						Description: "Whether the task was cancelled and is still finishing.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"parent_task_id": {
+						Description: "The ID of the parent task, if any.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(tasksSchema)
+
+	return &schema.Resource{
+		Description: "Lists the tasks running in the cluster, e.g. to monitor the reindex or the force merge operations. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/tasks.html",
+
+		ReadContext: dataSourceTasksRead,
+
+		Schema: tasksSchema,
+	}
+}
+
+func dataSourceTasksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "tasks")
+	if diags.HasError() {
+		return diags
+	}
+
+	tasks, diags := client.GetElasticsearchTasks(ctx, expandStringList(d.Get("actions").([]interface{})), expandStringList(d.Get("nodes").([]interface{})), d.Get("parent_task_id").(string))
+	if diags.HasError() {
+		return diags
+	}
+	result := make([]interface{}, len(tasks))
+	for i, task := range tasks {
+		result[i] = flattenTask(task)
+	}
+	if err := d.Set("tasks", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+func taskId(task models.TaskInfo) string {
+	return fmt.Sprintf("%s:%d", task.Node, task.Id)
+}
+
+func flattenTask(task models.TaskInfo) map[string]interface{} {
+	return map[string]interface{}{
+		"task_id":        taskId(task),
+		"node_id":        task.Node,
+		"node_name":      task.NodeName,
+		"action":         task.Action,
+		"type":           task.Type,
+		"description":    task.Description,
+		"start_time":     time.UnixMilli(task.StartTimeInMillis).UTC().Format(time.RFC3339),
+		"running_time":   time.Duration(task.RunningTimeInNanos).String(),
+		"cancellable":    task.Cancellable,
+		"cancelled":      task.Cancelled,
+		"parent_task_id": task.ParentTaskId,
+	}
+}
+
+func expandStringList(v []interface{}) []string {
+	list := make([]string, 0, len(v))
+	for _, e := range v {
+		list = append(list, e.(string))
+	}
+	return list
+}
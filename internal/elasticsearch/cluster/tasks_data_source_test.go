@@ -0,0 +1,38 @@
+package cluster_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTasks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTasks,
+				Check: resource.ComposeTestCheckFunc(
+					// the listing of the tasks is a running task itself
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_tasks.test", "tasks.0.action", regexp.MustCompile(`^cluster:monitor/tasks/lists`)),
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_tasks.test", "tasks.0.task_id", regexp.MustCompile(`^.+:\d+$`)),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_tasks.test", "tasks.0.node_name"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_tasks.test", "tasks.0.start_time"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceTasks = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_tasks" "test" {
+  actions = ["cluster:monitor/tasks/lists*"]
+}
+`
@@ -0,0 +1,167 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceVotingConfigExclusions() *schema.Resource {
+	exclusionsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"node_names": {
+			Description: "The names of the master-eligible nodes to exclude from the voting configuration.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"timeout": {
+			Description:  "How long to wait for the nodes to be removed from the voting configuration, e.g. `1m`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "30s",
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"wait_for_removal": {
+			Description: "Whether to wait for the excluded nodes to leave the cluster before the exclusions are cleared on destroy.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		"exclusions": {
+			Description: "The current voting configuration exclusions of the cluster.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"node_id": {
+						Description: "The ID of the excluded node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"node_name": {
+						Description: "The name of the excluded node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(exclusionsSchema)
+
+	return &schema.Resource{
+		Description: "Excludes the master-eligible nodes from the voting configuration of the cluster, e.g. before they are decommissioned. The exclusions are cluster-wide, so only one resource should manage them. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/voting-config-exclusions.html",
+
+		CreateContext: resourceVotingConfigExclusionsPut,
+		UpdateContext: resourceVotingConfigExclusionsPut,
+		ReadContext:   resourceVotingConfigExclusionsRead,
+		DeleteContext: resourceVotingConfigExclusionsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: exclusionsSchema,
+	}
+}
+
+func resourceVotingConfigExclusionsPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "voting-config-exclusions")
+	if diags.HasError() {
+		return diags
+	}
+	timeout, err := utils.ParseElasticDuration(d.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldNames, newNames := d.GetChange("node_names")
+	nodeNames := newNames.(*schema.Set)
+	// the exclusions can only be cleared all at once, so they are cleared and added again when any node is removed from them
+	if oldNames.(*schema.Set).Difference(nodeNames).Len() > 0 {
+		if diags := client.DeleteElasticsearchVotingConfigExclusions(ctx, false); diags.HasError() {
+			return diags
+		}
+	} else {
+		nodeNames = nodeNames.Difference(oldNames.(*schema.Set))
+	}
+
+	if nodeNames.Len() > 0 {
+		names := make([]string, nodeNames.Len())
+		for i, n := range nodeNames.List() {
+			names[i] = n.(string)
+		}
+		if diags := client.PostElasticsearchVotingConfigExclusions(ctx, names, timeout); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId(id.String())
+	return resourceVotingConfigExclusionsRead(ctx, d, meta)
+}
+
+func resourceVotingConfigExclusionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exclusions, diags := client.GetElasticsearchVotingConfigExclusions(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if len(exclusions) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	nodeNames := make([]string, len(exclusions))
+	flatExclusions := make([]interface{}, len(exclusions))
+	for i, e := range exclusions {
+		nodeNames[i] = e.NodeName
+		flatExclusions[i] = map[string]interface{}{
+			"node_id":   e.NodeId,
+			"node_name": e.NodeName,
+		}
+	}
+	if err := d.Set("node_names", nodeNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("exclusions", flatExclusions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceVotingConfigExclusionsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := client.DeleteElasticsearchVotingConfigExclusions(ctx, d.Get("wait_for_removal").(bool)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
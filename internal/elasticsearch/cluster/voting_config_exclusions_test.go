@@ -0,0 +1,63 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceVotingConfigExclusions(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceVotingConfigExclusionsDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				// the nodes which are not part of the cluster can be excluded too, without affecting the test cluster
+				Config: testAccResourceVotingConfigExclusions(`["decommissioned-1", "decommissioned-2"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_voting_config_exclusions.test", "node_names.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_voting_config_exclusions.test", "exclusions.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceVotingConfigExclusions(`["decommissioned-2"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_voting_config_exclusions.test", "node_names.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_voting_config_exclusions.test", "exclusions.0.node_name", "decommissioned-2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVotingConfigExclusions(nodeNames string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_voting_config_exclusions" "test" {
+  node_names       = %s
+  wait_for_removal = false
+}
+	`, nodeNames)
+}
+
+func checkResourceVotingConfigExclusionsDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	exclusions, diags := client.GetElasticsearchVotingConfigExclusions(context.Background())
+	if diags.HasError() {
+		return fmt.Errorf("Failed to get the voting configuration exclusions: %v", diags)
+	}
+	if len(exclusions) > 0 {
+		return fmt.Errorf("The voting configuration exclusions were not cleared: %+v", exclusions)
+	}
+	return nil
+}
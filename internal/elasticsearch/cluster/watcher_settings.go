@@ -0,0 +1,300 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maps the resource attributes to the cluster settings they manage
+var watcherSettings = map[string]string{
+	"default_throttle_period":  "xpack.watcher.execution.default_throttle_period",
+	"execution_scroll_size":    "xpack.watcher.execution.scroll.size",
+	"execution_scroll_timeout": "xpack.watcher.execution.scroll.timeout",
+}
+
+// maps the attributes of the watches_index block to the settings of the index of the watches
+var watchesIndexSettings = map[string]string{
+	"number_of_replicas":   "index.number_of_replicas",
+	"auto_expand_replicas": "index.auto_expand_replicas",
+}
+
+func ResourceWatcherSettings() *schema.Resource {
+	attributes := []string{"default_throttle_period", "execution_scroll_size", "execution_scroll_timeout", "watches_index"}
+
+	watcherSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"default_throttle_period": {
+			Description:  "The throttle period of the watches which don't set their own, i.e. how long the actions are not executed again after they ran (`xpack.watcher.execution.default_throttle_period`), e.g. `5s`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: attributes,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"execution_scroll_size": {
+			Description:  "The number of the watches loaded in one batch when the watches are executed (`xpack.watcher.execution.scroll.size`).",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			AtLeastOneOf: attributes,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"execution_scroll_timeout": {
+			Description:  "How long the search context of the batches of the watches is kept (`xpack.watcher.execution.scroll.timeout`), e.g. `30s`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: attributes,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"watches_index": {
+			Description:  "The settings of the `.watches` index, which are updated through the dedicated Watcher settings API. Requires Elasticsearch 8.10 or higher. The unset attributes keep their values in the cluster, removing the block resets them to the defaults.",
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			AtLeastOneOf: attributes,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"number_of_replicas": {
+						Description:  "The number of the replicas of the index of the watches (`index.number_of_replicas`), applies only when `auto_expand_replicas` is `false`.",
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"auto_expand_replicas": {
+						Description:  "Expands the number of the replicas of the index of the watches with the number of the data nodes (`index.auto_expand_replicas`), e.g. `0-1`, `0-all` or `false` to disable it.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(false|\d+-(\d+|all))$`), "must be a range, e.g. `0-1` or `0-all`, or `false`"),
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(watcherSchema)
+
+	return &schema.Resource{
+		Description: "Manages the cluster-wide settings of the Watcher execution, e.g. the default throttle period of the watches, instead of setting them through the raw cluster settings. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/notification-settings.html",
+
+		CreateContext: resourceWatcherSettingsPut,
+		UpdateContext: resourceWatcherSettingsPut,
+		ReadContext:   resourceWatcherSettingsRead,
+		DeleteContext: resourceWatcherSettingsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: watcherSchema,
+	}
+}
+
+func resourceWatcherSettingsPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "watcher-settings")
+	if diags.HasError() {
+		return diags
+	}
+
+	persistent := make(map[string]interface{})
+	for attr, setting := range watcherSettings {
+		if v, ok := d.GetOk(attr); ok {
+			persistent[setting] = fmt.Sprint(v)
+		} else {
+			// make sure the setting removed from the configuration is reset to its default value
+			persistent[setting] = nil
+		}
+	}
+
+	if !d.IsNewResource() {
+		expected := make(map[string]interface{})
+		for attr, setting := range watcherSettings {
+			old, _ := d.GetChange(attr)
+			// the unset scroll size is read as zero
+			if old == 0 {
+				old = nil
+			}
+			expected[setting] = old
+		}
+		if diags := checkClusterSettingsConflict(ctx, client, map[string]map[string]interface{}{"persistent": expected}); diags.HasError() {
+			return diags
+		}
+	}
+
+	if diags := client.PutElasticsearchSettings(ctx, map[string]interface{}{"persistent": persistent}); diags.HasError() {
+		return diags
+	}
+
+	if d.HasChange("watches_index") {
+		if diags := client.PutElasticsearchWatcherSettings(ctx, expandWatchesIndexSettings(d)); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId(id.String())
+	diags = resourceWatcherSettingsRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, checkTransientWatcherSettings(ctx, client)...)
+}
+
+// Returns the configured settings of the index of the watches, all the settings are reset once the block is removed
+func expandWatchesIndexSettings(d *schema.ResourceData) map[string]interface{} {
+	settings := make(map[string]interface{})
+	block := d.GetRawConfig().GetAttr("watches_index")
+	if block.IsNull() || !block.IsKnown() || block.LengthInt() == 0 {
+		for _, setting := range watchesIndexSettings {
+			settings[setting] = nil
+		}
+		return settings
+	}
+	configured := block.Index(cty.NumberIntVal(0))
+	for attr, setting := range watchesIndexSettings {
+		// the unset attributes keep the value found in the cluster
+		if configured.GetAttr(attr).IsNull() {
+			continue
+		}
+		settings[setting] = fmt.Sprint(d.Get("watches_index.0." + attr))
+	}
+	return settings
+}
+
+// The transient settings take precedence over the persistent ones, so the managed values would silently have no effect
+func checkTransientWatcherSettings(ctx context.Context, client *clients.ApiClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+	clusterSettings, diags := client.GetElasticsearchSettings(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	transient, ok := clusterSettings["transient"].(map[string]interface{})
+	if !ok {
+		return diags
+	}
+	overridden := make([]string, 0)
+	for _, setting := range watcherSettings {
+		if v, ok := transient[setting]; ok {
+			overridden = append(overridden, fmt.Sprintf("%s=%v", setting, v))
+		}
+	}
+	if len(overridden) == 0 {
+		return diags
+	}
+	sort.Strings(overridden)
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "The Watcher settings are overridden by the transient settings",
+		Detail:   fmt.Sprintf("The transient cluster settings take precedence over the persistent ones managed by the resource: %s", strings.Join(overridden, ", ")),
+	})
+}
+
+func resourceWatcherSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	clusterSettings, diags := client.GetElasticsearchSettings(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	persistent := make(map[string]interface{})
+	if v, ok := clusterSettings["persistent"].(map[string]interface{}); ok {
+		persistent = v
+	}
+	for attr, setting := range watcherSettings {
+		value, _ := persistent[setting].(string)
+		var err error
+		if attr == "execution_scroll_size" {
+			size := 0
+			if value != "" {
+				if size, err = strconv.Atoi(value); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			err = d.Set(attr, size)
+		} else {
+			err = d.Set(attr, value)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// the index of the watches always has settings, they are read only when they are managed
+	if len(d.Get("watches_index").([]interface{})) == 0 {
+		return diags
+	}
+	indexSettings, diags := client.GetElasticsearchWatcherSettings(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	numberOfReplicas := 0
+	if v, ok := indexSettings[watchesIndexSettings["number_of_replicas"]]; ok {
+		if numberOfReplicas, err = strconv.Atoi(fmt.Sprint(v)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	autoExpandReplicas := "false"
+	if v, ok := indexSettings[watchesIndexSettings["auto_expand_replicas"]]; ok {
+		autoExpandReplicas = fmt.Sprint(v)
+	}
+	watchesIndex := []interface{}{map[string]interface{}{
+		"number_of_replicas":   numberOfReplicas,
+		"auto_expand_replicas": autoExpandReplicas,
+	}}
+	if err := d.Set("watches_index", watchesIndex); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceWatcherSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	persistent := make(map[string]interface{})
+	for _, setting := range watcherSettings {
+		persistent[setting] = nil
+	}
+	if diags := client.PutElasticsearchSettings(ctx, map[string]interface{}{"persistent": persistent}); diags.HasError() {
+		return diags
+	}
+	if len(d.Get("watches_index").([]interface{})) > 0 {
+		indexSettings := make(map[string]interface{})
+		for _, setting := range watchesIndexSettings {
+			indexSettings[setting] = nil
+		}
+		if diags := client.PutElasticsearchWatcherSettings(ctx, indexSettings); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
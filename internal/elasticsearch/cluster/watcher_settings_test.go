@@ -0,0 +1,164 @@
+package cluster_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceWatcherSettings(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceWatcherSettingsDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceWatcherSettingsCreate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "default_throttle_period", "1m"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "execution_scroll_size", "100"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "execution_scroll_timeout", "1m"),
+				),
+			},
+			{
+				Config: testAccResourceWatcherSettingsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "default_throttle_period", "30s"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "execution_scroll_size", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "execution_scroll_timeout", ""),
+				),
+			},
+			{
+				Config:      testAccResourceWatcherSettingsInvalid,
+				ExpectError: regexp.MustCompile(`expected execution_scroll_size to be at least \(1\)`),
+			},
+		},
+	})
+}
+
+const testAccResourceWatcherSettingsCreate = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_watcher_settings" "test" {
+  default_throttle_period  = "1m"
+  execution_scroll_size    = 100
+  execution_scroll_timeout = "1m"
+}
+`
+
+const testAccResourceWatcherSettingsUpdate = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_watcher_settings" "test" {
+  default_throttle_period = "30s"
+}
+`
+
+const testAccResourceWatcherSettingsInvalid = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_watcher_settings" "test" {
+  default_throttle_period = "30s"
+  execution_scroll_size   = -1
+}
+`
+
+func TestAccResourceWatcherSettingsWatchesIndex(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceWatcherSettingsDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceWatcherSettingsWatchesIndex,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "default_throttle_period", "1m"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "watches_index.0.auto_expand_replicas", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watcher_settings.test", "watches_index.0.number_of_replicas", "0"),
+				),
+			},
+			{
+				Config:      testAccResourceWatcherSettingsWatchesIndexInvalid,
+				ExpectError: regexp.MustCompile(`must be a range`),
+			},
+		},
+	})
+}
+
+const testAccResourceWatcherSettingsWatchesIndex = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_watcher_settings" "test" {
+  default_throttle_period = "1m"
+
+  watches_index {
+    auto_expand_replicas = "false"
+    number_of_replicas   = 0
+  }
+}
+`
+
+const testAccResourceWatcherSettingsWatchesIndexInvalid = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_watcher_settings" "test" {
+  default_throttle_period = "1m"
+
+  watches_index {
+    auto_expand_replicas = "0-many"
+  }
+}
+`
+
+func checkResourceWatcherSettingsDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	listOfSettings := []string{
+		"xpack.watcher.execution.default_throttle_period",
+		"xpack.watcher.execution.scroll.size",
+		"xpack.watcher.execution.scroll.timeout",
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_watcher_settings" {
+			continue
+		}
+
+		req := client.GetESClient().Cluster.GetSettings.WithFlatSettings(true)
+		res, err := client.GetESClient().Cluster.GetSettings(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		clusterSettings := make(map[string]interface{})
+		if err := json.NewDecoder(res.Body).Decode(&clusterSettings); err != nil {
+			return err
+		}
+
+		if settings, ok := clusterSettings["persistent"].(map[string]interface{}); ok {
+			for _, s := range listOfSettings {
+				if v, ok := settings[s]; ok {
+					return fmt.Errorf(`Setting "%s=%s" still in the cluster, but it should be removed`, s, v)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const indexSettingAnalysisPrefix = "index.analysis."
+
+// The analysis components, which can be referenced by the analyzers and the normalizers without being defined in the index
+var (
+	builtinTokenizers = []string{
+		"char_group", "classic", "edge_ngram", "keyword", "letter", "lowercase", "ngram", "path_hierarchy", "pattern",
+		"simple_pattern", "simple_pattern_split", "standard", "thai", "uax_url_email", "whitespace",
+	}
+	builtinTokenFilters = []string{
+		"apostrophe", "arabic_normalization", "asciifolding", "bengali_normalization", "cjk_bigram", "cjk_width", "classic",
+		"common_grams", "decimal_digit", "delimited_payload", "dictionary_decompounder", "edge_ngram", "elision", "fingerprint",
+		"flatten_graph", "german_normalization", "hindi_normalization", "hyphenation_decompounder", "indic_normalization",
+		"keep", "keep_types", "keyword_marker", "keyword_repeat", "kstem", "length", "limit", "lowercase", "min_hash",
+		"ngram", "persian_normalization", "porter_stem", "remove_duplicates", "reverse", "scandinavian_folding",
+		"scandinavian_normalization", "serbian_normalization", "shingle", "snowball", "sorani_normalization", "stemmer",
+		"stop", "trim", "truncate", "unique", "uppercase", "word_delimiter", "word_delimiter_graph",
+	}
+	builtinCharFilters = []string{"html_strip"}
+)
+
+// The analysis components of the index, rendered as the `index.analysis.*` settings
+var indexAnalysisComponents = []string{"analyzer", "normalizer", "tokenizer", "filter", "char_filter"}
+
+// The names of the components referenced by the analyzers and the normalizers, as they are reported
+var analysisReferenceNames = map[string]string{
+	"filter":      "token filter",
+	"char_filter": "character filter",
+}
+
+func indexAnalysisSchema() *schema.Schema {
+	componentSchema := func(description string, extra map[string]*schema.Schema) *schema.Schema {
+		s := map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the component, which is referenced in the mappings and by the other components.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"type": {
+				Description: "The type of the component.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"parameters": {
+				Description:      "The other parameters of the component as JSON, e.g. `{\"stopwords\": [\"and\", \"the\"]}`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: utils.DiffJsonSuppress,
+			},
+		}
+		for k, v := range extra {
+			s[k] = v
+		}
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: s,
+			},
+		}
+	}
+	references := func(component string) *schema.Schema {
+		return &schema.Schema{
+			Description: fmt.Sprintf("The names of the %s applied in order, either the built-in ones or the ones defined in the `analysis` block.", component),
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+
+	return &schema.Schema{
+		Description: "The analysis components of the index, i.e. the `index.analysis.*` settings. The references between the components are validated when planning. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis.html",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"analyzer": componentSchema("The analyzers. The `custom` analyzer is built from the tokenizer, the token filters and the character filters.", map[string]*schema.Schema{
+					"type": {
+						Description: "The type of the analyzer, e.g. `custom` or the built-in analyzer like `standard` configured with the parameters.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "custom",
+					},
+					"tokenizer": {
+						Description: "The tokenizer of the `custom` analyzer, either the built-in one or the one defined in the `analysis` block.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"filter":      references("token filters"),
+					"char_filter": references("character filters"),
+				}),
+				"normalizer": componentSchema("The normalizers, which are applied to the keyword fields.", map[string]*schema.Schema{
+					"type": {
+						Description: "The type of the normalizer.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "custom",
+					},
+					"filter":      references("token filters"),
+					"char_filter": references("character filters"),
+				}),
+				"tokenizer":   componentSchema("The tokenizers.", nil),
+				"filter":      componentSchema("The token filters.", nil),
+				"char_filter": componentSchema("The character filters.", nil),
+			},
+		},
+	}
+}
+
+// Returns the analysis components as the flat `index.analysis.*` settings
+func expandIndexAnalysis(v interface{}) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
+		return settings, nil
+	}
+	analysis := l[0].(map[string]interface{})
+	for _, kind := range indexAnalysisComponents {
+		components, _ := analysis[kind].([]interface{})
+		for _, c := range components {
+			component := c.(map[string]interface{})
+			prefix := fmt.Sprintf("%s%s.%s.", indexSettingAnalysisPrefix, kind, component["name"].(string))
+			if p, ok := component["parameters"].(string); ok && p != "" {
+				params := make(map[string]interface{})
+				if err := json.Unmarshal([]byte(p), &params); err != nil {
+					return nil, err
+				}
+				for k, v := range utils.FlattenMap(params) {
+					settings[prefix+k] = v
+				}
+			}
+			settings[prefix+"type"] = component["type"].(string)
+			if t, ok := component["tokenizer"].(string); ok && t != "" {
+				settings[prefix+"tokenizer"] = t
+			}
+			for _, ref := range []string{"filter", "char_filter"} {
+				if refs, ok := component[ref].([]interface{}); ok && len(refs) > 0 {
+					settings[prefix+ref] = refs
+				}
+			}
+		}
+	}
+	return settings, nil
+}
+
+// Checks the components are defined once and the analyzers and the normalizers only reference the existing components
+func validateIndexAnalysis(v interface{}) error {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	analysis := l[0].(map[string]interface{})
+
+	defined := make(map[string]map[string]bool)
+	for _, kind := range indexAnalysisComponents {
+		defined[kind] = make(map[string]bool)
+		components, _ := analysis[kind].([]interface{})
+		for _, c := range components {
+			name := c.(map[string]interface{})["name"].(string)
+			if defined[kind][name] {
+				return fmt.Errorf(`the %s "%s" is defined more than once in the analysis block`, kind, name)
+			}
+			defined[kind][name] = true
+		}
+	}
+	for _, name := range builtinTokenizers {
+		defined["tokenizer"][name] = true
+	}
+	for _, name := range builtinTokenFilters {
+		defined["filter"][name] = true
+	}
+	for _, name := range builtinCharFilters {
+		defined["char_filter"][name] = true
+	}
+
+	undefined := make([]string, 0)
+	for _, kind := range []string{"analyzer", "normalizer"} {
+		components, _ := analysis[kind].([]interface{})
+		for _, c := range components {
+			component := c.(map[string]interface{})
+			name := component["name"].(string)
+			tokenizer, _ := component["tokenizer"].(string)
+			if kind == "analyzer" && component["type"].(string) == "custom" && tokenizer == "" {
+				return fmt.Errorf(`the custom analyzer "%s" requires the tokenizer`, name)
+			}
+			if tokenizer != "" && !defined["tokenizer"][tokenizer] {
+				undefined = append(undefined, fmt.Sprintf(`the %s "%s" references the undefined tokenizer "%s"`, kind, name, tokenizer))
+			}
+			for _, ref := range []string{"filter", "char_filter"} {
+				refs, _ := component[ref].([]interface{})
+				for _, r := range refs {
+					if r, _ := r.(string); !defined[ref][r] {
+						undefined = append(undefined, fmt.Sprintf(`the %s "%s" references the undefined %s "%s"`, kind, name, analysisReferenceNames[ref], r))
+					}
+				}
+			}
+		}
+	}
+	if len(undefined) > 0 {
+		sort.Strings(undefined)
+		return fmt.Errorf("%s. Define the components in the analysis block, the ones provided by the plugins are defined with the same name and type", strings.Join(undefined, ", "))
+	}
+	return nil
+}
+
+// Validates the analysis components, which must not be set in the settings at the same time
+func validateIndexAnalysisChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.GetRawConfig().GetAttr("analysis").IsWhollyKnown() {
+		return nil
+	}
+	if err := validateIndexAnalysis(d.Get("analysis")); err != nil {
+		return err
+	}
+	if len(d.Get("analysis").([]interface{})) == 0 || !d.NewValueKnown("settings") {
+		return nil
+	}
+	if names := analysisSettings(flattenIndexSettings(d.Get("settings").([]interface{}))); len(names) > 0 {
+		return fmt.Errorf("the settings [%s] are managed by the analysis block, they cannot be set in the settings too", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// Validates the analysis components of the template, which must not be set in the settings at the same time
+func validateTemplateAnalysis(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.GetRawConfig().GetAttr("template").IsWhollyKnown() {
+		return nil
+	}
+	analysis := d.Get("template.0.analysis")
+	if err := validateIndexAnalysis(analysis); err != nil {
+		return err
+	}
+	settings := d.Get("template.0.settings").(string)
+	if len(analysis.([]interface{})) == 0 || settings == "" {
+		return nil
+	}
+	sets := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(settings), &sets); err != nil {
+		return nil
+	}
+	if names := analysisSettings(sets); len(names) > 0 {
+		return fmt.Errorf("the settings [%s] are managed by the analysis block, they cannot be set in the settings too", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// Returns the names of the configured settings which are managed by the analysis block
+func analysisSettings(settings map[string]interface{}) []string {
+	names := make([]string, 0)
+	for name := range utils.NormalizeIndexSettings(utils.FlattenMap(settings)) {
+		if strings.HasPrefix(name, indexSettingAnalysisPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -127,6 +127,8 @@ func ResourceComponentTemplate() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
 		Schema: componentTemplateSchema,
 	}
 }
@@ -137,7 +139,7 @@ func resourceComponentTemplatePut(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 	componentId := d.Get("name").(string)
-	id, diags := client.ID(componentId)
+	id, diags := client.ID(ctx, componentId)
 	if diags.HasError() {
 		return diags
 	}
@@ -216,12 +218,19 @@ func resourceComponentTemplatePut(ctx context.Context, d *schema.ResourceData, m
 		componentTemplate.Version = &definedVer
 	}
 
-	if diags := client.PutElasticsearchComponentTemplate(&componentTemplate); diags.HasError() {
-		return diags
+	if componentTemplate.Template != nil && componentTemplate.Template.Settings != nil {
+		if diags := checkTemplatePipelines(ctx, client, componentTemplate.Template.Settings); diags.HasError() {
+			return diags
+		}
+	}
+
+	putDiags := client.PutElasticsearchComponentTemplate(ctx, &componentTemplate)
+	if putDiags.HasError() {
+		return putDiags
 	}
 
 	d.SetId(id.String())
-	return resourceComponentTemplateRead(ctx, d, meta)
+	return append(resourceComponentTemplateRead(ctx, d, meta), putDiags...)
 }
 
 func resourceComponentTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -236,7 +245,7 @@ func resourceComponentTemplateRead(ctx context.Context, d *schema.ResourceData,
 	}
 	templateId := compId.ResourceId
 
-	tpl, diags := client.GetElasticsearchComponentTemplate(templateId)
+	tpl, diags := client.GetElasticsearchComponentTemplate(ctx, templateId)
 	if tpl == nil && diags == nil {
 		d.SetId("")
 		return diags
@@ -290,7 +299,7 @@ func resourceComponentTemplateDelete(ctx context.Context, d *schema.ResourceData
 	if diags.HasError() {
 		return diags
 	}
-	if diags := client.DeleteElasticsearchComponentTemplate(compId.ResourceId); diags.HasError() {
+	if diags := client.DeleteElasticsearchComponentTemplate(ctx, compId.ResourceId); diags.HasError() {
 		return diags
 	}
 	d.SetId("")
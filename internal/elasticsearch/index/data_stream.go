@@ -3,11 +3,12 @@ package index
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"fmt"
 	"regexp"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -98,13 +99,17 @@ func ResourceDataStream() *schema.Resource {
 		},
 	}
 
+	for k, v := range healthCheckSchema() {
+		dataStreamSchema[k] = v
+	}
+
 	utils.AddConnectionSchema(dataStreamSchema)
 
 	return &schema.Resource{
 		Description: "Managing Elasticsearch data streams, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/data-stream-apis.html",
 
 		CreateContext: resourceDataStreamPut,
-		UpdateContext: resourceDataStreamPut,
+		UpdateContext: resourceDataStreamUpdate,
 		ReadContext:   resourceDataStreamRead,
 		DeleteContext: resourceDataStreamDelete,
 
@@ -112,6 +117,8 @@ func ResourceDataStream() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
 		Schema: dataStreamSchema,
 	}
 }
@@ -122,16 +129,31 @@ func resourceDataStreamPut(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 	dsId := d.Get("name").(string)
-	id, diags := client.ID(dsId)
+	id, diags := client.ID(ctx, dsId)
 	if diags.HasError() {
 		return diags
 	}
 
-	if diags := client.PutElasticsearchDataStream(dsId); diags.HasError() {
+	if diags := client.PutElasticsearchDataStream(ctx, dsId); diags.HasError() {
 		return diags
 	}
 
 	d.SetId(id.String())
+	if diags := checkIndexHealth(ctx, client, d, dsId); diags.HasError() {
+		return diags
+	}
+	return resourceDataStreamRead(ctx, d, meta)
+}
+
+// Only the health check attributes can change, the data stream is checked again with them
+func resourceDataStreamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := checkIndexHealth(ctx, client, d, d.Get("name").(string)); diags.HasError() {
+		return diags
+	}
 	return resourceDataStreamRead(ctx, d, meta)
 }
 
@@ -147,7 +169,7 @@ func resourceDataStreamRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diags
 	}
 
-	ds, diags := client.GetElasticsearchDataStream(compId.ResourceId)
+	ds, diags := client.GetElasticsearchDataStream(ctx, compId.ResourceId)
 	if ds == nil && diags == nil {
 		// no data stream found on ES side
 		d.SetId("")
@@ -156,7 +178,7 @@ func resourceDataStreamRead(ctx context.Context, d *schema.ResourceData, meta in
 	if diags.HasError() {
 		return diags
 	}
-	log.Printf("[TRACE] read the data stream data: %+v", ds)
+	tflog.Trace(ctx, fmt.Sprintf("read the data stream data: %+v", ds))
 
 	if err := d.Set("name", ds.Name); err != nil {
 		return diag.FromErr(err)
@@ -220,7 +242,7 @@ func resourceDataStreamDelete(ctx context.Context, d *schema.ResourceData, meta
 	if diags.HasError() {
 		return diags
 	}
-	if diags := client.DeleteElasticsearchDataStream(compId.ResourceId); diags.HasError() {
+	if diags := client.DeleteElasticsearchDataStream(ctx, compId.ResourceId); diags.HasError() {
 		return diags
 	}
 
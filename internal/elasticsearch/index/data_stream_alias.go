@@ -0,0 +1,210 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceDataStreamAlias() *schema.Resource {
+	aliasSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the data stream alias.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"data_streams": {
+			Description: "Names of the data streams the alias points to.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"write_data_stream": {
+			Description: "Name of the data stream the write requests to the alias are sent to. Must be one of the `data_streams`. Without the write data stream the alias is read-only.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"filter": {
+			Description:      "Query used to limit the documents the alias can access, applied to all the data streams.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+	}
+
+	utils.AddConnectionSchema(aliasSchema)
+
+	return &schema.Resource{
+		Description: "Manages an alias pointing to the data streams, e.g. to write into a new data stream while still reading from the old one. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/aliases.html",
+
+		CreateContext: resourceDataStreamAliasPut,
+		UpdateContext: resourceDataStreamAliasPut,
+		ReadContext:   resourceDataStreamAliasRead,
+		DeleteContext: resourceDataStreamAliasDelete,
+
+		CustomizeDiff: validateWriteDataStream,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: aliasSchema,
+	}
+}
+
+func validateWriteDataStream(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	writeDataStream := d.Get("write_data_stream").(string)
+	if writeDataStream == "" || !d.NewValueKnown("data_streams") {
+		return nil
+	}
+	if !d.Get("data_streams").(*schema.Set).Contains(writeDataStream) {
+		return fmt.Errorf(`write_data_stream "%s" must be one of the data_streams`, writeDataStream)
+	}
+	return nil
+}
+
+func resourceDataStreamAliasPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	aliasName := d.Get("name").(string)
+	id, diags := client.ID(ctx, aliasName)
+	if diags.HasError() {
+		return diags
+	}
+
+	var filter map[string]interface{}
+	if v, ok := d.GetOk("filter"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &filter); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	writeDataStream := d.Get("write_data_stream").(string)
+
+	// all the changes are sent in a single request, so the alias is updated atomically
+	actions := make([]map[string]interface{}, 0)
+	oldDataStreams, newDataStreams := d.GetChange("data_streams")
+	for _, ds := range oldDataStreams.(*schema.Set).Difference(newDataStreams.(*schema.Set)).List() {
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]interface{}{"index": ds.(string), "alias": aliasName},
+		})
+	}
+	for _, ds := range newDataStreams.(*schema.Set).List() {
+		add := map[string]interface{}{"index": ds.(string), "alias": aliasName}
+		if writeDataStream != "" || d.HasChange("write_data_stream") {
+			add["is_write_index"] = ds.(string) == writeDataStream
+		}
+		if filter != nil {
+			add["filter"] = filter
+		}
+		actions = append(actions, map[string]interface{}{"add": add})
+	}
+	if diags := client.UpdateElasticsearchAliases(ctx, actions); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceDataStreamAliasRead(ctx, d, meta)
+}
+
+func resourceDataStreamAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	aliasName := compId.ResourceId
+
+	aliases, diags := client.GetElasticsearchAlias(ctx, aliasName)
+	if diags.HasError() {
+		return diags
+	}
+	if len(aliases) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	dataStreams := make([]string, 0, len(aliases))
+	writeDataStream := ""
+	var filter map[string]interface{}
+	for ds, alias := range aliases {
+		dataStreams = append(dataStreams, ds)
+		if alias.IsWriteIndex {
+			writeDataStream = ds
+		}
+		if alias.Filter != nil {
+			filter = alias.Filter
+		}
+	}
+	sort.Strings(dataStreams)
+
+	if err := d.Set("name", aliasName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("data_streams", dataStreams); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("write_data_stream", writeDataStream); err != nil {
+		return diag.FromErr(err)
+	}
+	if filter != nil {
+		f, err := json.Marshal(filter)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("filter", string(f)); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := d.Set("filter", ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDataStreamAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	actions := make([]map[string]interface{}, 0)
+	for _, ds := range d.Get("data_streams").(*schema.Set).List() {
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]interface{}{"index": ds.(string), "alias": compId.ResourceId},
+		})
+	}
+	if diags := client.UpdateElasticsearchAliases(ctx, actions); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,95 @@
+package index_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceDataStreamAlias(t *testing.T) {
+	// generate a random name
+	name := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceDataStreamAliasDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDataStreamAlias(name, "old"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream_alias.test", "name", name),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream_alias.test", "data_streams.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream_alias.test", "write_data_stream", fmt.Sprintf("%s-old", name)),
+				),
+			},
+			{
+				Config: testAccResourceDataStreamAlias(name, "new"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream_alias.test", "data_streams.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream_alias.test", "write_data_stream", fmt.Sprintf("%s-new", name)),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceDataStreamAlias(name, write string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name = "%[1]s"
+
+  index_patterns = ["%[1]s-*"]
+
+  data_stream {}
+}
+
+resource "elasticstack_elasticsearch_data_stream" "old" {
+  name = "%[1]s-old"
+
+  depends_on = [elasticstack_elasticsearch_index_template.test]
+}
+
+resource "elasticstack_elasticsearch_data_stream" "new" {
+  name = "%[1]s-new"
+
+  depends_on = [elasticstack_elasticsearch_index_template.test]
+}
+
+resource "elasticstack_elasticsearch_data_stream_alias" "test" {
+  name              = "%[1]s"
+  data_streams      = [elasticstack_elasticsearch_data_stream.old.name, elasticstack_elasticsearch_data_stream.new.name]
+  write_data_stream = elasticstack_elasticsearch_data_stream.%[2]s.name
+}
+	`, name, write)
+}
+
+func checkResourceDataStreamAliasDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_data_stream_alias" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		aliases, diags := client.GetElasticsearchAlias(context.Background(), compId.ResourceId)
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get alias: %v", diags)
+		}
+		if len(aliases) > 0 {
+			return fmt.Errorf("Data stream alias (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
@@ -89,6 +89,53 @@ resource "elasticstack_elasticsearch_data_stream" "test_ds" {
 	`, name, name, name, name)
 }
 
+func TestAccResourceDataStreamHealthCheck(t *testing.T) {
+	dsName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceDataStreamDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDataStreamHealthCheck(dsName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream.test_ds", "health_check.0.status", "yellow"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream.test_ds", "health_check.0.timeout", "30s"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceDataStreamHealthCheck(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test_ds_template" {
+  name = "%s"
+
+  index_patterns = ["%s*"]
+
+  data_stream {}
+}
+
+resource "elasticstack_elasticsearch_data_stream" "test_ds" {
+  name = "%s"
+
+  depends_on = [
+    elasticstack_elasticsearch_index_template.test_ds_template
+  ]
+
+  health_check {
+    status = "yellow"
+  }
+}
+	`, name, name, name)
+}
+
 func checkResourceDataStreamDestroy(s *terraform.State) error {
 	client := acctest.Provider.Meta().(*clients.ApiClient)
 
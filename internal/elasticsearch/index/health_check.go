@@ -0,0 +1,93 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// the timeout of the health check, when only the active shards are waited for
+const defaultHealthCheckTimeout = "30s"
+
+// The attributes to check the health of the index or the data stream once it is created or updated
+func healthCheckSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"wait_for_active_shards": {
+			Description:  "The number of the active shards to wait for once the index or the data stream is created or updated, either a number or `all`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(all|\d+)$`), "must be a number or `all`"),
+		},
+		"health_check": {
+			Description: "Wait until the index or the data stream reaches the health status once it is created or updated, and fail the apply otherwise.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"status": {
+						Description:  "The status to wait for, either `green` or `yellow`. The `green` status also satisfies `yellow`.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "yellow",
+						ValidateFunc: validation.StringInSlice([]string{"green", "yellow"}, false),
+					},
+					"timeout": {
+						Description:  "How long to wait for the status, e.g. `1m`.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      defaultHealthCheckTimeout,
+						ValidateFunc: utils.StringIsElasticDuration,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Waits for the health status and the active shards of the target configured by the health check attributes
+func checkIndexHealth(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData, target string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	activeShards := d.Get("wait_for_active_shards").(string)
+	status, timeout := "", defaultHealthCheckTimeout
+	if v, ok := d.GetOk("health_check"); ok && v.([]interface{})[0] != nil {
+		check := v.([]interface{})[0].(map[string]interface{})
+		status = check["status"].(string)
+		timeout = check["timeout"].(string)
+	}
+	if status == "" && activeShards == "" {
+		return diags
+	}
+	wait, err := utils.ParseElasticDuration(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	health, diags := client.GetElasticsearchClusterHealth(ctx, []string{target}, status, activeShards, wait)
+	if diags.HasError() {
+		return diags
+	}
+	if health.TimedOut {
+		expected := make([]string, 0, 2)
+		if status != "" {
+			expected = append(expected, fmt.Sprintf("the %s status", status))
+		}
+		if activeShards != "" {
+			expected = append(expected, fmt.Sprintf("%s active shards", activeShards))
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`Timed out waiting for the health of "%s"`, target),
+			Detail: fmt.Sprintf("Expected %s within %s, found the %s status with %d active, %d initializing and %d unassigned shards.",
+				strings.Join(expected, " and "), timeout, health.Status, health.ActiveShards, health.InitializingShards, health.UnassignedShards),
+		}}
+	}
+	return diags
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -29,6 +31,67 @@ func ResourceIlm() *schema.Resource {
 			Required:    true,
 			ForceNew:    true,
 		},
+		"prevent_retention_shortening": {
+			Description: "Reject the changes shortening the retention of the indices, i.e. adding the `delete` phase or decreasing its `min_age`, during the plan. Set it to `false` in the same change to confirm the shorter retention.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"modified_date": {
+			Description: "The DateTime of the last modification.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"phase_summary": {
+			Description: "Human-readable table of the phases of the policy, with their minimum age and actions, e.g. to review the changes of the retention in the plan.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	for k, v := range ilmPolicySchema() {
+		ilmSchema[k] = v
+	}
+	// the policy is defined either by the phase blocks or by the JSON
+	phaseKeys := append(supportedIlmPhases[:], "policy_json")
+	for _, ph := range supportedIlmPhases {
+		ilmSchema[ph].AtLeastOneOf = phaseKeys
+	}
+	ilmSchema["policy_json"] = &schema.Schema{
+		Description:      "The policy as JSON, e.g. `{\"phases\": {...}, \"_meta\": {...}}` or the request body of the create lifecycle policy API, instead of the `metadata` and the phase blocks. The defaults Elasticsearch adds to the stored policy, e.g. the `min_age` of the phases, are not reported as the changes.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		AtLeastOneOf:     phaseKeys,
+		ConflictsWith:    append(supportedIlmPhases[:], "metadata"),
+		ValidateFunc:     validation.All(validation.StringIsJSON, validateIlmPolicyJson),
+		DiffSuppressFunc: suppressEquivalentIlmPolicyJson,
+	}
+
+	utils.AddConnectionSchema(ilmSchema)
+
+	return &schema.Resource{
+		Description: "Creates or updates lifecycle policy. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-put-lifecycle.html and https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-index-lifecycle.html",
+
+		CreateContext: resourceIlmPut,
+		UpdateContext: resourceIlmPut,
+		ReadContext:   resourceIlmRead,
+		DeleteContext: resourceIlmDelete,
+
+		CustomizeDiff: customdiff.All(validateIlmRetention, validateIlmDataMigration, planIlmPhaseSummary),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: ilmSchema,
+	}
+}
+
+// The metadata and the phases of the policy, shared with the data source rendering the policy JSON
+func ilmPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
 		"metadata": {
 			Description:      "Optional user metadata about the ilm policy. Must be valid JSON document.",
 			Type:             schema.TypeString,
@@ -86,29 +149,73 @@ func ResourceIlm() *schema.Resource {
 				Schema: getSchema("wait_for_snapshot", "delete"),
 			},
 		},
-		"modified_date": {
-			Description: "The DateTime of the last modification.",
-			Type:        schema.TypeString,
-			Computed:    true,
-		},
 	}
+}
 
-	utils.AddConnectionSchema(ilmSchema)
-
-	return &schema.Resource{
-		Description: "Creates or updates lifecycle policy. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-put-lifecycle.html and https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-index-lifecycle.html",
-
-		CreateContext: resourceIlmPut,
-		UpdateContext: resourceIlmPut,
-		ReadContext:   resourceIlmRead,
-		DeleteContext: resourceIlmDelete,
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+func validateIlmRetention(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("prevent_retention_shortening").(bool) {
+		return nil
+	}
+	var oldDelete, newDelete interface{}
+	oldJson, newJson := d.GetChange("policy_json")
+	switch {
+	case oldJson.(string) != "" || newJson.(string) != "":
+		if !d.HasChange("policy_json") || !d.NewValueKnown("policy_json") {
+			return nil
+		}
+		oldPhases, err := ilmPhasesFromJson(oldJson.(string))
+		if err != nil {
+			return err
+		}
+		newPhases, err := ilmPhasesFromJson(newJson.(string))
+		if err != nil {
+			return err
+		}
+		// the phases switched to or from the blocks are compared with the blocks
+		oldDelete, newDelete = oldPhases.Get("delete"), newPhases.Get("delete")
+		if oldJson.(string) == "" {
+			oldDelete, _ = d.GetChange("delete")
+		}
+		if newJson.(string) == "" {
+			_, newDelete = d.GetChange("delete")
+		}
+	case d.HasChange("delete"):
+		oldDelete, newDelete = d.GetChange("delete")
+	default:
+		return nil
+	}
+	oldRetention, err := ilmRetention(oldDelete.([]interface{}))
+	if err != nil {
+		return err
+	}
+	newRetention, err := ilmRetention(newDelete.([]interface{}))
+	if err != nil {
+		return err
+	}
+	if newRetention != nil && (oldRetention == nil || *newRetention < *oldRetention) {
+		old := "unlimited"
+		if oldRetention != nil {
+			old = oldRetention.String()
+		}
+		return fmt.Errorf("the change shortens the retention of the indices from %s to %s, set prevent_retention_shortening to false to confirm it", old, newRetention.String())
+	}
+	return nil
+}
 
-		Schema: ilmSchema,
+// Returns the retention defined by the delete phase, nil if the indices are never deleted
+func ilmRetention(deletePhase []interface{}) (*time.Duration, error) {
+	if len(deletePhase) == 0 || deletePhase[0] == nil {
+		return nil, nil
 	}
+	var retention time.Duration
+	if minAge := deletePhase[0].(map[string]interface{})["min_age"].(string); minAge != "" {
+		r, err := utils.ParseElasticDuration(minAge)
+		if err != nil {
+			return nil, err
+		}
+		retention = r
+	}
+	return &retention, nil
 }
 
 var suportedActions = map[string]*schema.Schema{
@@ -381,23 +488,50 @@ func resourceIlmPut(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.FromErr(err)
 	}
 	ilmId := d.Get("name").(string)
-	id, diags := client.ID(ilmId)
+	id, diags := client.ID(ctx, ilmId)
 	if diags.HasError() {
 		return diags
 	}
 
-	policy, diags := expandIlmPolicy(d)
-	if diags.HasError() {
+	var policy *models.Policy
+	if policyJson := d.Get("policy_json").(string); policyJson != "" {
+		if policy, err = expandIlmPolicyJson(policyJson); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if policy, diags = expandIlmPolicy(d); diags.HasError() {
 		return diags
 	}
 	policy.Name = ilmId
 
-	if diags := client.PutElasticsearchIlm(policy); diags.HasError() {
+	snapshotDiags := checkIlmWaitForSnapshot(ctx, client, policy)
+	if snapshotDiags.HasError() {
+		return snapshotDiags
+	}
+	if diags := client.PutElasticsearchIlm(ctx, policy); diags.HasError() {
 		return diags
 	}
 
+	// the policy is replaced as a whole, so the phases removed from the configuration are removed from the policy too
+	removedPhases := make([]string, 0)
+	for _, ph := range supportedIlmPhases {
+		if oldPhase, newPhase := d.GetChange(ph); len(oldPhase.([]interface{})) > 0 && len(newPhase.([]interface{})) == 0 {
+			removedPhases = append(removedPhases, ph)
+		}
+	}
+
 	d.SetId(id.String())
-	return resourceIlmRead(ctx, d, meta)
+	diags = resourceIlmRead(ctx, d, meta)
+	if len(removedPhases) > 0 && !diags.HasError() {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Phases removed from the lifecycle policy",
+			Detail:   fmt.Sprintf(`The phases [%s] were removed from the policy "%s". The indices already in these phases complete them, the other indices skip them.`, strings.Join(removedPhases, ", "), ilmId),
+		})
+	}
+	if !diags.HasError() {
+		diags = append(diags, snapshotDiags...)
+	}
+	return diags
 }
 
 func expandIlmPolicy(d *schema.ResourceData) (*models.Policy, diag.Diagnostics) {
@@ -405,8 +539,6 @@ func expandIlmPolicy(d *schema.ResourceData) (*models.Policy, diag.Diagnostics)
 	var policy models.Policy
 	phases := make(map[string]models.Phase)
 
-	policy.Name = d.Get("name").(string)
-
 	if v, ok := d.GetOk("metadata"); ok {
 		metadata := make(map[string]interface{})
 		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
@@ -436,10 +568,12 @@ func expandPhase(p map[string]interface{}, d *schema.ResourceData) (*models.Phas
 	if v := p["min_age"].(string); v != "" {
 		phase.MinAge = v
 	}
-	delete(p, "min_age")
 
 	actions := make(map[string]models.Action)
 	for actionName, action := range p {
+		if actionName == "min_age" {
+			continue
+		}
 		if a := action.([]interface{}); len(a) > 0 {
 			switch actionName {
 			case "allocate":
@@ -538,7 +672,7 @@ func resourceIlmRead(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 	policyId := compId.ResourceId
 
-	ilmDef, diags := client.GetElasticsearchIlm(policyId)
+	ilmDef, diags := client.GetElasticsearchIlm(ctx, policyId)
 	if ilmDef == nil && diags == nil {
 		d.SetId("")
 		return diags
@@ -550,6 +684,27 @@ func resourceIlmRead(ctx context.Context, d *schema.ResourceData, meta interface
 	if err := d.Set("modified_date", ilmDef.Modified); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("name", policyId); err != nil {
+		return diag.FromErr(err)
+	}
+	// the policy defined by the JSON is read back as JSON
+	if d.Get("policy_json").(string) != "" {
+		policyJson, err := json.Marshal(ilmDef.Policy)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("policy_json", string(policyJson)); err != nil {
+			return diag.FromErr(err)
+		}
+		phases, err := ilmPhasesFromJson(string(policyJson))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("phase_summary", ilmPhaseSummary(phases)); err != nil {
+			return diag.FromErr(err)
+		}
+		return diags
+	}
 	if ilmDef.Policy.Metadata != nil {
 		metadata, err := json.Marshal(ilmDef.Policy.Metadata)
 		if err != nil {
@@ -559,79 +714,100 @@ func resourceIlmRead(ctx context.Context, d *schema.ResourceData, meta interface
 			return diag.FromErr(err)
 		}
 	}
-	if err := d.Set("name", policyId); err != nil {
-		return diag.FromErr(err)
-	}
 	for _, ph := range supportedIlmPhases {
+		var phase interface{}
 		if v, ok := ilmDef.Policy.Phases[ph]; ok {
-			phase, diags := flattenPhase(ph, v, d)
+			var phaseDiags diag.Diagnostics
+			phase, phaseDiags = flattenPhase(ph, v)
+			diags = append(diags, phaseDiags...)
 			if diags.HasError() {
 				return diags
 			}
-			if err := d.Set(ph, phase); err != nil {
-				return diag.FromErr(err)
-			}
+		}
+		phase = flattenDisabledActions(phase, d.Get(ph).([]interface{}))
+		// the phases removed outside of Terraform are unset too
+		if err := d.Set(ph, phase); err != nil {
+			return diag.FromErr(err)
 		}
 	}
+	if err := d.Set("phase_summary", ilmPhaseSummary(d)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return diags
 }
 
-func flattenPhase(phaseName string, p models.Phase, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+// The actions which are toggled by their `enabled` attribute, while the disabled ones are left out of the policy
+var toggledActions = []string{"freeze", "readonly", "unfollow"}
+
+// Returns the phase as it's defined in the policy, the attributes missing in the definition are set to their defaults
+func flattenPhase(phaseName string, p models.Phase) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	out := make([]interface{}, 1)
 	phase := make(map[string]interface{})
-	enabled := make(map[string]interface{})
-	ns := make(map[string]interface{})
+	phaseSchema := ilmPolicySchema()[phaseName].Elem.(*schema.Resource).Schema
 
-	_, new := d.GetChange(phaseName)
-
-	if new != nil && len(new.([]interface{})) > 0 {
-		ns = new.([]interface{})[0].(map[string]interface{})
+	if p.MinAge != "" {
+		phase["min_age"] = p.MinAge
 	}
-
-	existsAndNotEmpty := func(key string, m map[string]interface{}) bool {
-		if v, ok := m[key]; ok && len(v.([]interface{})) > 0 {
-			return true
+	for actionName, action := range p.Actions {
+		actionSchema, ok := phaseSchema[actionName]
+		if !ok || actionName == "min_age" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unsupported action in the lifecycle policy",
+				Detail:   fmt.Sprintf(`The action "%s" of the %s phase is not supported by the provider, it's left out of the state.`, actionName, phaseName),
+			})
+			continue
 		}
-		return false
-	}
-	for _, aCase := range []string{"readonly", "freeze", "unfollow"} {
-		if existsAndNotEmpty(aCase, ns) {
-			enabled["enabled"] = false
-			phase[aCase] = []interface{}{enabled}
+		a, err := flattenAction(action, actionSchema.Elem.(*schema.Resource).Schema)
+		if err != nil {
+			return nil, diag.FromErr(err)
 		}
+		phase[actionName] = []interface{}{a}
 	}
+	return []interface{}{phase}, diags
+}
 
-	if p.MinAge != "" {
-		phase["min_age"] = p.MinAge
-	}
-	for actionName, action := range p.Actions {
-		switch actionName {
-		case "readonly", "freeze", "unfollow":
-			enabled["enabled"] = true
-			phase[actionName] = []interface{}{enabled}
-		case "allocate":
-			allocateAction := make(map[string]interface{})
-			if v, ok := action["number_of_replicas"]; ok {
-				allocateAction["number_of_replicas"] = v
+func flattenAction(action models.Action, attributes map[string]*schema.Schema) (map[string]interface{}, error) {
+	a := make(map[string]interface{})
+	for name, attribute := range attributes {
+		v, ok := action[name]
+		if !ok {
+			if attribute.Default != nil {
+				a[name] = attribute.Default
 			}
-			for _, f := range []string{"include", "require", "exclude"} {
-				if v, ok := action[f]; ok {
-					res, err := json.Marshal(v)
-					if err != nil {
-						return nil, diag.FromErr(err)
-					}
-					allocateAction[f] = string(res)
-				}
+			continue
+		}
+		switch name {
+		case "include", "exclude", "require":
+			res, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
 			}
-			phase[actionName] = []interface{}{allocateAction}
+			a[name] = string(res)
 		default:
-			phase[actionName] = []interface{}{action}
+			a[name] = v
+		}
+	}
+	return a, nil
+}
+
+// The disabled toggled actions are not part of the policy, so they are kept as they are configured in the phase
+func flattenDisabledActions(phase interface{}, configured []interface{}) interface{} {
+	if phase == nil || len(configured) == 0 || configured[0] == nil {
+		return phase
+	}
+	configuredPhase := configured[0].(map[string]interface{})
+	p := phase.([]interface{})[0].(map[string]interface{})
+	for _, actionName := range toggledActions {
+		if _, ok := p[actionName]; ok {
+			continue
+		}
+		if a, ok := configuredPhase[actionName].([]interface{}); ok && len(a) > 0 {
+			p[actionName] = []interface{}{map[string]interface{}{"enabled": false}}
 		}
 	}
-	out[0] = phase
-	return out, diags
+	return phase
 }
 
 func resourceIlmDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -647,7 +823,7 @@ func resourceIlmDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diags
 	}
 
-	if diags := client.DeleteElasticsearchIlm(compId.ResourceId); diags.HasError() {
+	if diags := client.DeleteElasticsearchIlm(ctx, compId.ResourceId); diags.HasError() {
 		return diags
 	}
 
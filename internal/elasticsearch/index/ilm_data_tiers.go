@@ -0,0 +1,92 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The phases moving the indices between the nodes either with the migrate or the allocate action
+var ilmDataMigrationPhases = []string{"warm", "cold"}
+
+// The settings of the allocate action which move the indices to the nodes with the given attributes
+var ilmAllocationRules = []string{"include", "exclude", "require"}
+
+// Both the enabled migrate action and the allocation rules move the indices in the phase, which Elasticsearch doesn't allow,
+// so the conflict is reported when planning rather than discovered once the indices are never allocated as expected
+func validateIlmDataMigration(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if policyJson, _ := d.Get("policy_json").(string); policyJson != "" {
+		if !d.NewValueKnown("policy_json") {
+			return nil
+		}
+		phases, err := ilmPhasesFromJson(policyJson)
+		if err != nil {
+			return err
+		}
+		return checkIlmDataMigration(phases)
+	}
+	for _, ph := range ilmDataMigrationPhases {
+		if !d.NewValueKnown(ph) {
+			return nil
+		}
+	}
+	return checkIlmDataMigration(d)
+}
+
+func checkIlmDataMigration(d ilmPhasesGetter) error {
+	for _, ph := range ilmDataMigrationPhases {
+		v, _ := d.Get(ph).([]interface{})
+		if len(v) == 0 || v[0] == nil {
+			continue
+		}
+		phase := v[0].(map[string]interface{})
+		if !ilmMigrateEnabled(phase) {
+			continue
+		}
+		if rules := ilmAllocateRules(phase); len(rules) > 0 {
+			return fmt.Errorf("the %s phase has both the enabled migrate action and the allocation rules [%s] of the allocate action, only one of them can move the indices in the phase. "+
+				"Set enabled to false in the migrate block to move the indices with the allocation rules, or remove the allocation rules to move them to the data tier of the phase", ph, strings.Join(rules, ", "))
+		}
+	}
+	return nil
+}
+
+// The migrate action is enabled when it's set without being disabled, otherwise Elasticsearch injects it only without the allocation rules
+func ilmMigrateEnabled(phase map[string]interface{}) bool {
+	migrate, _ := phase["migrate"].([]interface{})
+	if len(migrate) == 0 {
+		return false
+	}
+	if migrate[0] == nil {
+		return true
+	}
+	enabled, ok := migrate[0].(map[string]interface{})["enabled"].(bool)
+	return !ok || enabled
+}
+
+// Returns the allocation rules set in the allocate action of the phase
+func ilmAllocateRules(phase map[string]interface{}) []string {
+	allocate, _ := phase["allocate"].([]interface{})
+	if len(allocate) == 0 || allocate[0] == nil {
+		return nil
+	}
+	action := allocate[0].(map[string]interface{})
+	rules := make([]string, 0)
+	for _, rule := range ilmAllocationRules {
+		s, _ := action[rule].(string)
+		if s == "" {
+			continue
+		}
+		attributes := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(s), &attributes); err != nil {
+			continue
+		}
+		if len(attributes) > 0 {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
@@ -0,0 +1,66 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIlmJson() *schema.Resource {
+	ilmSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"json": {
+			Description: "JSON representation of the policy, the exact request body `elasticstack_elasticsearch_index_lifecycle` sends to Elasticsearch.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	for k, v := range ilmPolicySchema() {
+		ilmSchema[k] = v
+	}
+
+	return &schema.Resource{
+		Description: "Renders the lifecycle policy defined with the same blocks as `elasticstack_elasticsearch_index_lifecycle` into JSON, e.g. to compare it with the existing policies or to pass it to other tools. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-put-lifecycle.html",
+
+		ReadContext: dataSourceIlmJsonRead,
+
+		Schema: ilmSchema,
+	}
+}
+
+func dataSourceIlmJsonRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := checkIlmDataMigration(d); err != nil {
+		return diag.FromErr(err)
+	}
+	policy, diags := expandIlmPolicy(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	policyJson, err := json.MarshalIndent(map[string]interface{}{"policy": policy}, "", " ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("json", string(policyJson)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash, err := utils.StringToHash(string(policyJson))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*hash)
+
+	return diags
+}
@@ -0,0 +1,79 @@
+package index_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIlmJson(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIlmJson,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_lifecycle_json.test", "json", expectedIlmJson),
+				),
+			},
+		},
+	})
+}
+
+const expectedIlmJson = `{
+ "policy": {
+  "_meta": {
+   "owner": "logs"
+  },
+  "phases": {
+   "delete": {
+    "min_age": "30d",
+    "actions": {
+     "delete": {
+      "delete_searchable_snapshot": true
+     }
+    }
+   },
+   "hot": {
+    "min_age": "1h",
+    "actions": {
+     "rollover": {
+      "max_age": "1d"
+     },
+     "set_priority": {
+      "priority": 10
+     }
+    }
+   }
+  }
+ }
+}`
+
+const testAccDataSourceIlmJson = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_index_lifecycle_json" "test" {
+  metadata = jsonencode({
+    owner = "logs"
+  })
+
+  hot {
+    min_age = "1h"
+    set_priority {
+      priority = 10
+    }
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  delete {
+    min_age = "30d"
+    delete {}
+  }
+}
+`
@@ -0,0 +1,150 @@
+package index
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIlmPhaseRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		phases map[string]interface{}
+	}{
+		{
+			name: "hot",
+			phases: map[string]interface{}{
+				"hot": []interface{}{map[string]interface{}{
+					"min_age":      "1h",
+					"set_priority": []interface{}{map[string]interface{}{"priority": 0}},
+					"rollover": []interface{}{map[string]interface{}{
+						"max_age":                "1d",
+						"max_docs":               1000,
+						"max_size":               "50gb",
+						"max_primary_shard_size": "25gb",
+					}},
+					"readonly":            []interface{}{map[string]interface{}{"enabled": true}},
+					"unfollow":            []interface{}{map[string]interface{}{"enabled": false}},
+					"shrink":              []interface{}{map[string]interface{}{"number_of_shards": 1}},
+					"forcemerge":          []interface{}{map[string]interface{}{"max_num_segments": 1, "index_codec": "best_compression"}},
+					"searchable_snapshot": []interface{}{map[string]interface{}{"snapshot_repository": "repo", "force_merge_index": false}},
+				}},
+			},
+		},
+		{
+			name: "warm",
+			phases: map[string]interface{}{
+				"warm": []interface{}{map[string]interface{}{
+					"min_age":  "7d",
+					"readonly": []interface{}{map[string]interface{}{"enabled": false}},
+					"unfollow": []interface{}{map[string]interface{}{"enabled": true}},
+					"allocate": []interface{}{map[string]interface{}{
+						"number_of_replicas": 1,
+						"include":            `{"box_type":"warm"}`,
+						"require":            `{"data":"warm"}`,
+					}},
+					"migrate": []interface{}{map[string]interface{}{"enabled": false}},
+					"shrink":  []interface{}{map[string]interface{}{"max_primary_shard_size": "5gb"}},
+				}},
+			},
+		},
+		{
+			name: "cold",
+			phases: map[string]interface{}{
+				"cold": []interface{}{map[string]interface{}{
+					"min_age":  "30d",
+					"freeze":   []interface{}{map[string]interface{}{"enabled": true}},
+					"readonly": []interface{}{map[string]interface{}{"enabled": false}},
+					"unfollow": []interface{}{map[string]interface{}{"enabled": false}},
+					"migrate":  []interface{}{map[string]interface{}{"enabled": true}},
+				}},
+			},
+		},
+		{
+			name: "frozen and delete",
+			phases: map[string]interface{}{
+				"frozen": []interface{}{map[string]interface{}{
+					"min_age":             "60d",
+					"searchable_snapshot": []interface{}{map[string]interface{}{"snapshot_repository": "repo"}},
+				}},
+				"delete": []interface{}{map[string]interface{}{
+					"min_age":           "90d",
+					"wait_for_snapshot": []interface{}{map[string]interface{}{"policy": "daily"}},
+					"delete":            []interface{}{map[string]interface{}{"delete_searchable_snapshot": false}},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{"name": "test"}
+			for k, v := range tt.phases {
+				raw[k] = v
+			}
+			configured := schema.TestResourceDataRaw(t, ResourceIlm().Schema, raw)
+			policy, diags := expandIlmPolicy(configured)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			// the policy is read back as the API returns it
+			body, err := json.Marshal(policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var returned models.Policy
+			if err := json.Unmarshal(body, &returned); err != nil {
+				t.Fatal(err)
+			}
+
+			read := schema.TestResourceDataRaw(t, ResourceIlm().Schema, map[string]interface{}{"name": "test"})
+			for _, ph := range supportedIlmPhases {
+				var phase interface{}
+				if v, ok := returned.Phases[ph]; ok {
+					phase, diags = flattenPhase(ph, v)
+					if len(diags) > 0 {
+						t.Fatalf("unexpected diagnostics: %v", diags)
+					}
+				}
+				phase = flattenDisabledActions(phase, configured.Get(ph).([]interface{}))
+				if err := read.Set(ph, phase); err != nil {
+					t.Fatal(err)
+				}
+				if expected, actual := configured.Get(ph), read.Get(ph); !reflect.DeepEqual(expected, actual) {
+					t.Errorf("phase %s does not round-trip\nexpected: %#v\nactual:   %#v", ph, expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenPhaseDefaults(t *testing.T) {
+	phase, diags := flattenPhase("warm", models.Phase{
+		MinAge: "0ms",
+		Actions: map[string]models.Action{
+			"readonly":   {},
+			"allocate":   {"number_of_replicas": float64(2)},
+			"downsample": {"fixed_interval": "1h"},
+		},
+	})
+	if len(diags) != 1 || diags.HasError() {
+		t.Fatalf("expected the warning about the unsupported action, got: %v", diags)
+	}
+	expected := []interface{}{map[string]interface{}{
+		"min_age":  "0ms",
+		"readonly": []interface{}{map[string]interface{}{"enabled": true}},
+		"allocate": []interface{}{map[string]interface{}{
+			"number_of_replicas": float64(2),
+			"include":            "{}",
+			"exclude":            "{}",
+			"require":            "{}",
+		}},
+	}}
+	if !reflect.DeepEqual(phase, expected) {
+		t.Errorf("expected: %#v\nactual:   %#v", expected, phase)
+	}
+}
@@ -0,0 +1,159 @@
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// the parameters Elasticsearch adds to the actions of the stored policies when they are not set
+var ilmActionDefaults = map[string]map[string]interface{}{
+	"allocate":            {"include": map[string]interface{}{}, "exclude": map[string]interface{}{}, "require": map[string]interface{}{}},
+	"delete":              {"delete_searchable_snapshot": true},
+	"migrate":             {"enabled": true},
+	"searchable_snapshot": {"force_merge_index": true},
+	"shrink":              {"allow_write_after_shrink": false},
+}
+
+// Decodes the policy JSON, either the request body, i.e. `{"policy": {...}}` as rendered by the `elasticstack_elasticsearch_index_lifecycle_json` data source, or the policy itself
+func decodeIlmPolicyJson(policyJson string) (map[string]interface{}, error) {
+	policy := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(policyJson), &policy); err != nil {
+		return nil, err
+	}
+	if wrapped, ok := policy["policy"].(map[string]interface{}); ok && len(policy) == 1 {
+		policy = wrapped
+	}
+	return policy, nil
+}
+
+// Builds the policy from the JSON, checking the phases are known
+func expandIlmPolicyJson(policyJson string) (*models.Policy, error) {
+	decoded, err := decodeIlmPolicyJson(policyJson)
+	if err != nil {
+		return nil, err
+	}
+	for key := range decoded {
+		if key != "phases" && key != "_meta" {
+			return nil, fmt.Errorf(`unknown key "%s" of the policy, expected phases and _meta`, key)
+		}
+	}
+	phases, ok := decoded["phases"].(map[string]interface{})
+	if !ok || len(phases) == 0 {
+		return nil, fmt.Errorf("the policy must define at least one of the phases: %s", strings.Join(supportedIlmPhases[:], ", "))
+	}
+	for ph := range phases {
+		if !isSupportedIlmPhase(ph) {
+			return nil, fmt.Errorf(`unknown phase "%s" of the policy, expected one of: %s`, ph, strings.Join(supportedIlmPhases[:], ", "))
+		}
+	}
+
+	b, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var policy models.Policy
+	if err := json.Unmarshal(b, &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func isSupportedIlmPhase(name string) bool {
+	for _, ph := range supportedIlmPhases {
+		if ph == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validateIlmPolicyJson(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	if _, err := expandIlmPolicyJson(value); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// Adds the defaults Elasticsearch sets in the stored policy, so the configured JSON compares equal to the one read from the cluster
+func normalizeIlmPolicy(policy map[string]interface{}) map[string]interface{} {
+	phases, _ := policy["phases"].(map[string]interface{})
+	for _, p := range phases {
+		phase, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// the phases are entered immediately by default
+		if _, ok := phase["min_age"]; !ok {
+			phase["min_age"] = "0ms"
+		}
+		actions, ok := phase["actions"].(map[string]interface{})
+		if !ok {
+			actions = make(map[string]interface{})
+			phase["actions"] = actions
+		}
+		for name, a := range actions {
+			action, ok := a.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for param, value := range ilmActionDefaults[name] {
+				if _, ok := action[param]; !ok {
+					action[param] = value
+				}
+			}
+		}
+	}
+	return policy
+}
+
+// Suppresses the diff of the policy JSON which is equal to the one in the cluster once the defaults are added
+func suppressEquivalentIlmPolicyJson(k, old, new string, d *schema.ResourceData) bool {
+	oldPolicy, err := decodeIlmPolicyJson(old)
+	if err != nil {
+		return false
+	}
+	newPolicy, err := decodeIlmPolicyJson(new)
+	if err != nil {
+		return false
+	}
+	return utils.MapsEqual(normalizeIlmPolicy(oldPolicy), normalizeIlmPolicy(newPolicy))
+}
+
+// The phases of the policy JSON in the format of the phase blocks, so the checks and the summary of the blocks apply to the JSON too
+type ilmJsonPhases map[string]interface{}
+
+func (p ilmJsonPhases) Get(key string) interface{} {
+	if v, ok := p[key]; ok {
+		return v
+	}
+	return []interface{}{}
+}
+
+func ilmPhasesFromJson(policyJson string) (ilmJsonPhases, error) {
+	phases := make(ilmJsonPhases)
+	if policyJson == "" {
+		return phases, nil
+	}
+	policy, err := expandIlmPolicyJson(policyJson)
+	if err != nil {
+		return nil, err
+	}
+	for name, p := range policy.Phases {
+		// the unsupported actions are left out, they are reported when the policy is read
+		phase, diags := flattenPhase(name, p)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s phase: %v", name, diags)
+		}
+		phases[name] = phase
+	}
+	return phases, nil
+}
@@ -0,0 +1,69 @@
+package index
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuppressEquivalentIlmPolicyJson(t *testing.T) {
+	stored := `{"phases": {"hot": {"min_age": "0ms", "actions": {"rollover": {"max_age": "1d"}}}, "delete": {"min_age": "30d", "actions": {"delete": {"delete_searchable_snapshot": true}}}}}`
+
+	tests := []struct {
+		name       string
+		configured string
+		equal      bool
+	}{
+		{
+			name:       "defaults left out",
+			configured: `{"phases": {"hot": {"actions": {"rollover": {"max_age": "1d"}}}, "delete": {"min_age": "30d", "actions": {"delete": {}}}}}`,
+			equal:      true,
+		},
+		{
+			name:       "request body",
+			configured: `{"policy": {"phases": {"hot": {"actions": {"rollover": {"max_age": "1d"}}}, "delete": {"min_age": "30d", "actions": {"delete": {}}}}}}`,
+			equal:      true,
+		},
+		{
+			name:       "changed retention",
+			configured: `{"phases": {"hot": {"actions": {"rollover": {"max_age": "1d"}}}, "delete": {"min_age": "60d", "actions": {"delete": {}}}}}`,
+			equal:      false,
+		},
+		{
+			name:       "kept searchable snapshots",
+			configured: `{"phases": {"hot": {"actions": {"rollover": {"max_age": "1d"}}}, "delete": {"min_age": "30d", "actions": {"delete": {"delete_searchable_snapshot": false}}}}}`,
+			equal:      false,
+		},
+	}
+
+	for _, tc := range tests {
+		if equal := suppressEquivalentIlmPolicyJson("policy_json", stored, tc.configured, nil); equal != tc.equal {
+			t.Errorf("%s: expected the policies to be equal: %t, got %t", tc.name, tc.equal, equal)
+		}
+	}
+}
+
+func TestValidateIlmPolicyJson(t *testing.T) {
+	tests := []struct {
+		policy string
+		err    string
+	}{
+		{`{"phases": {"hot": {"actions": {}}}}`, ""},
+		{`{"policy": {"phases": {"delete": {"actions": {"delete": {}}}}, "_meta": {"team": "search"}}}`, ""},
+		{`{"phases": {}}`, "must define at least one of the phases"},
+		{`{"phases": {"hott": {"actions": {}}}}`, `unknown phase "hott"`},
+		{`{"phases": {"hot": {"actions": {}}}, "name": "logs"}`, `unknown key "name"`},
+	}
+
+	for _, tc := range tests {
+		_, errs := validateIlmPolicyJson(tc.policy, "policy_json")
+		if tc.err == "" {
+			if len(errs) > 0 {
+				t.Errorf("%s: unexpected error: %v", tc.policy, errs[0])
+			}
+			continue
+		}
+		if len(errs) == 0 || !strings.Contains(errs[0].Error(), tc.err) {
+			t.Errorf("%s: expected the error %q, got %v", tc.policy, tc.err, errs)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Returns the name of the SLM policy awaited by the delete phase of the lifecycle policy, if any
+func ilmWaitForSnapshotPolicy(policy *models.Policy) string {
+	phase, ok := policy.Phases["delete"]
+	if !ok {
+		return ""
+	}
+	action, ok := phase.Actions["wait_for_snapshot"]
+	if !ok {
+		return ""
+	}
+	name, _ := action["policy"].(string)
+	return name
+}
+
+// Warns when the SLM policy awaited by the delete phase doesn't exist, the indices then never leave the delete phase.
+// The plan cannot tell whether the SLM policy is created by the same configuration, i.e. its name is known before it's created,
+// so the check runs once the lifecycle policy is put, when the SLM policies it depends on must already exist.
+func checkIlmWaitForSnapshot(ctx context.Context, client *clients.ApiClient, policy *models.Policy) diag.Diagnostics {
+	var diags diag.Diagnostics
+	slmName := ilmWaitForSnapshotPolicy(policy)
+	if slmName == "" {
+		return diags
+	}
+	slm, diags := client.GetElasticsearchSlm(ctx, slmName)
+	if diags.HasError() || slm != nil {
+		return diags
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf(`The SLM policy "%s" awaited by the delete phase does not exist`, slmName),
+		Detail: fmt.Sprintf(`The delete phase of the lifecycle policy "%s" waits for a snapshot taken by the SLM policy "%s", which does not exist, so the indices are never deleted until it's created and takes a snapshot. `+
+			"When the SLM policy is managed in the same configuration, reference it, e.g. `policy = elasticstack_elasticsearch_snapshot_lifecycle.<name>.name`, so it's created before the lifecycle policy.", policy.Name, slmName),
+	}}
+}
@@ -0,0 +1,25 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+)
+
+func TestIlmWaitForSnapshotPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		phases   map[string]models.Phase
+		expected string
+	}{
+		{"no delete phase", map[string]models.Phase{"hot": {Actions: map[string]models.Action{"rollover": {"max_age": "1d"}}}}, ""},
+		{"no wait", map[string]models.Phase{"delete": {Actions: map[string]models.Action{"delete": {}}}}, ""},
+		{"wait", map[string]models.Phase{"delete": {Actions: map[string]models.Action{"wait_for_snapshot": {"policy": "daily"}, "delete": {}}}}, "daily"},
+	}
+
+	for _, tc := range tests {
+		if policy := ilmWaitForSnapshotPolicy(&models.Policy{Phases: tc.phases}); policy != tc.expected {
+			t.Errorf("%s: expected the SLM policy %q, got %q", tc.name, tc.expected, policy)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Either the resource data or the resource diff, so the summary is rendered the same way from the state and from the plan
+type ilmPhasesGetter interface {
+	Get(key string) interface{}
+}
+
+// Plans the new summary of the phases whenever the phases are changed, so it's shown in the plan next to the changed blocks
+func planIlmPhaseSummary(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if policyJson := d.Get("policy_json").(string); policyJson != "" || d.HasChange("policy_json") {
+		if !d.NewValueKnown("policy_json") {
+			return d.SetNewComputed("phase_summary")
+		}
+		if !d.HasChange("policy_json") {
+			return nil
+		}
+		if policyJson != "" {
+			phases, err := ilmPhasesFromJson(policyJson)
+			if err != nil {
+				return err
+			}
+			return d.SetNew("phase_summary", ilmPhaseSummary(phases))
+		}
+	}
+	changed := false
+	for _, ph := range supportedIlmPhases {
+		if !d.NewValueKnown(ph) {
+			return d.SetNewComputed("phase_summary")
+		}
+		changed = changed || d.HasChange(ph)
+	}
+	if !changed {
+		return nil
+	}
+	return d.SetNew("phase_summary", ilmPhaseSummary(d))
+}
+
+// Renders the phases of the policy as the table with the minimum age and the actions of every phase, e.g.
+//
+//	PHASE   MIN AGE  ACTIONS
+//	hot     0ms      rollover(max_age=7d), set_priority(priority=100)
+//	delete  30d      delete
+func ilmPhaseSummary(d ilmPhasesGetter) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PHASE\tMIN AGE\tACTIONS")
+	for _, ph := range supportedIlmPhases {
+		v, _ := d.Get(ph).([]interface{})
+		if len(v) == 0 || v[0] == nil {
+			continue
+		}
+		phase := v[0].(map[string]interface{})
+		minAge, _ := phase["min_age"].(string)
+		// the phases are entered immediately by default
+		if minAge == "" {
+			minAge = "0ms"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", ph, minAge, strings.Join(ilmActionsSummary(phase), ", "))
+	}
+	w.Flush()
+	return b.String()
+}
+
+func ilmActionsSummary(phase map[string]interface{}) []string {
+	actions := make([]string, 0)
+	for name, action := range phase {
+		a, ok := action.([]interface{})
+		if name == "min_age" || !ok || len(a) == 0 {
+			continue
+		}
+		params := make(map[string]interface{})
+		if a[0] != nil {
+			params = a[0].(map[string]interface{})
+		}
+		// the disabled actions are not added to the policy, except the migration which is disabled explicitly
+		if enabled, ok := params["enabled"].(bool); ok && !enabled && name != "migrate" {
+			continue
+		}
+		settings := make([]string, 0)
+		for k, v := range params {
+			switch vv := v.(type) {
+			case string:
+				if vv == "" || vv == "{}" {
+					continue
+				}
+			case int:
+				if vv == 0 {
+					continue
+				}
+			case bool:
+				// the flags are enabled by default
+				if vv {
+					continue
+				}
+			}
+			settings = append(settings, fmt.Sprintf("%s=%v", k, v))
+		}
+		sort.Strings(settings)
+		if len(settings) > 0 {
+			name = fmt.Sprintf("%s(%s)", name, strings.Join(settings, ", "))
+		}
+		actions = append(actions, name)
+	}
+	sort.Strings(actions)
+	return actions
+}
@@ -2,6 +2,7 @@ package index_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -34,6 +35,10 @@ func TestAccResourceILM(t *testing.T) {
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "warm.#", "0"),
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "cold.#", "0"),
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "frozen.#", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "phase_summary", `PHASE   MIN AGE  ACTIONS
+hot     1h       readonly, rollover(max_age=1d), set_priority(priority=10)
+delete  0ms      delete
+`),
 				),
 			},
 			{
@@ -129,6 +134,170 @@ resource "elasticstack_elasticsearch_index_lifecycle" "test" {
  `, name)
 }
 
+func TestAccResourceILMRetention(t *testing.T) {
+	policyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceILMDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceILMRetention(policyName, "30d", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "delete.0.min_age", "30d"),
+				),
+			},
+			{
+				Config:      testAccResourceILMRetention(policyName, "7d", true),
+				ExpectError: regexp.MustCompile("shortens the retention"),
+			},
+			{
+				Config: testAccResourceILMRetention(policyName, "7d", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "delete.0.min_age", "7d"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceILMRetention(name, retention string, prevent bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  delete {
+    min_age = "%s"
+    delete {}
+  }
+
+  prevent_retention_shortening = %t
+}
+ `, name, retention, prevent)
+}
+
+func TestAccResourceILMDataMigration(t *testing.T) {
+	policyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceILMDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceILMDataMigration(policyName, true),
+				ExpectError: regexp.MustCompile("the warm phase has both the enabled migrate action and the allocation rules \\[require\\]"),
+			},
+			{
+				Config: testAccResourceILMDataMigration(policyName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "warm.0.migrate.0.enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceILMDataMigration(name string, migrate bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  warm {
+    min_age = "7d"
+    allocate {
+      require = jsonencode({
+        box_type = "warm"
+      })
+    }
+    migrate {
+      enabled = %t
+    }
+  }
+}
+ `, name, migrate)
+}
+
+func TestAccResourceILMPolicyJson(t *testing.T) {
+	policyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceILMDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceILMPolicyJson(policyName, "hott", "30d"),
+				ExpectError: regexp.MustCompile(`unknown phase "hott" of the policy`),
+			},
+			{
+				Config: testAccResourceILMPolicyJson(policyName, "hot", "30d"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "name", policyName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "hot.#", "0"),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "phase_summary", regexp.MustCompile(`delete\s+30d\s+delete`)),
+				),
+			},
+			{
+				Config: testAccResourceILMPolicyJson(policyName, "hot", "60d"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "phase_summary", regexp.MustCompile(`delete\s+60d\s+delete`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceILMPolicyJson(name, phase, retention string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  policy_json = jsonencode({
+    _meta = { managed_by = "terraform" }
+    phases = {
+      %s = {
+        actions = {
+          rollover = { max_age = "1d" }
+        }
+      }
+      delete = {
+        min_age = "%s"
+        actions = {
+          delete = {}
+        }
+      }
+    }
+  })
+}
+	`, name, phase, retention)
+}
+
 func checkResourceILMDestroy(s *terraform.State) error {
 	client := acctest.Provider.Meta().(*clients.ApiClient)
 
@@ -150,3 +319,63 @@ func checkResourceILMDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestAccResourceILMWaitForSnapshot(t *testing.T) {
+	policyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceILMDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceILMWaitForSnapshot(policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "delete.0.wait_for_snapshot.0.policy", policyName+"-slm"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceILMWaitForSnapshot(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_snapshot_repository" "repo" {
+  name = "%[1]s-repo"
+
+  fs {
+    location = "/tmp/snapshots"
+  }
+}
+
+resource "elasticstack_elasticsearch_snapshot_lifecycle" "test" {
+  name = "%[1]s-slm"
+
+  schedule      = "0 30 1 * * ?"
+  snapshot_name = "<daily-snap-{now/d}>"
+  repository    = elasticstack_elasticsearch_snapshot_repository.repo.name
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%[1]s"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  delete {
+    min_age = "30d"
+    wait_for_snapshot {
+      policy = elasticstack_elasticsearch_snapshot_lifecycle.test.name
+    }
+    delete {}
+  }
+}
+	`, name)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"reflect"
 	"regexp"
 	"strings"
@@ -12,6 +11,7 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -95,10 +95,17 @@ If specified, this mapping can include: field names, field data types (https://w
 **NOTE:** changing datatypes in the existing _mappings_ will force index to be re-created.`,
 			Type:             schema.TypeString,
 			Optional:         true,
-			DiffSuppressFunc: utils.DiffJsonSuppress,
-			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: suppressMappingsFromFile("mappings_file"),
+			ValidateFunc:     validation.All(validation.StringIsJSON, validateMappingsJson),
 			Default:          "{}",
 		},
+		"mappings_file": {
+			Description:   "The path of the JSON file with the mappings of the index, instead of the inline `mappings`. The file is read and its structure is checked during the plan, the changes of its content and the drift of the mappings in the cluster are tracked by `mappings_file_sha256`.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"mappings"},
+		},
+		mappingsFileHashAttr: mappingsFileHashSchema(),
 		"settings": {
 			Description: `Configuration options for the index. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html#index-modules-settings.
 **NOTE:** Static index settings (see: https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html#_static_index_settings) can be only set on the index creation and later cannot be removed or updated - _apply_ will return error`,
@@ -130,6 +137,66 @@ If specified, this mapping can include: field names, field data types (https://w
 				},
 			},
 		},
+		"analysis": indexAnalysisSchema(),
+		"validate_pipelines": {
+			Description: "Check that the ingest pipelines set as `default_pipeline` or `final_pipeline`, either by the attributes or in the settings, exist before the index settings are stored, since the index rejects all the writes otherwise.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		"blocks": {
+			Description: "The blocks of the index, which limit the operations allowed on it. Removing the block leaves the blocks of the index as they are. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules-blocks.html",
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"read_only": {
+						Description: "Make the index and its metadata read only.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+					"read_only_allow_delete": {
+						Description: "Make the index read only, but allow the deletion of the documents and the index. Elasticsearch sets it when the disk flood stage watermark is exceeded.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+					"write": {
+						Description: "Reject the writes to the index, but allow the changes of its metadata.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+				},
+			},
+		},
+		"closed": {
+			Description: "Whether the index is closed. The closed indices reject the reads and the writes. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-close.html",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"allow_close": {
+			Description: "Allow to close the index temporarily to update the settings which can be updated only on the closed index, e.g. the analysis settings. The index is reopened once the settings are updated, unless `closed` is set. The index is unavailable while it's closed.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"migration_strategy": {
+			Description:  "How to apply the changes of the mappings which cannot be applied to the existing index, e.g. the changed type of a field: `recreate` deletes the index and creates it again, `reindex_and_swap` creates the next generation of the index, e.g. `my-index-000002`, copies the documents into it and atomically moves the aliases to it. The clients must access the index through its aliases with `reindex_and_swap`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      indexMigrationRecreate,
+			ValidateFunc: validation.StringInSlice([]string{indexMigrationRecreate, indexMigrationReindexAndSwap}, false),
+		},
+		"current_index": {
+			Description: "The name of the current generation of the index, which differs from `name` once the index is migrated with `reindex_and_swap`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
 		"settings_raw": {
 			Description: "All raw settings fetched from the cluster.",
 			Type:        schema.TypeString,
@@ -137,6 +204,16 @@ If specified, this mapping can include: field names, field data types (https://w
 		},
 	}
 
+	for k, v := range indexPipelineSchema() {
+		indexSchema[k] = v
+	}
+	for k, v := range indexSortRoutingSchema() {
+		indexSchema[k] = v
+	}
+	for k, v := range healthCheckSchema() {
+		indexSchema[k] = v
+	}
+
 	utils.AddConnectionSchema(indexSchema)
 
 	return &schema.Resource{
@@ -172,7 +249,7 @@ If specified, this mapping can include: field names, field data types (https://w
 					return nil, fmt.Errorf("Failed to parse provided ID")
 				}
 				indexName := compId.ResourceId
-				index, diags := client.GetElasticsearchIndex(indexName)
+				index, diags := client.GetElasticsearchIndex(ctx, indexName)
 				if diags.HasError() {
 					return nil, fmt.Errorf("Failed to get an ES Index")
 				}
@@ -199,70 +276,75 @@ If specified, this mapping can include: field names, field data types (https://w
 			},
 		},
 
-		CustomizeDiff: customdiff.ForceNewIfChange("mappings", func(ctx context.Context, old, new, meta interface{}) bool {
-			o := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(old.(string))).Decode(&o); err != nil {
-				return true
-			}
-			n := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(new.(string))).Decode(&n); err != nil {
-				return true
-			}
-			log.Printf("[TRACE] mappings custom diff old = %+v new = %+v", o, n)
-
-			var isForceable func(map[string]interface{}, map[string]interface{}) bool
-			isForceable = func(old, new map[string]interface{}) bool {
-				for k, v := range old {
-					oldFieldSettings := v.(map[string]interface{})
-					if newFieldSettings, ok := new[k]; ok {
-						newSettings := newFieldSettings.(map[string]interface{})
-						// check if the "type" field exists and match with new one
-						if s, ok := oldFieldSettings["type"]; ok {
-							if ns, ok := newSettings["type"]; ok {
-								if !reflect.DeepEqual(s, ns) {
-									return true
-								}
-								continue
-							} else {
-								return true
-							}
-						}
+		CustomizeDiff: customdiff.All(planMappingsFile("mappings_file"), validateIndexMappingsChange, validateIndexAnalysisChange, validateIndexPipelines, validateIndexSortRouting, validateIndexClose),
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, indexUpdateTimeout),
 
-						// if we have "mapping" field, let's call ourself to check again
-						if s, ok := oldFieldSettings["properties"]; ok {
-							if ns, ok := newSettings["properties"]; ok {
-								if isForceable(s.(map[string]interface{}), ns.(map[string]interface{})) {
-									return true
-								}
-								continue
-							} else {
-								return true
-							}
+		Schema: indexSchema,
+	}
+}
+
+// Checks whether the change of the mappings requires a new index, i.e. the type of any existing field changes or the field is removed
+func mappingsRequireNewIndex(ctx context.Context, old, new interface{}) bool {
+	o := make(map[string]interface{})
+	if err := json.NewDecoder(strings.NewReader(old.(string))).Decode(&o); err != nil {
+		return true
+	}
+	n := make(map[string]interface{})
+	if err := json.NewDecoder(strings.NewReader(new.(string))).Decode(&n); err != nil {
+		return true
+	}
+	tflog.Trace(ctx, fmt.Sprintf("mappings custom diff old = %+v new = %+v", o, n))
+
+	var isForceable func(map[string]interface{}, map[string]interface{}) bool
+	isForceable = func(old, new map[string]interface{}) bool {
+		for k, v := range old {
+			oldFieldSettings := v.(map[string]interface{})
+			if newFieldSettings, ok := new[k]; ok {
+				newSettings := newFieldSettings.(map[string]interface{})
+				// check if the "type" field exists and match with new one
+				if s, ok := oldFieldSettings["type"]; ok {
+					if ns, ok := newSettings["type"]; ok {
+						if !reflect.DeepEqual(s, ns) {
+							return true
 						}
+						continue
 					} else {
-						// if the key not found in the new props, force new resource
 						return true
 					}
 				}
-				return false
-			}
 
-			// if old defined we must check if the type of the existing fields were changed
-			if oldProps, ok := o["properties"]; ok {
-				newProps, ok := n["properties"]
-				// if the old has props but new one not, immediately force new resource
-				if !ok {
-					return true
+				// if we have "mapping" field, let's call ourself to check again
+				if s, ok := oldFieldSettings["properties"]; ok {
+					if ns, ok := newSettings["properties"]; ok {
+						if isForceable(s.(map[string]interface{}), ns.(map[string]interface{})) {
+							return true
+						}
+						continue
+					} else {
+						return true
+					}
 				}
-				return isForceable(oldProps.(map[string]interface{}), newProps.(map[string]interface{}))
+			} else {
+				// if the key not found in the new props, force new resource
+				return true
 			}
+		}
+		return false
+	}
 
-			// if all check passed, we can update the map
-			return false
-		}),
-
-		Schema: indexSchema,
+	// if old defined we must check if the type of the existing fields were changed
+	if oldProps, ok := o["properties"]; ok {
+		newProps, ok := n["properties"]
+		// if the old has props but new one not, immediately force new resource
+		if !ok {
+			return true
+		}
+		return isForceable(oldProps.(map[string]interface{}), newProps.(map[string]interface{}))
 	}
+
+	// if all check passed, we can update the map
+	return false
 }
 
 func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -271,27 +353,62 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.FromErr(err)
 	}
 	indexName := d.Get("name").(string)
-	id, diags := client.ID(indexName)
+	id, diags := client.ID(ctx, indexName)
+	if diags.HasError() {
+		return diags
+	}
+	index, diags := expandIndex(d)
 	if diags.HasError() {
 		return diags
 	}
-	var index models.Index
 	index.Name = indexName
 
+	if d.Get("validate_pipelines").(bool) && index.Settings != nil {
+		if diags := checkIndexPipelines(ctx, client, index.Settings); diags.HasError() {
+			return diags
+		}
+	}
+	if diags := client.PutElasticsearchIndex(ctx, index); diags.HasError() {
+		return diags
+	}
+
+	if d.Get("closed").(bool) {
+		if diags := client.CloseElasticsearchIndex(ctx, indexName); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId(id.String())
+	if diags := checkIndexHealth(ctx, client, d, indexName); diags.HasError() {
+		return diags
+	}
+	return resourceIndexRead(ctx, d, meta)
+}
+
+func expandIndex(d *schema.ResourceData) (*models.Index, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var index models.Index
+
 	if v, ok := d.GetOk("alias"); ok {
 		aliases := v.(*schema.Set)
 		als, diags := ExpandIndexAliases(aliases)
 		if diags.HasError() {
-			return diags
+			return nil, diags
 		}
 		index.Aliases = als
 	}
 
-	if v, ok := d.GetOk("mappings"); ok {
+	if path := d.Get("mappings_file").(string); path != "" {
+		maps, _, err := readMappingsFile(path)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		index.Mappings = maps
+	} else if v, ok := d.GetOk("mappings"); ok {
 		maps := make(map[string]interface{})
 		if v.(string) != "" {
 			if err := json.Unmarshal([]byte(v.(string)), &maps); err != nil {
-				return diag.FromErr(err)
+				return nil, diag.FromErr(err)
 			}
 		}
 		index.Mappings = maps
@@ -308,12 +425,50 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		index.Settings = sets
 	}
 
-	if diags := client.PutElasticsearchIndex(&index); diags.HasError() {
-		return diags
+	analysis, err := expandIndexAnalysis(d.Get("analysis"))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if len(analysis) > 0 {
+		if index.Settings == nil {
+			index.Settings = make(map[string]interface{})
+		}
+		for setting, value := range analysis {
+			index.Settings[setting] = value
+		}
 	}
 
-	d.SetId(id.String())
-	return resourceIndexRead(ctx, d, meta)
+	if pipelines := expandIndexPipelines(map[string]interface{}{"default_pipeline": d.Get("default_pipeline"), "final_pipeline": d.Get("final_pipeline")}); len(pipelines) > 0 {
+		if index.Settings == nil {
+			index.Settings = make(map[string]interface{})
+		}
+		for setting, value := range pipelines {
+			index.Settings[setting] = value
+		}
+	}
+
+	sortRouting := make(map[string]interface{})
+	for attr := range indexSortRoutingAttributes {
+		sortRouting[attr] = d.Get(attr)
+	}
+	if settings := expandIndexSortRouting(sortRouting); len(settings) > 0 {
+		if index.Settings == nil {
+			index.Settings = make(map[string]interface{})
+		}
+		for setting, value := range settings {
+			index.Settings[setting] = value
+		}
+	}
+
+	if v, ok := d.GetOk("blocks"); ok {
+		if index.Settings == nil {
+			index.Settings = make(map[string]interface{})
+		}
+		for setting, enabled := range expandIndexBlocks(v.([]interface{})) {
+			index.Settings[setting] = enabled
+		}
+	}
+	return &index, diags
 }
 
 // Because of limitation of ES API we must handle changes to aliases, mappings and settings separately
@@ -322,7 +477,39 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	indexName := d.Get("name").(string)
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	// the index is renamed by the migration
+	indexName := compId.ResourceId
+
+	oldMappings, newMappings, mappingsChanged, err := indexMappingsChange(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if mappingsChanged && d.Get("migration_strategy").(string) == indexMigrationReindexAndSwap {
+		if mappingsRequireNewIndex(ctx, oldMappings, newMappings) {
+			return resourceIndexMigrate(ctx, d, meta)
+		}
+	}
+
+	oldClosed, newClosed := d.GetChange("closed")
+	isOpen := !oldClosed.(bool)
+
+	// the closed index is opened first, so the other changes can be applied
+	if !isOpen && !newClosed.(bool) {
+		if diags := client.OpenElasticsearchIndex(ctx, indexName); diags.HasError() {
+			return diags
+		}
+		isOpen = true
+	}
+
+	// the blocks are lifted before and added after the other changes, since they would reject them
+	liftedBlocks, addedBlocks := changedIndexBlocks(d)
+	if diags := updateIndexBlocks(ctx, client, indexName, liftedBlocks); diags.HasError() {
+		return diags
+	}
 
 	// aliases
 	if d.HasChange("alias") {
@@ -345,51 +532,104 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 			}
 		}
 		if len(aliasesToDelete) > 0 {
-			if diags := client.DeleteElasticsearchIndexAlias(indexName, aliasesToDelete); diags.HasError() {
+			if diags := client.DeleteElasticsearchIndexAlias(ctx, indexName, aliasesToDelete); diags.HasError() {
 				return diags
 			}
 		}
 
 		// keep new aliases up-to-date
 		for _, v := range enew {
-			if diags := client.UpdateElasticsearchIndexAlias(indexName, &v); diags.HasError() {
+			if diags := client.UpdateElasticsearchIndexAlias(ctx, indexName, &v); diags.HasError() {
 				return diags
 			}
 		}
 	}
 
 	// settings
-	if d.HasChange("settings") {
+	if d.HasChanges("settings", "analysis", "default_pipeline", "final_pipeline", "routing_allocation_total_shards_per_node") {
 		oldSettings, newSettings := d.GetChange("settings")
-		os := flattenIndexSettings(oldSettings.([]interface{}))
-		ns := flattenIndexSettings(newSettings.([]interface{}))
-		log.Printf("[TRACE] Change in the settings detected old settings = %+v, new  settings = %+v", os, ns)
-		// make sure to add setting to the new map which were removed
-		for k, ov := range os {
-			if _, ok := ns[k]; !ok {
-				ns[k] = nil
+		oldAnalysis, newAnalysis := d.GetChange("analysis")
+		os, err := managedIndexSettings(oldSettings, oldAnalysis)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ns, err := managedIndexSettings(newSettings, newAnalysis)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		oldPipelines, newPipelines := make(map[string]interface{}), make(map[string]interface{})
+		for attr := range indexPipelineAttributes {
+			oldPipelines[attr], newPipelines[attr] = d.GetChange(attr)
+		}
+		for setting, value := range expandIndexPipelines(oldPipelines) {
+			os[setting] = value
+		}
+		for setting, value := range expandIndexPipelines(newPipelines) {
+			ns[setting] = value
+		}
+		oldSortRouting, newSortRouting := make(map[string]interface{}), make(map[string]interface{})
+		for attr := range indexSortRoutingAttributes {
+			oldSortRouting[attr], newSortRouting[attr] = d.GetChange(attr)
+		}
+		for setting, value := range expandIndexSortRouting(oldSortRouting) {
+			os[setting] = value
+		}
+		for setting, value := range expandIndexSortRouting(newSortRouting) {
+			ns[setting] = value
+		}
+		tflog.Trace(ctx, fmt.Sprintf("Change in the settings detected old settings = %+v, new  settings = %+v", os, ns))
+		ns = changedIndexSettings(os, ns)
+		tflog.Trace(ctx, fmt.Sprintf("settings to update: %+v", ns))
+
+		if d.Get("validate_pipelines").(bool) {
+			if diags := checkIndexPipelines(ctx, client, ns); diags.HasError() {
+				return diags
+			}
+		}
+
+		closeIndex := isOpen && len(settingsRequiringClosedIndex(ns)) > 0
+		if closeIndex {
+			if !newClosed.(bool) && !d.Get("allow_close").(bool) {
+				return diag.Errorf("the settings [%s] can be updated only on the closed index, set allow_close to close the index while they are updated", strings.Join(settingsRequiringClosedIndex(ns), ", "))
 			}
-			// remove the keys if the new value matches old one
-			// we need to update only changed settings
-			if nv, ok := ns[k]; ok && nv == ov {
-				delete(ns, k)
+			tflog.Debug(ctx, fmt.Sprintf("closing the index %s to update the static settings", indexName))
+			if diags := client.CloseElasticsearchIndex(ctx, indexName); diags.HasError() {
+				return diags
 			}
 		}
-		log.Printf("[TRACE] settings to update: %+v", ns)
-		if diags := client.UpdateElasticsearchIndexSettings(indexName, ns); diags.HasError() {
+		if diags := client.UpdateElasticsearchIndexSettings(ctx, indexName, ns); diags.HasError() {
 			return diags
 		}
+		if closeIndex {
+			if newClosed.(bool) {
+				isOpen = false
+			} else if diags := client.OpenElasticsearchIndex(ctx, indexName); diags.HasError() {
+				return diags
+			}
+		}
 	}
 
 	// mappings
-	if d.HasChange("mappings") {
+	if mappingsChanged {
 		// at this point we know there are mappings defined and there is a change which we can apply
-		mappings := d.Get("mappings").(string)
-		if diags := client.UpdateElasticsearchIndexMappings(indexName, mappings); diags.HasError() {
+		if diags := client.UpdateElasticsearchIndexMappings(ctx, indexName, newMappings.(string)); diags.HasError() {
 			return diags
 		}
 	}
 
+	if diags := updateIndexBlocks(ctx, client, indexName, addedBlocks); diags.HasError() {
+		return diags
+	}
+
+	if isOpen && newClosed.(bool) {
+		if diags := client.CloseElasticsearchIndex(ctx, indexName); diags.HasError() {
+			return diags
+		}
+	}
+
+	if diags := checkIndexHealth(ctx, client, d, indexName); diags.HasError() {
+		return diags
+	}
 	return resourceIndexRead(ctx, d, meta)
 }
 
@@ -405,6 +645,19 @@ func flattenIndexSettings(settings []interface{}) map[string]interface{} {
 	return ns
 }
 
+// Returns the index settings managed by the resource, i.e. the settings and the analysis components
+func managedIndexSettings(settings, analysis interface{}) (map[string]interface{}, error) {
+	managed := flattenIndexSettings(settings.([]interface{}))
+	analysisSettings, err := expandIndexAnalysis(analysis)
+	if err != nil {
+		return nil, err
+	}
+	for setting, value := range analysisSettings {
+		managed[setting] = value
+	}
+	return managed, nil
+}
+
 func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client, err := clients.NewApiClient(d, meta)
@@ -417,11 +670,17 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 	indexName := compId.ResourceId
 
-	if err := d.Set("name", indexName); err != nil {
+	// the name of the migrated index is kept, since it's the base of the names of its generations
+	if !isIndexGeneration(d.Get("name").(string), indexName) {
+		if err := d.Set("name", indexName); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("current_index", indexName); err != nil {
 		return diag.FromErr(err)
 	}
 
-	index, diags := client.GetElasticsearchIndex(indexName)
+	index, diags := client.GetElasticsearchIndex(ctx, indexName)
 	if index == nil && diags == nil {
 		// no index found on ES side
 		d.SetId("")
@@ -430,7 +689,7 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	if diags.HasError() {
 		return diags
 	}
-	log.Printf("[TRACE] read the index data: %+v", index)
+	tflog.Trace(ctx, fmt.Sprintf("read the index data: %+v", index))
 
 	if index.Aliases != nil {
 		aliases, diags := FlattenIndexAliases(index.Aliases)
@@ -450,6 +709,13 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 			return diag.FromErr(err)
 		}
 	}
+	hash, err := flattenMappingsFileHash(d.Get("mappings_file").(string), index.Mappings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(mappingsFileHashAttr, hash); err != nil {
+		return diag.FromErr(err)
+	}
 	if index.Settings != nil {
 		s, err := json.Marshal(index.Settings)
 		if err != nil {
@@ -458,6 +724,29 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		if err := d.Set("settings_raw", string(s)); err != nil {
 			return diag.FromErr(err)
 		}
+		if err := d.Set("blocks", flattenIndexBlocks(index.Settings)); err != nil {
+			return diag.FromErr(err)
+		}
+		// the pipelines set in the settings are tracked there
+		for attr, value := range flattenIndexPipelines(index.Settings, flattenIndexSettings(d.Get("settings").([]interface{}))) {
+			if err := d.Set(attr, value); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		// so are the sorting and routing settings
+		for attr, value := range flattenIndexSortRouting(index.Settings, flattenIndexSettings(d.Get("settings").([]interface{}))) {
+			if err := d.Set(attr, value); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	state, diags := client.GetElasticsearchIndexState(ctx, indexName)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("closed", state == "close"); err != nil {
+		return diag.FromErr(err)
 	}
 	return diags
 }
@@ -473,7 +762,7 @@ func resourceIndexDelete(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
-	if diags := client.DeleteElasticsearchIndex(compId.ResourceId); diags.HasError() {
+	if diags := client.DeleteElasticsearchIndex(ctx, compId.ResourceId); diags.HasError() {
 		return diags
 	}
 	d.SetId("")
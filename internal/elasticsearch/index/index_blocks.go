@@ -0,0 +1,134 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// the blocks managed by the `blocks` attribute of the index
+var indexBlocks = []string{"read_only", "read_only_allow_delete", "write"}
+
+// the static settings, which can be updated only when the index is closed
+var closedIndexSettingPrefixes = []string{"index.analysis.", "index.similarity.", "index.codec", "index.shard.check_on_startup"}
+
+// Returns the sorted names of the settings, which can be updated only on the closed index
+func settingsRequiringClosedIndex(settings map[string]interface{}) []string {
+	names := make([]string, 0)
+	for name := range settings {
+		normalized := name
+		if !strings.HasPrefix(normalized, "index.") {
+			normalized = "index." + normalized
+		}
+		for _, prefix := range closedIndexSettingPrefixes {
+			if strings.HasPrefix(normalized, prefix) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the settings to send to update the index from the old to the new settings, the removed settings are reset to their defaults
+func changedIndexSettings(oldSettings, newSettings map[string]interface{}) map[string]interface{} {
+	changed := make(map[string]interface{}, len(newSettings))
+	for k, v := range newSettings {
+		changed[k] = v
+	}
+	for k, ov := range oldSettings {
+		// make sure to add setting to the new map which were removed
+		nv, ok := changed[k]
+		if !ok {
+			changed[k] = nil
+			continue
+		}
+		// we need to update only changed settings
+		if reflect.DeepEqual(nv, ov) {
+			delete(changed, k)
+		}
+	}
+	return changed
+}
+
+func validateIndexClose(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// the index is created with all the settings
+	if d.Id() == "" || (!d.HasChange("settings") && !d.HasChange("analysis")) {
+		return nil
+	}
+	// the index is closed anyway
+	if d.Get("closed").(bool) {
+		return nil
+	}
+	oldSettings, newSettings := d.GetChange("settings")
+	oldAnalysis, newAnalysis := d.GetChange("analysis")
+	os, err := managedIndexSettings(oldSettings, oldAnalysis)
+	if err != nil {
+		return err
+	}
+	ns, err := managedIndexSettings(newSettings, newAnalysis)
+	if err != nil {
+		return err
+	}
+	changed := changedIndexSettings(os, ns)
+	if names := settingsRequiringClosedIndex(changed); len(names) > 0 && !d.Get("allow_close").(bool) {
+		return fmt.Errorf("the settings [%s] can be updated only on the closed index, set allow_close to close the index while they are updated", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// Returns the index block settings, which are lifted and the ones which are added by the change of the `blocks` attribute
+func changedIndexBlocks(d *schema.ResourceData) (map[string]interface{}, map[string]interface{}) {
+	lifted, added := make(map[string]interface{}), make(map[string]interface{})
+	if !d.HasChange("blocks") {
+		return lifted, added
+	}
+	oldBlocks, newBlocks := d.GetChange("blocks")
+	o, n := expandIndexBlocks(oldBlocks.([]interface{})), expandIndexBlocks(newBlocks.([]interface{}))
+	for setting, enabled := range n {
+		if o[setting] == enabled {
+			continue
+		}
+		if enabled.(bool) {
+			added[setting] = true
+		} else {
+			lifted[setting] = false
+		}
+	}
+	return lifted, added
+}
+
+func expandIndexBlocks(blocks []interface{}) map[string]interface{} {
+	settings := make(map[string]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return settings
+	}
+	b := blocks[0].(map[string]interface{})
+	for _, block := range indexBlocks {
+		settings["index.blocks."+block] = b[block].(bool)
+	}
+	return settings
+}
+
+func flattenIndexBlocks(settings map[string]interface{}) []interface{} {
+	blocks := make(map[string]interface{})
+	for _, block := range indexBlocks {
+		blocks[block] = fmt.Sprintf("%v", settings["index.blocks."+block]) == "true"
+	}
+	return []interface{}{blocks}
+}
+
+func updateIndexBlocks(ctx context.Context, client *clients.ApiClient, indexName string, blocks map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(blocks) == 0 {
+		return diags
+	}
+	return client.UpdateElasticsearchIndexSettings(ctx, indexName, blocks)
+}
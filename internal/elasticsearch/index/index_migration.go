@@ -0,0 +1,258 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	indexMigrationRecreate       = "recreate"
+	indexMigrationReindexAndSwap = "reindex_and_swap"
+
+	// how long the update of the index may take by default, including the copy of the documents by the migration
+	indexUpdateTimeout = 90 * time.Minute
+	// the part of the update timeout kept for the steps following the copy of the documents
+	indexMigrationMargin = time.Minute
+	// how long the rollback of the failed migration may take, it doesn't share the deadline of the update which may be over already
+	indexMigrationRollbackTimeout = time.Minute
+)
+
+func validateIndexMappingsChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	oldMappings, newMappings, changed, err := indexMappingsChange(d)
+	if err != nil || !changed {
+		return err
+	}
+	if !mappingsRequireNewIndex(ctx, oldMappings, newMappings) {
+		return nil
+	}
+	if d.Get("migration_strategy").(string) != indexMigrationReindexAndSwap {
+		// the mappings attribute keeps the mappings found in the cluster while they are read from the file
+		if d.Get("mappings_file").(string) != "" {
+			return d.ForceNew(mappingsFileHashAttr)
+		}
+		return d.ForceNew("mappings")
+	}
+	if d.Get("alias").(*schema.Set).Len() == 0 {
+		return fmt.Errorf("the %s migration strategy requires at least one alias, which is moved to the next generation of the index", indexMigrationReindexAndSwap)
+	}
+	return d.SetNewComputed("current_index")
+}
+
+// Checks whether the index is the name itself, or its generation created by the migration
+func isIndexGeneration(name, index string) bool {
+	if name == index {
+		return true
+	}
+	return regexp.MustCompile(fmt.Sprintf(`^%s-\d{6}$`, regexp.QuoteMeta(name))).MatchString(index)
+}
+
+// Returns the name of the next generation of the index, e.g. `my-index-000002` for `my-index`
+func nextIndexGeneration(name, index string) string {
+	generation := 1
+	if index != name {
+		if n, err := strconv.Atoi(index[len(name)+1:]); err == nil {
+			generation = n
+		}
+	}
+	return fmt.Sprintf("%s-%06d", name, generation+1)
+}
+
+// Migrates the index to the new mappings: the next generation of the index is created, the writes to the current one are blocked,
+// the documents are copied into the new index and the aliases are moved to it, while the current index is removed in the same atomic request.
+func resourceIndexMigrate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	oldIndex := compId.ResourceId
+	newIndex := nextIndexGeneration(d.Get("name").(string), oldIndex)
+	tflog.Info(ctx, fmt.Sprintf("migrating the index %s to %s", oldIndex, newIndex))
+
+	index, diags := expandIndex(d)
+	if diags.HasError() {
+		return diags
+	}
+	index.Name = newIndex
+	// the aliases are moved once the documents are copied, and the blocks would reject the copied documents
+	aliases := index.Aliases
+	index.Aliases = nil
+	blocks := make(map[string]interface{})
+	for _, block := range indexBlocks {
+		setting := "index.blocks." + block
+		if enabled, ok := index.Settings[setting]; ok {
+			if enabled.(bool) {
+				blocks[setting] = true
+			}
+			delete(index.Settings, setting)
+		}
+	}
+
+	rollback := func(taskId string) diag.Diagnostics {
+		tflog.Warn(ctx, fmt.Sprintf("rolling back the migration of the index %s to %s", oldIndex, newIndex))
+		rollbackCtx, cancel := context.WithTimeout(context.Background(), indexMigrationRollbackTimeout)
+		defer cancel()
+		return rollbackIndexMigration(rollbackCtx, client, oldIndex, newIndex, taskId)
+	}
+
+	if diags := client.PutElasticsearchIndex(ctx, index); diags.HasError() {
+		return diags
+	}
+	if diags := client.UpdateElasticsearchIndexSettings(ctx, oldIndex, map[string]interface{}{"index.blocks.write": true}); diags.HasError() {
+		return append(diags, rollback("")...)
+	}
+	if taskId, diags := copyIndexDocuments(ctx, client, oldIndex, newIndex, indexMigrationWait(ctx, d.Timeout(schema.TimeoutUpdate))); diags.HasError() {
+		return append(diags, rollback(taskId)...)
+	}
+
+	actions := make([]map[string]interface{}, 0, len(aliases)+1)
+	for name, alias := range aliases {
+		add, diags := indexAliasAction(newIndex, name, alias)
+		if diags.HasError() {
+			return append(diags, rollback("")...)
+		}
+		actions = append(actions, map[string]interface{}{"add": add})
+	}
+	actions = append(actions, map[string]interface{}{"remove_index": map[string]interface{}{"index": oldIndex}})
+	if diags := client.UpdateElasticsearchAliases(ctx, actions); diags.HasError() {
+		return append(diags, rollback("")...)
+	}
+
+	compId.ResourceId = newIndex
+	d.SetId(compId.String())
+
+	if diags := updateIndexBlocks(ctx, client, newIndex, blocks); diags.HasError() {
+		return diags
+	}
+	if d.Get("closed").(bool) {
+		if diags := client.CloseElasticsearchIndex(ctx, newIndex); diags.HasError() {
+			return diags
+		}
+	}
+	if diags := checkIndexHealth(ctx, client, d, newIndex); diags.HasError() {
+		return diags
+	}
+	return resourceIndexRead(ctx, d, meta)
+}
+
+// Copies the documents into the new index, returning the ID of the reindex task, which may still be running when the copy fails
+func copyIndexDocuments(ctx context.Context, client *clients.ApiClient, source, dest string, timeout time.Duration) (string, diag.Diagnostics) {
+	reindex := models.Reindex{
+		Source: models.ReindexSource{Index: []string{source}},
+		Dest:   models.ReindexDest{Index: dest},
+	}
+	taskId, diags := client.ReindexElasticsearch(ctx, &reindex, "auto", true)
+	if diags.HasError() {
+		return "", diags
+	}
+	task, diags := waitForTask(ctx, client, taskId, timeout)
+	if diags.HasError() {
+		return taskId, diags
+	}
+	if !task.Completed {
+		return taskId, diag.Errorf(`Timed out copying the documents from "%s" to "%s" after %s, increase the update timeout of the resource`, source, dest, timeout)
+	}
+	status, diags := reindexTaskStatus(task)
+	if diags.HasError() {
+		return taskId, diags
+	}
+	if task.Error != nil || len(status.Failures) > 0 {
+		detail, _ := json.Marshal(task.Error)
+		if task.Error == nil {
+			detail, _ = json.Marshal(status.Failures)
+		}
+		return taskId, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`Unable to copy the documents from "%s" to "%s"`, source, dest),
+			Detail:   string(detail),
+		}}
+	}
+	return taskId, diags
+}
+
+func indexAliasAction(index, name string, alias models.IndexAlias) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	aliasBytes, err := json.Marshal(alias)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	action := make(map[string]interface{})
+	if err := json.Unmarshal(aliasBytes, &action); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	action["index"] = index
+	action["alias"] = name
+	return action, diags
+}
+
+// Returns how long to wait for the documents to be copied: the update timeout, or the time left before the deadline of the update
+// if it's sooner, minus the margin for the steps following the copy
+func indexMigrationWait(ctx context.Context, timeout time.Duration) time.Duration {
+	wait := timeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		wait = time.Until(deadline)
+	}
+	wait -= indexMigrationMargin
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// Lifts the write block of the current index and removes the next generation, so the migration can be retried. The reindex task
+// is cancelled first, since the running task would create the deleted index again with the dynamic mappings.
+func rollbackIndexMigration(ctx context.Context, client *clients.ApiClient, oldIndex, newIndex, taskId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if d := client.UpdateElasticsearchIndexSettings(ctx, oldIndex, map[string]interface{}{"index.blocks.write": nil}); d.HasError() {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`The index "%s" is still write-blocked`, oldIndex),
+			Detail: fmt.Sprintf("The migration failed and the write block of the index could not be lifted, so all the writes to it are rejected. "+
+				"Lift it with `PUT %s/_settings {\"index.blocks.write\": null}`. %s", oldIndex, diagnosticsDetail(d)),
+		})
+	}
+	if taskId != "" {
+		if _, d := client.CancelElasticsearchTasks(ctx, taskId, nil, nil, true); d.HasError() {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf(`Unable to cancel the copy of the documents into "%s"`, newIndex),
+				Detail: fmt.Sprintf("The reindex task %s may still be running, so the index \"%s\" is not removed. Cancel the task and delete the index before the migration is retried. %s",
+					taskId, newIndex, diagnosticsDetail(d)),
+			})
+		}
+	}
+	return append(diags, client.DeleteElasticsearchIndex(ctx, newIndex)...)
+}
+
+// Joins the summaries and the details of the error diagnostics, to report them as the cause of another error
+func diagnosticsDetail(diags diag.Diagnostics) string {
+	causes := make([]string, 0, len(diags))
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		cause := d.Summary
+		if d.Detail != "" {
+			cause += ": " + d.Detail
+		}
+		causes = append(causes, cause)
+	}
+	return "Cause: " + strings.Join(causes, "; ")
+}
@@ -0,0 +1,25 @@
+package index
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIndexMigrationWait(t *testing.T) {
+	if wait := indexMigrationWait(context.Background(), 30*time.Minute); wait != 29*time.Minute {
+		t.Errorf("expected to wait for the update timeout minus the margin, got %s", wait)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	if wait := indexMigrationWait(ctx, 30*time.Minute); wait > 9*time.Minute || wait < 8*time.Minute {
+		t.Errorf("expected to wait until the deadline of the context minus the margin, got %s", wait)
+	}
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), 0)
+	defer cancelExpired()
+	if wait := indexMigrationWait(expired, 30*time.Minute); wait != 0 {
+		t.Errorf("expected no wait once the deadline is over, got %s", wait)
+	}
+}
@@ -0,0 +1,116 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maps the attributes to the index settings referencing the ingest pipelines
+var indexPipelineAttributes = map[string]string{
+	"default_pipeline": "index.default_pipeline",
+	"final_pipeline":   "index.final_pipeline",
+}
+
+func indexPipelineSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"default_pipeline": {
+			Description: "The ingest pipeline applied to the documents indexed without the pipeline (`index.default_pipeline`), `_none` disables it. It cannot be set in the settings at the same time.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"final_pipeline": {
+			Description: "The ingest pipeline applied to all the documents after the other pipelines (`index.final_pipeline`), `_none` disables it. It cannot be set in the settings at the same time.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	}
+}
+
+// Returns the pipeline settings set by the attributes, keyed by the attribute
+func expandIndexPipelines(attrs map[string]interface{}) map[string]interface{} {
+	settings := make(map[string]interface{})
+	for attr, setting := range indexPipelineAttributes {
+		if v, _ := attrs[attr].(string); v != "" {
+			settings[setting] = v
+		}
+	}
+	return settings
+}
+
+// Returns the pipeline attributes read from the index settings, except the ones managed in the configured settings
+func flattenIndexPipelines(settings map[string]interface{}, configured map[string]interface{}) map[string]interface{} {
+	normalized := utils.NormalizeIndexSettings(utils.FlattenMap(settings))
+	configured = utils.NormalizeIndexSettings(configured)
+	attrs := make(map[string]interface{})
+	for attr, setting := range indexPipelineAttributes {
+		attrs[attr] = ""
+		if _, ok := configured[setting]; ok {
+			continue
+		}
+		if v, ok := normalized[setting]; ok {
+			attrs[attr] = fmt.Sprint(v)
+		}
+	}
+	return attrs
+}
+
+// Returns the pipeline settings which are set both by the attributes and in the settings
+func conflictingPipelineSettings(attrs, settings map[string]interface{}) []string {
+	normalized := utils.NormalizeIndexSettings(utils.FlattenMap(settings))
+	conflicts := make([]string, 0)
+	for setting := range expandIndexPipelines(attrs) {
+		if _, ok := normalized[setting]; ok {
+			conflicts = append(conflicts, setting)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// The pipelines can be managed either by the attributes or in the settings
+func validateIndexPipelines(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	attrs := make(map[string]interface{})
+	for attr := range indexPipelineAttributes {
+		if !d.NewValueKnown(attr) {
+			return nil
+		}
+		attrs[attr] = d.Get(attr)
+	}
+	if !d.NewValueKnown("settings") {
+		return nil
+	}
+	if conflicts := conflictingPipelineSettings(attrs, flattenIndexSettings(d.Get("settings").([]interface{}))); len(conflicts) > 0 {
+		return fmt.Errorf("[%s] are set both by the pipeline attributes and in the settings, only one of them can be used", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// The pipelines of the template can be managed either by the attributes or in the settings
+func validateTemplatePipelines(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	attrs := make(map[string]interface{})
+	for attr := range indexPipelineAttributes {
+		if !d.NewValueKnown("template.0." + attr) {
+			return nil
+		}
+		attrs[attr] = d.Get("template.0." + attr)
+	}
+	if !d.NewValueKnown("template.0.settings") {
+		return nil
+	}
+	conflicts := make([]string, 0)
+	for setting := range expandIndexPipelines(attrs) {
+		if configuredIndexSetting(d.Get("template.0.settings").(string), setting) {
+			conflicts = append(conflicts, setting)
+		}
+	}
+	sort.Strings(conflicts)
+	if len(conflicts) > 0 {
+		return fmt.Errorf("[%s] are set both by the pipeline attributes and in the settings of the template, only one of them can be used", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
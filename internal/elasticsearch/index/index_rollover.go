@@ -0,0 +1,183 @@
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceIndexRollover() *schema.Resource {
+	rolloverSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"target": {
+			Description: "The name of the alias or the data stream to roll over.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"new_index": {
+			Description: "The name of the new index, only for the aliases. Generated from the name of the current index by default, e.g. `logs-000002` for `logs-000001`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"conditions": {
+			Description: "The conditions to roll over on, at least one of them must be met. The target is rolled over unconditionally by default.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"max_age": {
+						Description: "Rolls over once the maximum elapsed time from the creation of the index is reached.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						ForceNew:    true,
+					},
+					"max_docs": {
+						Description:  "Rolls over once the maximum number of documents is reached.",
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"max_size": {
+						Description: "Rolls over once the index reaches the size.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						ForceNew:    true,
+					},
+					"max_primary_shard_size": {
+						Description: "Rolls over once the largest primary shard of the index reaches the size.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						ForceNew:    true,
+					},
+				},
+			},
+		},
+		"dry_run": {
+			Description: "Only check the conditions, without rolling over.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+		},
+		"triggers": {
+			Description: "Arbitrary map of values that, when changed, will roll over again, e.g. the version of the index template.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"old_index": {
+			Description: "The name of the index the target pointed to before the rollover.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"rolled_over": {
+			Description: "Whether the target was rolled over.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"condition_results": {
+			Description: "Whether each of the conditions was met, e.g. `[max_docs: 1000]`.",
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeBool,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(rolloverSchema)
+
+	return &schema.Resource{
+		Description: "Rolls over the alias or the data stream into the new index, e.g. to apply the changes of the index template to the write index at a controlled time. Destroying the resource doesn't change the indices. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-rollover-index.html",
+
+		CreateContext: resourceIndexRolloverCreate,
+		// the rollover cannot be changed, only the connection can
+		UpdateContext: resourceIndexRolloverRead,
+		ReadContext:   resourceIndexRolloverRead,
+		DeleteContext: resourceIndexRolloverDelete,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: rolloverSchema,
+	}
+}
+
+func resourceIndexRolloverCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	target := d.Get("target").(string)
+
+	var rollover models.Rollover
+	if v, ok := d.GetOk("conditions"); ok && v.([]interface{})[0] != nil {
+		conditions, diags := expandAction(v.([]interface{}), "max_age", "max_docs", "max_size", "max_primary_shard_size")
+		if diags.HasError() {
+			return diags
+		}
+		rollover.Conditions = conditions
+	}
+	dryRun := d.Get("dry_run").(bool)
+	response, diags := client.RolloverElasticsearchIndex(ctx, target, d.Get("new_index").(string), &rollover, dryRun)
+	if diags.HasError() {
+		return diags
+	}
+
+	id, diags := client.ID(ctx, response.NewIndex)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("new_index", response.NewIndex); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("old_index", response.OldIndex); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("rolled_over", response.RolledOver); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("condition_results", response.Conditions); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id.String())
+
+	if !response.RolledOver && !dryRun {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The target was not rolled over",
+			Detail:   fmt.Sprintf(`None of the conditions were met, "%s" still points to "%s". Change the triggers to check the conditions again.`, target, response.OldIndex),
+		})
+	}
+	return append(diags, resourceIndexRolloverRead(ctx, d, meta)...)
+}
+
+// The rollover is done once the request succeeds, there is nothing to read back
+func resourceIndexRolloverRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+func resourceIndexRolloverDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,76 @@
+package index_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceIndexRollover(t *testing.T) {
+	dsName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceDataStreamDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexRollover(dsName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_rollover.test", "rolled_over", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_rollover.test", "condition_results.[max_docs: 1000]", "false"),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_index_rollover.test", "old_index", regexp.MustCompile(`-000001$`)),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_index_rollover.test", "new_index", regexp.MustCompile(`-000002$`)),
+				),
+			},
+			{
+				Config: testAccResourceIndexRollover(dsName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_rollover.test", "rolled_over", "true"),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_index_rollover.test", "new_index", regexp.MustCompile(`-000002$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexRollover(name string, dryRun bool) string {
+	conditions := ""
+	if dryRun {
+		conditions = `
+  conditions {
+    max_docs = 1000
+  }`
+	}
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name = "%s"
+
+  index_patterns = ["%s*"]
+
+  data_stream {}
+}
+
+resource "elasticstack_elasticsearch_data_stream" "test" {
+  name = "%s"
+
+  depends_on = [
+    elasticstack_elasticsearch_index_template.test
+  ]
+}
+
+resource "elasticstack_elasticsearch_index_rollover" "test" {
+  target  = elasticstack_elasticsearch_data_stream.test.name
+  dry_run = %t
+%s
+}
+	`, name, name, name, dryRun, conditions)
+}
@@ -0,0 +1,312 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maps the resource attributes to the index settings they manage
+var indexSettingsAttributes = map[string]string{
+	"number_of_replicas":        "index.number_of_replicas",
+	"refresh_interval":          "index.refresh_interval",
+	"routing_allocation_enable": "index.routing.allocation.enable",
+}
+
+// maps the resource attributes to the prefixes of the index settings they manage
+var indexSettingsFilterAttributes = map[string]string{
+	"routing_allocation_include": "index.routing.allocation.include.",
+	"routing_allocation_exclude": "index.routing.allocation.exclude.",
+	"routing_allocation_require": "index.routing.allocation.require.",
+}
+
+func ResourceIndexSettings() *schema.Resource {
+	settingsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Name of the existing index, or the index pattern (e.g. `filebeat-*`), to manage the settings of.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"number_of_replicas": {
+			Description:  "The number of replicas each primary shard has.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"refresh_interval": {
+			Description: "How often to perform a refresh operation, e.g. `30s`, or `-1` to disable the refresh.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ValidateFunc: validation.Any(
+				validation.StringInSlice([]string{"-1"}, false),
+				utils.StringIsElasticDuration,
+			),
+		},
+		"routing_allocation_enable": {
+			Description:  "Controls shard allocation for the index, one of `all`, `primaries`, `new_primaries` or `none`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"all", "primaries", "new_primaries", "none"}, false),
+		},
+		"routing_allocation_include": {
+			Description:      "Assigns the index to the nodes having at least one of the node attribute values, given as the JSON object, e.g. `{\"_tier_preference\": \"data_warm,data_hot\"}`.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"routing_allocation_exclude": {
+			Description:      "Assigns the index to the nodes having none of the node attribute values, given as the JSON object.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"routing_allocation_require": {
+			Description:      "Assigns the index to the nodes having all of the node attribute values, given as the JSON object.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+	}
+
+	utils.AddConnectionSchema(settingsSchema)
+
+	return &schema.Resource{
+		Description: "Manages the dynamic settings of the existing indices, without managing the indices themselves, e.g. to tune the indices created by Beats or Elastic Agent. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-update-settings.html",
+
+		CreateContext: resourceIndexSettingsPut,
+		UpdateContext: resourceIndexSettingsPut,
+		ReadContext:   resourceIndexSettingsRead,
+		DeleteContext: resourceIndexSettingsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				// on import all the settings explicitly set on the index are taken over
+				if diags := readIndexSettings(ctx, d, m, true); diags.HasError() {
+					return nil, fmt.Errorf("Unable to import the settings of the requested index")
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: settingsSchema,
+	}
+}
+
+func resourceIndexSettingsPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	indexName := d.Get("index").(string)
+	id, diags := client.ID(ctx, indexName)
+	if diags.HasError() {
+		return diags
+	}
+
+	settings := make(map[string]interface{})
+	for attr, setting := range indexSettingsAttributes {
+		// number_of_replicas can be zero, so we must check whether it is set at all
+		if v, ok := d.GetOkExists(attr); ok && (attr == "number_of_replicas" || v.(string) != "") {
+			settings[setting] = v
+		} else if d.HasChange(attr) {
+			// make sure the setting removed from the configuration is reset to its default value
+			settings[setting] = nil
+		}
+	}
+	for attr, prefix := range indexSettingsFilterAttributes {
+		oldFilters, newFilters := d.GetChange(attr)
+		oldFilter, diags := expandIndexSettingsFilter(oldFilters.(string))
+		if diags.HasError() {
+			return diags
+		}
+		newFilter, diags := expandIndexSettingsFilter(newFilters.(string))
+		if diags.HasError() {
+			return diags
+		}
+		for k := range oldFilter {
+			settings[prefix+k] = nil
+		}
+		for k, v := range newFilter {
+			settings[prefix+k] = v
+		}
+	}
+
+	if diags := client.UpdateElasticsearchIndexSettings(ctx, indexName, settings); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceIndexSettingsRead(ctx, d, meta)
+}
+
+func expandIndexSettingsFilter(filter string) (map[string]interface{}, diag.Diagnostics) {
+	res := make(map[string]interface{})
+	if filter == "" {
+		return res, nil
+	}
+	if err := json.Unmarshal([]byte(filter), &res); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return res, nil
+}
+
+func resourceIndexSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readIndexSettings(ctx, d, meta, false)
+}
+
+// Reads the managed settings of the index, or all the supported settings explicitly set on the index if `all` is true.
+// When the index pattern matches several indices, the value differing from the configured one is reported,
+// so the drift on any of the indices is detected.
+func readIndexSettings(ctx context.Context, d *schema.ResourceData, meta interface{}, all bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	indexName := compId.ResourceId
+
+	indices, diags := client.GetElasticsearchIndexSettings(ctx, indexName)
+	if diags.HasError() {
+		return diags
+	}
+	if len(indices) == 0 {
+		// no indices found on ES side
+		d.SetId("")
+		return diags
+	}
+	names := make([]string, 0, len(indices))
+	for name := range indices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := d.Set("index", indexName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for attr, setting := range indexSettingsAttributes {
+		current := fmt.Sprintf("%v", d.Get(attr))
+		if _, ok := d.GetOkExists(attr); !ok && !all {
+			continue
+		}
+		value := ""
+		for _, name := range names {
+			v, _ := indices[name][setting].(string)
+			value = v
+			if v != current {
+				break
+			}
+		}
+		if attr == "number_of_replicas" {
+			if value == "" {
+				continue
+			}
+			replicas, err := strconv.Atoi(value)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set(attr, replicas); err != nil {
+				return diag.FromErr(err)
+			}
+		} else if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for attr, prefix := range indexSettingsFilterAttributes {
+		currentFilter, diags := expandIndexSettingsFilter(d.Get(attr).(string))
+		if diags.HasError() {
+			return diags
+		}
+		if len(currentFilter) == 0 && !all {
+			continue
+		}
+		var filter map[string]interface{}
+		for _, name := range names {
+			filter = make(map[string]interface{})
+			for k, v := range indices[name] {
+				if strings.HasPrefix(k, prefix) {
+					filter[strings.TrimPrefix(k, prefix)] = v
+				}
+			}
+			if !utils.MapsEqual(filter, currentFilter) {
+				break
+			}
+		}
+		if len(filter) == 0 {
+			if err := d.Set(attr, ""); err != nil {
+				return diag.FromErr(err)
+			}
+			continue
+		}
+		f, err := json.Marshal(filter)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set(attr, string(f)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceIndexSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	// reset the managed settings to their default values, the index itself is left untouched
+	settings := make(map[string]interface{})
+	for attr, setting := range indexSettingsAttributes {
+		if _, ok := d.GetOkExists(attr); ok {
+			settings[setting] = nil
+		}
+	}
+	for attr, prefix := range indexSettingsFilterAttributes {
+		filter, diags := expandIndexSettingsFilter(d.Get(attr).(string))
+		if diags.HasError() {
+			return diags
+		}
+		for k := range filter {
+			settings[prefix+k] = nil
+		}
+	}
+	if diags := client.UpdateElasticsearchIndexSettings(ctx, compId.ResourceId, settings); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
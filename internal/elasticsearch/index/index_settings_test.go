@@ -0,0 +1,97 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceIndexSettings(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexSettingsDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexSettingsCreate(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "index", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "number_of_replicas", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "refresh_interval", "30s"),
+				),
+			},
+			{
+				Config: testAccResourceIndexSettingsUpdate(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "number_of_replicas", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "refresh_interval", ""),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "routing_allocation_enable", "primaries"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexSettingsCreate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+}
+
+resource "elasticstack_elasticsearch_index_settings" "test" {
+  index              = elasticstack_elasticsearch_index.test.name
+  number_of_replicas = 0
+  refresh_interval   = "30s"
+}
+	`, name)
+}
+
+func testAccResourceIndexSettingsUpdate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+}
+
+resource "elasticstack_elasticsearch_index_settings" "test" {
+  index                     = elasticstack_elasticsearch_index.test.name
+  number_of_replicas        = 0
+  routing_allocation_enable = "primaries"
+}
+	`, name)
+}
+
+func checkResourceIndexSettingsDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_index" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		res, err := client.GetESClient().Indices.Get([]string{compId.ResourceId})
+		if err != nil {
+			return err
+		}
+
+		if res.StatusCode != 404 {
+			return fmt.Errorf("Index (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
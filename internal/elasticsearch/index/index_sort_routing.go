@@ -0,0 +1,202 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maps the attributes to the index sorting and routing settings
+var indexSortRoutingAttributes = map[string]string{
+	"sort_field":                               "index.sort.field",
+	"sort_order":                               "index.sort.order",
+	"routing_partition_size":                   "index.routing_partition_size",
+	"routing_allocation_total_shards_per_node": "index.routing.allocation.total_shards_per_node",
+}
+
+// the attributes of the list settings, the others are the integer settings
+var indexSortRoutingListAttributes = map[string]bool{"sort_field": true, "sort_order": true}
+
+func indexSortRoutingSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"sort_field": {
+			Description: "The fields to sort the segments of the index by (`index.sort.field`). The sorting is a static setting, changing it creates a new index. It cannot be set in the settings at the same time.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+		},
+		"sort_order": {
+			Description:  "The sort order of each of the `sort_field` fields, `asc` or `desc` (`index.sort.order`). Changing it creates a new index. It cannot be set in the settings at the same time.",
+			Type:         schema.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: []string{"sort_field"},
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"asc", "desc"}, false),
+			},
+		},
+		"routing_partition_size": {
+			Description:  "The number of shards the documents with the same custom routing value can go to (`index.routing_partition_size`). It must be less than the number of shards and the mappings must require the routing. It's a static setting, changing it creates a new index. It cannot be set in the settings at the same time.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"routing_allocation_total_shards_per_node": {
+			Description:  "The maximum number of the shards of the index allocated to a single node, `-1` for no limit (`index.routing.allocation.total_shards_per_node`). It cannot be set in the settings at the same time.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.Any(validation.IntInSlice([]int{-1}), validation.IntAtLeast(1)),
+		},
+	}
+}
+
+// Returns the sorting and routing settings set by the attributes
+func expandIndexSortRouting(attrs map[string]interface{}) map[string]interface{} {
+	settings := make(map[string]interface{})
+	for attr, setting := range indexSortRoutingAttributes {
+		switch v := attrs[attr].(type) {
+		case []interface{}:
+			if len(v) > 0 {
+				values := make([]string, 0, len(v))
+				for _, value := range v {
+					values = append(values, value.(string))
+				}
+				settings[setting] = values
+			}
+		case int:
+			if v != 0 {
+				settings[setting] = v
+			}
+		}
+	}
+	return settings
+}
+
+// Returns the sorting and routing attributes read from the index settings, except the ones managed in the configured settings
+func flattenIndexSortRouting(settings map[string]interface{}, configured map[string]interface{}) map[string]interface{} {
+	flat := utils.FlattenMap(settings)
+	configured = utils.NormalizeIndexSettings(configured)
+	attrs := make(map[string]interface{})
+	for attr, setting := range indexSortRoutingAttributes {
+		isList := indexSortRoutingListAttributes[attr]
+		if isList {
+			attrs[attr] = []interface{}{}
+		} else {
+			attrs[attr] = 0
+		}
+		if _, ok := configured[setting]; ok {
+			continue
+		}
+		v, ok := flat[setting]
+		if !ok {
+			v, ok = flat[strings.TrimPrefix(setting, "index.")]
+		}
+		if !ok {
+			continue
+		}
+		if isList {
+			attrs[attr] = settingValues(v)
+		} else if i, err := strconv.Atoi(fmt.Sprint(v)); err == nil {
+			attrs[attr] = i
+		}
+	}
+	return attrs
+}
+
+// The list settings are returned as the arrays, or as the comma separated strings when they were set so
+func settingValues(v interface{}) []interface{} {
+	values := make([]interface{}, 0)
+	switch s := v.(type) {
+	case []interface{}:
+		for _, value := range s {
+			values = append(values, fmt.Sprint(value))
+		}
+	default:
+		for _, value := range strings.Split(fmt.Sprint(s), ",") {
+			values = append(values, strings.TrimSpace(value))
+		}
+	}
+	return values
+}
+
+// Checks the sorting and routing attributes against the settings and the mappings of the index
+func sortRoutingError(attrs, settings map[string]interface{}, mappings string) error {
+	normalized := utils.NormalizeIndexSettings(utils.FlattenMap(settings))
+	expanded := expandIndexSortRouting(attrs)
+	conflicts := make([]string, 0)
+	for setting := range expanded {
+		if _, ok := normalized[setting]; ok {
+			conflicts = append(conflicts, setting)
+		}
+	}
+	sort.Strings(conflicts)
+	if len(conflicts) > 0 {
+		return fmt.Errorf("[%s] are set both by the sorting and routing attributes and in the settings, only one of them can be used", strings.Join(conflicts, ", "))
+	}
+
+	if order, ok := expanded["index.sort.order"].([]string); ok {
+		if fields, _ := expanded["index.sort.field"].([]string); len(fields) != len(order) {
+			return fmt.Errorf("sort_order must have the order of each of the %d sort_field fields, got %d", len(fields), len(order))
+		}
+	}
+
+	partitionSize, _ := expanded["index.routing_partition_size"].(int)
+	if partitionSize <= 1 {
+		return nil
+	}
+	// the number of shards may also come from the matching index templates, so it's only checked when it's configured
+	if shards, ok := normalized["index.number_of_shards"]; ok {
+		if n, err := strconv.Atoi(fmt.Sprint(shards)); err == nil && partitionSize >= n {
+			return fmt.Errorf("routing_partition_size must be less than index.number_of_shards (%d), got %d", n, partitionSize)
+		}
+	}
+	// the mappings may also come from the matching index templates, so the empty ones are not checked
+	if mappings != "" {
+		m := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(mappings), &m); err != nil || len(m) == 0 {
+			return nil
+		}
+		if doc, ok := m["_doc"].(map[string]interface{}); ok && len(m) == 1 {
+			m = doc
+		}
+		routing, _ := m["_routing"].(map[string]interface{})
+		if required, _ := routing["required"].(bool); !required {
+			return fmt.Errorf(`routing_partition_size requires the routing in the mappings, i.e. "_routing": {"required": true}`)
+		}
+	}
+	return nil
+}
+
+// The sorting and routing can be managed either by the attributes or in the settings, and they must be accepted by Elasticsearch
+func validateIndexSortRouting(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	attrs := make(map[string]interface{})
+	for attr := range indexSortRoutingAttributes {
+		if !d.NewValueKnown(attr) {
+			return nil
+		}
+		attrs[attr] = d.Get(attr)
+	}
+	if !d.NewValueKnown("settings") {
+		return nil
+	}
+	// the mappings of the file are checked by Elasticsearch
+	mappings := ""
+	if d.Get("mappings_file").(string) == "" && d.NewValueKnown("mappings") {
+		mappings = d.Get("mappings").(string)
+	}
+	return sortRoutingError(attrs, flattenIndexSettings(d.Get("settings").([]interface{})), mappings)
+}
@@ -0,0 +1,60 @@
+package index
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortRoutingError(t *testing.T) {
+	tests := []struct {
+		name     string
+		attrs    map[string]interface{}
+		settings map[string]interface{}
+		mappings string
+		expected string
+	}{
+		{"none", map[string]interface{}{}, nil, "", ""},
+		{"sort", map[string]interface{}{"sort_field": []interface{}{"@timestamp", "host"}, "sort_order": []interface{}{"desc", "asc"}}, nil, "", ""},
+		{"sort order of every field", map[string]interface{}{"sort_field": []interface{}{"@timestamp", "host"}, "sort_order": []interface{}{"desc"}}, nil, "",
+			"sort_order must have the order of each of the 2 sort_field fields, got 1"},
+		{"sort in the settings", map[string]interface{}{"sort_field": []interface{}{"@timestamp"}}, map[string]interface{}{"sort.field": "@timestamp"}, "",
+			"[index.sort.field] are set both by the sorting and routing attributes and in the settings, only one of them can be used"},
+		{"partition", map[string]interface{}{"routing_partition_size": 2}, map[string]interface{}{"index.number_of_shards": "3"}, `{"_routing": {"required": true}}`, ""},
+		{"partition of the template shards", map[string]interface{}{"routing_partition_size": 2}, nil, "{}", ""},
+		{"partition larger than the shards", map[string]interface{}{"routing_partition_size": 3}, map[string]interface{}{"index.number_of_shards": "3"}, "",
+			"routing_partition_size must be less than index.number_of_shards (3), got 3"},
+		{"partition without routing", map[string]interface{}{"routing_partition_size": 2}, nil, `{"properties": {"host": {"type": "keyword"}}}`,
+			`routing_partition_size requires the routing in the mappings, i.e. "_routing": {"required": true}`},
+	}
+
+	for _, tc := range tests {
+		err := sortRoutingError(tc.attrs, tc.settings, tc.mappings)
+		if tc.expected == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		}
+		if tc.expected != "" && (err == nil || err.Error() != tc.expected) {
+			t.Errorf("%s: expected the error %q, got %v", tc.name, tc.expected, err)
+		}
+	}
+}
+
+func TestFlattenIndexSortRouting(t *testing.T) {
+	settings := map[string]interface{}{
+		"index.sort.field": []interface{}{"@timestamp", "host"},
+		"index.sort.order": "desc,asc",
+		"index.routing": map[string]interface{}{
+			"allocation": map[string]interface{}{"total_shards_per_node": "2"},
+		},
+		"index.routing_partition_size": "2",
+	}
+	expected := map[string]interface{}{
+		"sort_field":                               []interface{}{"@timestamp", "host"},
+		"sort_order":                               []interface{}{"desc", "asc"},
+		"routing_partition_size":                   0,
+		"routing_allocation_total_shards_per_node": 2,
+	}
+	// the routing partition is managed in the settings
+	if attrs := flattenIndexSortRouting(settings, map[string]interface{}{"routing_partition_size": "2"}); !reflect.DeepEqual(attrs, expected) {
+		t.Errorf("expected the attributes %v, got %v", expected, attrs)
+	}
+}
@@ -2,6 +2,9 @@ package index_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -105,6 +108,398 @@ resource "elasticstack_elasticsearch_index" "test" {
 	`, name)
 }
 
+func TestAccResourceIndexBlocks(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexBlocks(indexName, "standard", true, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "blocks.0.write", "true"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "blocks.0.read_only", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "closed", "false"),
+				),
+			},
+			{
+				Config:      testAccResourceIndexBlocks(indexName, "simple", false, false, false),
+				ExpectError: regexp.MustCompile("can be updated only on the closed index"),
+			},
+			{
+				Config: testAccResourceIndexBlocks(indexName, "simple", false, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "blocks.0.write", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "closed", "false"),
+				),
+			},
+			{
+				Config: testAccResourceIndexBlocks(indexName, "simple", false, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "closed", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexBlocks(name, analyzer string, write, closed, allowClose bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  settings {
+    setting {
+      name  = "index.analysis.analyzer.default.type"
+      value = "%s"
+    }
+  }
+
+  blocks {
+    write = %t
+  }
+
+  closed      = %t
+  allow_close = %t
+}
+	`, name, analyzer, write, closed, allowClose)
+}
+
+func TestAccResourceIndexMigration(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexMigration(indexName, "text"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "name", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "current_index", indexName),
+				),
+			},
+			{
+				Config: testAccResourceIndexMigration(indexName, "keyword"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "name", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "current_index", indexName+"-000002"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "alias.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexMigration(name, fieldType string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  alias {
+    name = "%s-alias"
+  }
+
+  mappings = jsonencode({
+    properties = {
+      field1 = { type = "%s" }
+    }
+  })
+
+  migration_strategy = "reindex_and_swap"
+}
+	`, name, name, fieldType)
+}
+
+func TestAccResourceIndexAnalysis(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexAnalysis(indexName, "missing_stop"),
+				ExpectError: regexp.MustCompile(`references the undefined token filter "missing_stop"`),
+			},
+			{
+				Config: testAccResourceIndexAnalysis(indexName, "english_stop"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "analysis.0.analyzer.0.name", "english_text"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "analysis.0.analyzer.0.type", "custom"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "analysis.0.filter.0.name", "english_stop"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexAnalysis(name, stopFilter string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  analysis {
+    analyzer {
+      name        = "english_text"
+      tokenizer   = "standard"
+      filter      = ["lowercase", "%s"]
+      char_filter = ["html_strip"]
+    }
+
+    normalizer {
+      name   = "lowercase_keyword"
+      filter = ["lowercase", "asciifolding"]
+    }
+
+    filter {
+      name       = "english_stop"
+      type       = "stop"
+      parameters = jsonencode({ stopwords = "_english_" })
+    }
+  }
+
+  mappings = jsonencode({
+    properties = {
+      title = { type = "text", analyzer = "english_text" }
+      tag   = { type = "keyword", normalizer = "lowercase_keyword" }
+    }
+  })
+}
+	`, name, stopFilter)
+}
+
+func TestAccResourceIndexPipelines(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexPipelines(indexName, `"${elasticstack_elasticsearch_ingest_pipeline.test.name}-missing"`),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`The pipeline "%s-missing" set as index.default_pipeline does not exist`, indexName)),
+			},
+			{
+				Config: testAccResourceIndexPipelines(indexName, "elasticstack_elasticsearch_ingest_pipeline.test.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "default_pipeline", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "final_pipeline", "_none"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexPipelines(name, defaultPipeline string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test" {
+  name = "%[1]s"
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "event.ingested"
+        value = "{{_ingest.timestamp}}"
+      }
+    })
+  ]
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%[1]s"
+
+  default_pipeline = %[2]s
+  final_pipeline   = "_none"
+}
+	`, name, defaultPipeline)
+}
+
+func TestAccResourceIndexSortRouting(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexSortRouting(indexName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "sort_field.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "sort_field.0", "@timestamp"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "sort_order.0", "desc"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "routing_partition_size", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "routing_allocation_total_shards_per_node", "2"),
+				),
+			},
+			{
+				Config: testAccResourceIndexSortRouting(indexName, -1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "sort_field.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "routing_allocation_total_shards_per_node", "-1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexSortRouting(name string, totalShardsPerNode int) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  sort_field                               = ["@timestamp", "host.name"]
+  sort_order                               = ["desc", "asc"]
+  routing_partition_size                   = 2
+  routing_allocation_total_shards_per_node = %d
+
+  mappings = jsonencode({
+    _routing = { required = true }
+    properties = {
+      "@timestamp" = { type = "date" }
+      "host.name"  = { type = "keyword" }
+    }
+  })
+
+  settings {
+    setting {
+      name  = "index.number_of_shards"
+      value = "3"
+    }
+  }
+}
+	`, name, totalShardsPerNode)
+}
+
+func TestAccResourceIndexHealthCheck(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexHealthCheck(indexName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "health_check.0.status", "green"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "wait_for_active_shards", "all"),
+				),
+			},
+			{
+				// the replicas cannot be allocated on the single node of the test cluster
+				Config:      testAccResourceIndexHealthCheck(indexName, 1),
+				ExpectError: regexp.MustCompile(`Timed out waiting for the health of`),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexHealthCheck(name string, replicas int) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  settings {
+    setting {
+      name  = "index.number_of_replicas"
+      value = "%d"
+    }
+  }
+
+  wait_for_active_shards = "all"
+  health_check {
+    status  = "green"
+    timeout = "5s"
+  }
+}
+	`, name, replicas)
+}
+
+func TestAccResourceIndexMappingsFile(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+	mappingsFile := filepath.Join(t.TempDir(), "mappings.json")
+	writeMappings := func(mappings string) func() {
+		return func() {
+			if err := os.WriteFile(mappingsFile, []byte(mappings), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   writeMappings(`{"proprties": {"field1": {"type": "text"}}}`),
+				Config:      testAccResourceIndexMappingsFile(indexName, mappingsFile),
+				ExpectError: regexp.MustCompile(`unknown parameter "proprties" of the mappings`),
+			},
+			{
+				PreConfig: writeMappings(`{"properties": {"field1": {"type": "text"}}}`),
+				Config:    testAccResourceIndexMappingsFile(indexName, mappingsFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "name", indexName),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_index.test", "mappings_file_sha256"),
+				),
+			},
+			{
+				PreConfig: writeMappings(`{"properties": {"field1": {"type": "text"}, "field2": {"type": "keyword"}}}`),
+				Config:    testAccResourceIndexMappingsFile(indexName, mappingsFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "name", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "mappings", `{"properties":{"field1":{"type":"text"},"field2":{"type":"keyword"}}}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexMappingsFile(name, mappingsFile string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name          = "%s"
+  mappings_file = "%s"
+}
+	`, name, mappingsFile)
+}
+
 func checkResourceIndexDestroy(s *terraform.State) error {
 	client := acctest.Provider.Meta().(*clients.ApiClient)
 
@@ -0,0 +1,181 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceIndices() *schema.Resource {
+	indicesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"pattern": {
+			Description: "Name of the indices to list, wildcards (`*`) and comma-separated lists are supported, e.g. `logs-*`.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"include_hidden": {
+			Description: "Also list the hidden indices, e.g. the backing indices of the data streams.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"health": {
+			Description:  "Only list the indices with this health, one of `green`, `yellow` or `red`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"green", "yellow", "red"}, false),
+		},
+		"indices": {
+			Description: "The indices matching the pattern, sorted by their names.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"uuid": {
+						Description: "UUID of the index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"health": {
+						Description: "Health of the index, empty for the closed index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"status": {
+						Description: "Status of the index, `open` or `close`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"number_of_shards": {
+						Description: "The number of the primary shards.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"number_of_replicas": {
+						Description: "The number of the replicas of each primary shard.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"docs_count": {
+						Description: "The number of the documents in the index, 0 for the closed index.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"store_size": {
+						Description: "The size of all the shards of the index in bytes, 0 for the closed index.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"primary_store_size": {
+						Description: "The size of the primary shards of the index in bytes, 0 for the closed index.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"names": {
+			Description: "Names of the indices matching the pattern, e.g. to use them in `for_each`.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(indicesSchema)
+
+	return &schema.Resource{
+		Description: "Lists the indices matching the pattern with their health, status and statistics. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/cat-indices.html",
+
+		ReadContext: dataSourceIndicesRead,
+
+		Schema: indicesSchema,
+	}
+}
+
+func dataSourceIndicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pattern := d.Get("pattern").(string)
+	id, diags := client.ID(ctx, pattern)
+	if diags.HasError() {
+		return diags
+	}
+
+	indicesInfo, diags := client.GetElasticsearchIndicesInfo(ctx, pattern, d.Get("include_hidden").(bool), d.Get("health").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	indices := make([]interface{}, len(indicesInfo))
+	names := make([]string, len(indicesInfo))
+	for i, info := range indicesInfo {
+		index, err := flattenIndexInfo(info)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		indices[i] = index
+		names[i] = info.Index
+	}
+
+	if err := d.Set("indices", indices); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+func flattenIndexInfo(info models.IndexInfo) (map[string]interface{}, error) {
+	index := map[string]interface{}{
+		"name":   info.Index,
+		"uuid":   info.Uuid,
+		"health": info.Health,
+		"status": info.Status,
+	}
+	numbers := map[string]string{
+		"number_of_shards":   info.Primaries,
+		"number_of_replicas": info.Replicas,
+		"docs_count":         info.DocsCount,
+		"store_size":         info.StoreSize,
+		"primary_store_size": info.PrimaryStoreSize,
+	}
+	for k, v := range numbers {
+		index[k] = 0
+		// the statistics of the closed indices are not known
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(`unable to parse %s "%s" of the index %s: %w`, k, v, info.Index, err)
+		}
+		index[k] = int(n)
+	}
+	return index, nil
+}
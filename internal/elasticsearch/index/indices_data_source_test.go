@@ -0,0 +1,59 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIndices(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndices(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "names.0", name+"-1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "names.1", name+"-2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.status", "open"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.number_of_shards", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.docs_count", "0"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_indices.test", "indices.0.uuid"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIndices(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  for_each = toset(["1", "2"])
+  name     = "%s-${each.key}"
+
+  settings {
+    setting {
+      name  = "index.number_of_shards"
+      value = "1"
+    }
+  }
+}
+
+data "elasticstack_elasticsearch_indices" "test" {
+  pattern = "%s-*"
+
+  depends_on = [elasticstack_elasticsearch_index.test]
+}
+	`, name, name)
+}
@@ -0,0 +1,243 @@
+package index
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The attribute of the resources keeping the hash of the mappings file, or the hash of the mappings found in the cluster
+// when they differ from the file, so the change of either is planned
+const mappingsFileHashAttr = "mappings_file_sha256"
+
+// the parameters of the root object of the mappings besides the metadata fields, i.e. the `_` prefixed ones
+var rootMappingParams = map[string]bool{
+	"properties":           true,
+	"dynamic":              true,
+	"dynamic_templates":    true,
+	"dynamic_date_formats": true,
+	"date_detection":       true,
+	"numeric_detection":    true,
+	"runtime":              true,
+	"enabled":              true,
+	"subobjects":           true,
+}
+
+// the parameters of the object and nested fields
+var objectMappingParams = map[string]bool{
+	"type":              true,
+	"properties":        true,
+	"dynamic":           true,
+	"enabled":           true,
+	"subobjects":        true,
+	"include_in_parent": true,
+	"include_in_root":   true,
+}
+
+func mappingsFileHashSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The SHA-256 hash of the content of the mappings file, which differs once the mappings in the cluster differ from the file.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+// Reads the mappings from the file, and checks their structure
+func readMappingsFile(path string) (map[string]interface{}, string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read the mappings file: %w", err)
+	}
+	mappings := make(map[string]interface{})
+	if err := json.Unmarshal(content, &mappings); err != nil {
+		return nil, "", fmt.Errorf(`the mappings file "%s" is not a valid JSON object: %w`, path, err)
+	}
+	if err := validateMappings(mappings); err != nil {
+		return nil, "", fmt.Errorf(`invalid mappings in "%s": %w`, path, err)
+	}
+	return mappings, mappingsHash(content), nil
+}
+
+func mappingsHash(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// Returns the hash of the mappings file when the mappings found in the cluster are the ones of the file, otherwise the hash of the found mappings
+func flattenMappingsFileHash(path string, found map[string]interface{}) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	if found == nil {
+		found = make(map[string]interface{})
+	}
+	foundJson, err := json.Marshal(found)
+	if err != nil {
+		return "", err
+	}
+	mappings, hash, err := readMappingsFile(path)
+	if err != nil {
+		return "", err
+	}
+	if utils.MapsEqual(normalizeMappings(mappings), normalizeMappings(found)) {
+		return hash, nil
+	}
+	return mappingsHash(foundJson), nil
+}
+
+// Round-trips the mappings through JSON, so the values read from the file compare equal to the ones decoded from the API
+func normalizeMappings(mappings map[string]interface{}) interface{} {
+	var normalized interface{}
+	b, _ := json.Marshal(mappings)
+	_ = json.Unmarshal(b, &normalized)
+	return normalized
+}
+
+// Plans the change of the mappings file of the attribute, i.e. the change of its content or the drift of the mappings in the cluster
+func planMappingsFile(fileAttr string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		path, _ := d.Get(fileAttr).(string)
+		if path == "" {
+			if d.Get(mappingsFileHashAttr).(string) != "" {
+				return d.SetNew(mappingsFileHashAttr, "")
+			}
+			return nil
+		}
+		_, hash, err := readMappingsFile(path)
+		if err != nil {
+			return err
+		}
+		if d.Get(mappingsFileHashAttr).(string) != hash {
+			return d.SetNew(mappingsFileHashAttr, hash)
+		}
+		return nil
+	}
+}
+
+// The source of the planned or applied changes, i.e. *schema.ResourceDiff or *schema.ResourceData
+type mappingsChangeSource interface {
+	Get(string) interface{}
+	GetChange(string) (interface{}, interface{})
+	HasChange(string) bool
+}
+
+// Returns the current and the desired mappings of the index, and whether they change. The desired mappings are read
+// from the mappings file when it's set, while the mappings attribute keeps the ones found in the cluster.
+func indexMappingsChange(d mappingsChangeSource) (interface{}, interface{}, bool, error) {
+	path := d.Get("mappings_file").(string)
+	if path == "" {
+		oldMappings, newMappings := d.GetChange("mappings")
+		return oldMappings, newMappings, d.HasChange("mappings"), nil
+	}
+	current := d.Get("mappings")
+	if !d.HasChange(mappingsFileHashAttr) && !d.HasChange("mappings_file") {
+		return current, current, false, nil
+	}
+	mappings, _, err := readMappingsFile(path)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	desired, err := json.Marshal(mappings)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return current, string(desired), true, nil
+}
+
+// Suppresses the diff of the inline mappings while the mappings are read from the file
+func suppressMappingsFromFile(fileAttr string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if path, _ := d.Get(fileAttr).(string); path != "" {
+			return true
+		}
+		return utils.DiffJsonSuppress(k, old, new, d)
+	}
+}
+
+// Checks the structure of the inline mappings at plan time
+func validateMappingsJson(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	mappings := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(value), &mappings); err != nil {
+		return nil, []error{fmt.Errorf("%s: the mappings are not a valid JSON object: %w", k, err)}
+	}
+	if err := validateMappings(mappings); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// Checks the parameters of the root object and of the object fields, which Elasticsearch rejects when they are unknown,
+// e.g. the misspelled `properties`. The parameters of the other field types depend on the type and the plugins, so they are not checked.
+func validateMappings(mappings map[string]interface{}) error {
+	// the mappings may be wrapped into the `_doc` type
+	if doc, ok := mappings["_doc"].(map[string]interface{}); ok && len(mappings) == 1 {
+		mappings = doc
+	}
+	for param := range mappings {
+		if !rootMappingParams[param] && !strings.HasPrefix(param, "_") {
+			return fmt.Errorf(`unknown parameter "%s" of the mappings, expected one of: %s or the metadata fields, e.g. _source`, param, sortedParams(rootMappingParams))
+		}
+	}
+	return validateMappingProperties("", mappings["properties"])
+}
+
+func validateMappingProperties(parent string, properties interface{}) error {
+	if properties == nil {
+		return nil
+	}
+	fields, ok := properties.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf(`the properties of "%s" must be an object`, parent)
+	}
+	for name, f := range fields {
+		path := name
+		if parent != "" {
+			path = parent + "." + name
+		}
+		field, ok := f.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf(`the mapping of the field "%s" must be an object`, path)
+		}
+		fieldType, hasType := field["type"]
+		if hasType {
+			if _, ok := fieldType.(string); !ok {
+				return fmt.Errorf(`the type of the field "%s" must be a string`, path)
+			}
+		}
+		if !hasType || fieldType == "object" || fieldType == "nested" {
+			for param := range field {
+				if !objectMappingParams[param] {
+					return fmt.Errorf(`unknown parameter "%s" of the object field "%s", expected one of: %s, or a "type" for the other fields`, param, path, sortedParams(objectMappingParams))
+				}
+			}
+		}
+		if err := validateMappingProperties(path, field["properties"]); err != nil {
+			return err
+		}
+		if err := validateMappingProperties(path, field["fields"]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedParams(params map[string]bool) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,56 @@
+package index
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings string
+		err      string
+	}{
+		{
+			name:     "valid",
+			mappings: `{"dynamic": "strict", "_source": {"enabled": false}, "properties": {"user": {"properties": {"name": {"type": "text", "fields": {"raw": {"type": "keyword"}}}}}}}`,
+		},
+		{
+			name:     "wrapped into the type",
+			mappings: `{"_doc": {"properties": {"field1": {"type": "text"}}}}`,
+		},
+		{
+			name:     "misspelled properties",
+			mappings: `{"proprties": {"field1": {"type": "text"}}}`,
+			err:      `unknown parameter "proprties" of the mappings`,
+		},
+		{
+			name:     "misspelled type",
+			mappings: `{"properties": {"user": {"typ": "keyword"}}}`,
+			err:      `unknown parameter "typ" of the object field "user"`,
+		},
+		{
+			name:     "invalid nested field",
+			mappings: `{"properties": {"user": {"type": "nested", "properties": {"name": "text"}}}}`,
+			err:      `the mapping of the field "user.name" must be an object`,
+		},
+		{
+			name:     "invalid multi-field",
+			mappings: `{"properties": {"name": {"type": "text", "fields": {"raw": {"index": false}}}}}`,
+			err:      `unknown parameter "index" of the object field "name.raw"`,
+		},
+	}
+
+	for _, tc := range tests {
+		_, errs := validateMappingsJson(tc.mappings, "mappings")
+		if tc.err == "" {
+			if len(errs) > 0 {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[0])
+			}
+			continue
+		}
+		if len(errs) == 0 || !strings.Contains(errs[0].Error(), tc.err) {
+			t.Errorf("%s: expected the error %q, got %v", tc.name, tc.err, errs)
+		}
+	}
+}
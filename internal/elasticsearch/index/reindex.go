@@ -0,0 +1,435 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// how long to wait for the completion of the reindex task in a single request
+	reindexWaitInterval = 30 * time.Second
+	// how long the reindex may run by default, longer than the default `timeout` of the reindex
+	reindexCreateTimeout = 2 * time.Hour
+)
+
+func ResourceReindex() *schema.Resource {
+	reindexSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"source": {
+			Description: "The source of the documents.",
+			Type:        schema.TypeList,
+			Required:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Description: "The indices, data streams or aliases to copy the documents from.",
+						Type:        schema.TypeList,
+						Required:    true,
+						ForceNew:    true,
+						MinItems:    1,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"query": {
+						Description:      "The query the documents must match to be copied. All the documents are copied by default.",
+						Type:             schema.TypeString,
+						Optional:         true,
+						ForceNew:         true,
+						ValidateFunc:     validation.StringIsJSON,
+						DiffSuppressFunc: utils.DiffJsonSuppress,
+					},
+				},
+			},
+		},
+		"dest": {
+			Description: "The destination of the documents.",
+			Type:        schema.TypeList,
+			Required:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Description: "The index, data stream or alias to copy the documents to.",
+						Type:        schema.TypeString,
+						Required:    true,
+						ForceNew:    true,
+					},
+					"op_type": {
+						Description:  "Set to `create` to only copy the missing documents, it's required for the data streams.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringInSlice([]string{"index", "create"}, false),
+					},
+					"pipeline": {
+						Description: "The ingest pipeline to process the documents with.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						ForceNew:    true,
+					},
+				},
+			},
+		},
+		"script": {
+			Description: "The script to modify the documents with while they are copied.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"source": {
+						Description: "The source of the script, e.g. `ctx._source.remove('obsolete')`.",
+						Type:        schema.TypeString,
+						Required:    true,
+						ForceNew:    true,
+					},
+					"lang": {
+						Description: "The language of the script.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						ForceNew:    true,
+						Default:     "painless",
+					},
+					"params": {
+						Description:  "The parameters of the script as JSON.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsJSON,
+					},
+				},
+			},
+		},
+		"conflicts": {
+			Description:  "Whether to `abort` the reindex on the version conflicts, or to `proceed` with the other documents.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "abort",
+			ValidateFunc: validation.StringInSlice([]string{"abort", "proceed"}, false),
+		},
+		"max_docs": {
+			Description:  "The maximum number of the documents to copy.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"slices": {
+			Description:  "The number of the slices to split the reindex into, or `auto`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "1",
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(auto|[1-9][0-9]*)$`), "must be a positive number or auto"),
+		},
+		"refresh": {
+			Description: "Refresh the destination once the reindex is completed.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+		},
+		"wait_for_completion": {
+			Description: "Wait for the completion of the reindex, and fail when any document could not be copied. Otherwise the reindex continues in the background and its counts are updated on every refresh.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     true,
+		},
+		"timeout": {
+			Description:  "How long to wait for the completion of the reindex, e.g. `2h`. The reindex continues in the background once the timeout is reached. It must be shorter than the `create` timeout of the resource.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "1h",
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"triggers": {
+			Description: "Arbitrary map of values that, when changed, will run the reindex again.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"task_id": {
+			Description: "The ID of the reindex task.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"completed": {
+			Description: "Whether the reindex is completed.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"total": {
+			Description: "The number of the documents to copy.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"created": {
+			Description: "The number of the created documents.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"updated": {
+			Description: "The number of the updated documents.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"deleted": {
+			Description: "The number of the deleted documents.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"version_conflicts": {
+			Description: "The number of the version conflicts.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"failures": {
+			Description: "The failures of the reindex as JSON, one per failed document or request.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(reindexSchema)
+
+	return &schema.Resource{
+		Description: "Copies the documents from the source to the destination once, e.g. to migrate the data to the index with the new mappings. Destroying the resource does not change the documents. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-reindex.html",
+
+		CreateContext: resourceReindexCreate,
+		// the reindex cannot be changed, only the connection and the timeout can
+		UpdateContext: resourceReindexRead,
+		ReadContext:   resourceReindexRead,
+		DeleteContext: resourceReindexDelete,
+
+		Timeouts: utils.ResourceTimeouts(reindexCreateTimeout, utils.DefaultResourceTimeout),
+
+		Schema: reindexSchema,
+	}
+}
+
+func resourceReindexCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	reindex, diags := expandReindex(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	taskId, diags := client.ReindexElasticsearch(ctx, reindex, d.Get("slices").(string), d.Get("refresh").(bool))
+	if diags.HasError() {
+		return diags
+	}
+	id, diags := client.ID(ctx, taskId)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(id.String())
+
+	if !d.Get("wait_for_completion").(bool) {
+		return resourceReindexRead(ctx, d, meta)
+	}
+
+	timeout, err := utils.ParseElasticDuration(d.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	task, diags := waitForTask(ctx, client, taskId, timeout)
+	if diags.HasError() {
+		return diags
+	}
+	status, diags := reindexTaskStatus(task)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := setReindexStatus(d, taskId, task, status); diags.HasError() {
+		return diags
+	}
+
+	// the resource is kept in the state, so it's tainted and the reindex is run again on the next apply
+	if !task.Completed {
+		return diag.Errorf(`Timed out waiting for the reindex task "%s" after %s`, taskId, timeout)
+	}
+	if task.Error != nil || len(status.Failures) > 0 {
+		detail, _ := json.Marshal(task.Error)
+		if task.Error == nil {
+			detail, _ = json.Marshal(status.Failures)
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`The reindex into "%s" failed`, reindex.Dest.Index),
+			Detail:   string(detail),
+		}}
+	}
+	return diags
+}
+
+func resourceReindexRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	task, diags := client.GetElasticsearchTask(ctx, compId.ResourceId, 0)
+	if diags.HasError() {
+		return diags
+	}
+	// the result of the completed task was removed, the state keeps the last known counts
+	if task == nil {
+		return diags
+	}
+	status, diags := reindexTaskStatus(task)
+	if diags.HasError() {
+		return diags
+	}
+	return setReindexStatus(d, compId.ResourceId, task, status)
+}
+
+func resourceReindexDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
+
+// Waits for the completion of the task up to the timeout, the task is returned in its last state
+func waitForTask(ctx context.Context, client *clients.ApiClient, taskId string, timeout time.Duration) (*models.Task, diag.Diagnostics) {
+	deadline := time.Now().Add(timeout)
+	for {
+		wait := time.Until(deadline)
+		if wait > reindexWaitInterval {
+			wait = reindexWaitInterval
+		}
+		task, diags := client.GetElasticsearchTask(ctx, taskId, wait)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if task == nil {
+			return nil, diag.Errorf(`Unable to find the task "%s"`, taskId)
+		}
+		if task.Completed || !time.Now().Before(deadline) {
+			// the task must be read again if the wait timed out, to get its progress
+			if !task.Completed {
+				return client.GetElasticsearchTask(ctx, taskId, 0)
+			}
+			return task, diags
+		}
+		tflog.Trace(ctx, fmt.Sprintf("waiting for the completion of the task '%s'", taskId))
+	}
+}
+
+// The status of the reindex is in the response of the completed task, and in the task itself while it's running
+func reindexTaskStatus(task *models.Task) (*models.ReindexStatus, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var status models.ReindexStatus
+	raw := task.Task.Status
+	if len(task.Response) > 0 {
+		raw = task.Response
+	}
+	if len(raw) == 0 {
+		return &status, diags
+	}
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &status, diags
+}
+
+func setReindexStatus(d *schema.ResourceData, taskId string, task *models.Task, status *models.ReindexStatus) diag.Diagnostics {
+	var diags diag.Diagnostics
+	failures := make([]string, len(status.Failures))
+	for i, f := range status.Failures {
+		failure, err := json.Marshal(f)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		failures[i] = string(failure)
+	}
+
+	for attr, value := range map[string]interface{}{
+		"task_id":           taskId,
+		"completed":         task.Completed,
+		"total":             status.Total,
+		"created":           status.Created,
+		"updated":           status.Updated,
+		"deleted":           status.Deleted,
+		"version_conflicts": status.VersionConflicts,
+		"failures":          failures,
+	} {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diags
+}
+
+func expandReindex(d *schema.ResourceData) (*models.Reindex, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	reindex := models.Reindex{
+		Conflicts: d.Get("conflicts").(string),
+		MaxDocs:   d.Get("max_docs").(int),
+	}
+
+	source := d.Get("source").([]interface{})[0].(map[string]interface{})
+	for _, index := range source["index"].([]interface{}) {
+		reindex.Source.Index = append(reindex.Source.Index, index.(string))
+	}
+	if query := source["query"].(string); query != "" {
+		if err := json.Unmarshal([]byte(query), &reindex.Source.Query); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+
+	dest := d.Get("dest").([]interface{})[0].(map[string]interface{})
+	reindex.Dest = models.ReindexDest{
+		Index:    dest["index"].(string),
+		OpType:   dest["op_type"].(string),
+		Pipeline: dest["pipeline"].(string),
+	}
+
+	if v, ok := d.GetOk("script"); ok {
+		script := v.([]interface{})[0].(map[string]interface{})
+		reindex.Script = &models.ReindexScript{
+			Source: script["source"].(string),
+			Lang:   script["lang"].(string),
+		}
+		if params := script["params"].(string); params != "" {
+			if err := json.Unmarshal([]byte(params), &reindex.Script.Params); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+	}
+	return &reindex, diags
+}
@@ -0,0 +1,64 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceReindex(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceReindex(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_reindex.test", "completed", "true"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_reindex.test", "total", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_reindex.test", "failures.#", "0"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_reindex.test", "task_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceReindex(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "source" {
+  name = "%s-source"
+}
+
+resource "elasticstack_elasticsearch_index" "dest" {
+  name = "%s-dest"
+}
+
+resource "elasticstack_elasticsearch_reindex" "test" {
+  source {
+    index = [elasticstack_elasticsearch_index.source.name]
+  }
+
+  dest {
+    index = elasticstack_elasticsearch_index.dest.name
+  }
+
+  script {
+    source = "ctx._source.migrated = params.migrated"
+    params = jsonencode({ migrated = true })
+  }
+
+  refresh = true
+}
+	`, name, name)
+}
@@ -0,0 +1,332 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	retentionSourceIlm       = "ilm"
+	retentionSourceLifecycle = "data_stream_lifecycle"
+
+	violationReasonExpired      = "expired"
+	violationReasonMaxRetention = "max_retention_exceeded"
+)
+
+func DataSourceRetentionCompliance() *schema.Resource {
+	violationSchema := map[string]*schema.Schema{
+		"index": {
+			Description: "The name of the index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"data_stream": {
+			Description: "The data stream the index is the backing index of, if any.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"source": {
+			Description: "Where the retention is declared: `ilm` for the delete phase of the ILM policy, or `data_stream_lifecycle` for the data retention of the data stream lifecycle.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"policy": {
+			Description: "The name of the ILM policy managing the index, if the retention is declared by ILM.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"retention": {
+			Description: "The declared retention, e.g. `30d`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"age_days": {
+			Description: "The age of the index in days, counted from the date the retention applies from.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"reason": {
+			Description: "Why the index is reported: `expired` if it's older than the retention, or `max_retention_exceeded` if the retention is longer than `max_retention`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	complianceSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Comma-separated list of the indices or data streams to check. Supports wildcards (`*`).",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "*",
+		},
+		"include_hidden": {
+			Description: "Whether to check the hidden indices too, e.g. the backing indices of the data streams.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		"grace_period": {
+			Description:  "How long the index may outlive its retention before it's reported, to allow for the ILM and data stream lifecycle poll intervals, e.g. `1d`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "1d",
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"max_retention": {
+			Description:  "The maximum retention allowed, e.g. `365d`. The indices declaring a longer retention are reported as the violations too.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"fail_on_violations": {
+			Description: "Fail the read of the data source if any violation is found, e.g. to fail the scheduled plans.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"violations": {
+			Description: "The indices exceeding the declared retention, or declaring a retention longer than `max_retention`.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: violationSchema,
+			},
+		},
+		"no_retention": {
+			Description: "The indices without any declared retention: neither managed by an ILM policy with the delete phase, nor backing a data stream with the data retention.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"compliant": {
+			Description: "Whether no violation is found.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(complianceSchema)
+
+	return &schema.Resource{
+		Description: "Reports the indices which exceed the retention declared by their ILM policies or data stream lifecycles. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-explain-lifecycle.html",
+
+		ReadContext: dataSourceRetentionComplianceRead,
+
+		Schema: complianceSchema,
+	}
+}
+
+type retentionViolation struct {
+	Index      string
+	DataStream string
+	Source     string
+	Policy     string
+	Retention  string
+	AgeDays    int
+	Reason     string
+}
+
+func dataSourceRetentionComplianceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pattern := d.Get("index").(string)
+	id, diags := client.ID(ctx, pattern)
+	if diags.HasError() {
+		return diags
+	}
+
+	gracePeriod, err := utils.ParseElasticDuration(d.Get("grace_period").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var maxRetention time.Duration
+	if v, ok := d.GetOk("max_retention"); ok {
+		if maxRetention, err = utils.ParseElasticDuration(v.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	includeHidden := d.Get("include_hidden").(bool)
+
+	settings, diags := client.GetElasticsearchIndicesSettings(ctx, pattern, includeHidden)
+	if diags.HasError() {
+		return diags
+	}
+	explain, diags := client.ExplainElasticsearchIlm(ctx, pattern)
+	if diags.HasError() {
+		return diags
+	}
+	policies, diags := client.GetElasticsearchIlms(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	dataStreams, diags := client.GetElasticsearchDataStreams(ctx, "*", true)
+	if diags.HasError() {
+		return diags
+	}
+
+	violations, noRetention, diags := checkRetention(time.Now(), settings, explain, policies, dataStreams, gracePeriod, maxRetention)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Trace(ctx, fmt.Sprintf("checked the retention of %d indices matching '%s', found %d violations", len(settings), pattern, len(violations)))
+
+	flatViolations := make([]interface{}, len(violations))
+	for i, v := range violations {
+		flatViolations[i] = map[string]interface{}{
+			"index":       v.Index,
+			"data_stream": v.DataStream,
+			"source":      v.Source,
+			"policy":      v.Policy,
+			"retention":   v.Retention,
+			"age_days":    v.AgeDays,
+			"reason":      v.Reason,
+		}
+	}
+	if err := d.Set("violations", flatViolations); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("no_retention", noRetention); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("compliant", len(violations) == 0); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(violations) > 0 && d.Get("fail_on_violations").(bool) {
+		for _, v := range violations {
+			diags = append(diags, violationDiagnostic(v, d.Get("max_retention").(string)))
+		}
+		return diags
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// Compares the age of every index against the retention declared for it, the ILM delete phase takes precedence over the data stream lifecycle,
+// the same way Elasticsearch applies them. Returns the violations and the names of the indices without any retention, both sorted by the index name.
+func checkRetention(now time.Time, settings map[string]map[string]interface{}, explain map[string]models.IlmExplain, policies map[string]models.PolicyDefinition, dataStreams []models.DataStream, gracePeriod, maxRetention time.Duration) ([]retentionViolation, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	backingIndices := make(map[string]models.DataStream)
+	for _, ds := range dataStreams {
+		for _, i := range ds.Indices {
+			backingIndices[i.IndexName] = ds
+		}
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	violations := make([]retentionViolation, 0)
+	noRetention := make([]string, 0)
+	for _, name := range names {
+		v := retentionViolation{Index: name}
+		ds, isBacking := backingIndices[name]
+		if isBacking {
+			v.DataStream = ds.Name
+		}
+
+		creationDate, err := indexCreationDate(settings[name])
+		if err != nil {
+			return nil, nil, diag.FromErr(err)
+		}
+		since := creationDate
+
+		if e, ok := explain[name]; ok && e.Managed && hasDeletePhase(policies[e.Policy]) {
+			v.Source = retentionSourceIlm
+			v.Policy = e.Policy
+			v.Retention = policies[e.Policy].Policy.Phases["delete"].MinAge
+			if v.Retention == "" {
+				v.Retention = "0ms"
+			}
+			// the min_age is counted from the rollover, if the index was rolled over
+			if e.LifecycleDateMillis > 0 {
+				since = time.UnixMilli(e.LifecycleDateMillis)
+			}
+		} else if isBacking && ds.Lifecycle != nil && ds.Lifecycle.DataRetention != "" && (ds.Lifecycle.Enabled == nil || *ds.Lifecycle.Enabled) {
+			// the write index is never deleted by the data stream lifecycle
+			if ds.Indices[len(ds.Indices)-1].IndexName == name {
+				continue
+			}
+			v.Source = retentionSourceLifecycle
+			v.Retention = ds.Lifecycle.DataRetention
+		} else {
+			noRetention = append(noRetention, name)
+			continue
+		}
+
+		retention, err := utils.ParseElasticDuration(v.Retention)
+		if err != nil {
+			return nil, nil, diag.FromErr(err)
+		}
+		age := now.Sub(since)
+		v.AgeDays = int(age.Hours() / 24)
+		if age > retention+gracePeriod {
+			v.Reason = violationReasonExpired
+			violations = append(violations, v)
+		} else if maxRetention > 0 && retention > maxRetention {
+			v.Reason = violationReasonMaxRetention
+			violations = append(violations, v)
+		}
+	}
+	return violations, noRetention, diags
+}
+
+func hasDeletePhase(policy models.PolicyDefinition) bool {
+	_, ok := policy.Policy.Phases["delete"]
+	return ok
+}
+
+func indexCreationDate(settings map[string]interface{}) (time.Time, error) {
+	v, ok := settings["index.creation_date"].(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("the index settings do not contain the creation date")
+	}
+	millis, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(`invalid index creation date: "%s"`, v)
+	}
+	return time.UnixMilli(millis), nil
+}
+
+func violationDiagnostic(v retentionViolation, maxRetention string) diag.Diagnostic {
+	source := fmt.Sprintf(`the lifecycle of the data stream "%s"`, v.DataStream)
+	if v.Source == retentionSourceIlm {
+		source = fmt.Sprintf(`the ILM policy "%s"`, v.Policy)
+	}
+	if v.Reason == violationReasonMaxRetention {
+		return diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`The retention of the index "%s" is too long`, v.Index),
+			Detail:   fmt.Sprintf("The retention declared by %s is %s, while the maximum retention allowed is %s.", source, v.Retention, maxRetention),
+		}
+	}
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf(`The index "%s" exceeds the retention`, v.Index),
+		Detail:   fmt.Sprintf("The index is %d days old, while the retention declared by %s is %s.", v.AgeDays, source, v.Retention),
+	}
+}
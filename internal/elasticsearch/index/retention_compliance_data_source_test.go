@@ -0,0 +1,89 @@
+package index_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRetentionCompliance(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRetentionCompliance(name, "30d", "365d", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "compliant", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "violations.#", "0"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "no_retention.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "no_retention.0", fmt.Sprintf("%s-unmanaged", name)),
+				),
+			},
+			{
+				Config: testAccDataSourceRetentionCompliance(name, "400d", "365d", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "compliant", "false"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "violations.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "violations.0.index", fmt.Sprintf("%s-managed", name)),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "violations.0.source", "ilm"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "violations.0.policy", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "violations.0.retention", "400d"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_retention_compliance.test", "violations.0.reason", "max_retention_exceeded"),
+				),
+			},
+			{
+				Config:      testAccDataSourceRetentionCompliance(name, "400d", "365d", true),
+				ExpectError: regexp.MustCompile("The retention of the index .* is too long"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceRetentionCompliance(name, retention, maxRetention string, failOnViolations bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%[1]s"
+
+  delete {
+    min_age = "%[2]s"
+    delete {}
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "managed" {
+  name = "%[1]s-managed"
+  settings {
+    setting {
+      name  = "index.lifecycle.name"
+      value = elasticstack_elasticsearch_index_lifecycle.test.name
+    }
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "unmanaged" {
+  name = "%[1]s-unmanaged"
+}
+
+data "elasticstack_elasticsearch_retention_compliance" "test" {
+  index              = "%[1]s-*"
+  max_retention      = "%[3]s"
+  fail_on_violations = %[4]t
+
+  depends_on = [
+    elasticstack_elasticsearch_index.managed,
+    elasticstack_elasticsearch_index.unmanaged,
+  ]
+}
+	`, name, retention, maxRetention, failOnViolations)
+}
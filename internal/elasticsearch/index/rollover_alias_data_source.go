@@ -0,0 +1,196 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRolloverAlias() *schema.Resource {
+	aliasSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"alias": {
+			Description: "Name of the rollover alias to check.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"fail_on_issues": {
+			Description: "Fail the read of the data source if the alias is misconfigured, instead of only reporting the issues.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"indices": {
+			Description: "Names of the indices the alias points to.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"write_index": {
+			Description: "Name of the write index of the alias, either set explicitly with `is_write_index = true` or implicitly when the alias points to a single index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ilm_policy": {
+			Description: "Name of the ILM policy (`index.lifecycle.name`) of the write index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"valid": {
+			Description: "Whether the alias can be rolled over by ILM.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"issues": {
+			Description: "List of the found misconfigurations, which would make the ILM rollover step fail.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(aliasSchema)
+
+	return &schema.Resource{
+		Description: "Checks that the alias can be rolled over by the index lifecycle management: exactly one index the alias points to is the write index, and the write index is managed by an ILM policy with the rollover action and the matching `index.lifecycle.rollover_alias` setting. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-rollover.html",
+
+		ReadContext: dataSourceRolloverAliasRead,
+
+		Schema: aliasSchema,
+	}
+}
+
+func dataSourceRolloverAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	aliasName := d.Get("alias").(string)
+	id, diags := client.ID(ctx, aliasName)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliases, diags := client.GetElasticsearchAlias(ctx, aliasName)
+	if diags.HasError() {
+		return diags
+	}
+	if aliases == nil {
+		return diag.Errorf(`Unable to find the alias "%s" in the cluster`, aliasName)
+	}
+
+	indices := make([]string, 0, len(aliases))
+	for indexName := range aliases {
+		indices = append(indices, indexName)
+	}
+	sort.Strings(indices)
+
+	issues := make([]string, 0)
+	writeIndex := rolloverWriteIndex(aliasName, indices, aliases, &issues)
+
+	policyName := ""
+	if writeIndex != "" {
+		index, diags := client.GetElasticsearchIndex(ctx, writeIndex)
+		if diags.HasError() {
+			return diags
+		}
+		var policy *models.PolicyDefinition
+		if index != nil {
+			policyName, _ = index.Settings["index.lifecycle.name"].(string)
+		}
+		if policyName != "" {
+			policy, diags = client.GetElasticsearchIlm(ctx, policyName)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		checkRolloverPolicy(aliasName, writeIndex, index, policyName, policy, &issues)
+	}
+
+	if d.Get("fail_on_issues").(bool) && len(issues) > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`The alias "%s" cannot be rolled over by ILM`, aliasName),
+			Detail:   strings.Join(issues, "\n"),
+		}}
+	}
+
+	if err := d.Set("indices", indices); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("write_index", writeIndex); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ilm_policy", policyName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("valid", len(issues) == 0); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("issues", issues); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// Finds the write index of the alias, the only index the alias points to is implicitly the write index.
+func rolloverWriteIndex(aliasName string, indices []string, aliases map[string]models.IndexAlias, issues *[]string) string {
+	writeIndices := make([]string, 0)
+	for _, indexName := range indices {
+		if aliases[indexName].IsWriteIndex {
+			writeIndices = append(writeIndices, indexName)
+		}
+	}
+
+	switch {
+	case len(writeIndices) == 1:
+		return writeIndices[0]
+	case len(writeIndices) > 1:
+		*issues = append(*issues, fmt.Sprintf(`more than one index is marked as the write index of the alias "%s": %s`, aliasName, strings.Join(writeIndices, ", ")))
+	case len(indices) == 1:
+		return indices[0]
+	default:
+		*issues = append(*issues, fmt.Sprintf(`none of the %d indices the alias "%s" points to is marked with is_write_index = true`, len(indices), aliasName))
+	}
+	return ""
+}
+
+func checkRolloverPolicy(aliasName, writeIndex string, index *models.Index, policyName string, policy *models.PolicyDefinition, issues *[]string) {
+	if policyName == "" {
+		*issues = append(*issues, fmt.Sprintf(`the write index "%s" is not managed by any ILM policy (index.lifecycle.name is not set)`, writeIndex))
+		return
+	}
+
+	if rolloverAlias, _ := index.Settings["index.lifecycle.rollover_alias"].(string); rolloverAlias != aliasName {
+		*issues = append(*issues, fmt.Sprintf(`the write index "%s" has index.lifecycle.rollover_alias set to "%s" instead of "%s"`, writeIndex, rolloverAlias, aliasName))
+	}
+
+	if policy == nil {
+		*issues = append(*issues, fmt.Sprintf(`the ILM policy "%s" of the write index "%s" does not exist`, policyName, writeIndex))
+		return
+	}
+	for _, phase := range policy.Policy.Phases {
+		if _, ok := phase.Actions["rollover"]; ok {
+			return
+		}
+	}
+	*issues = append(*issues, fmt.Sprintf(`the ILM policy "%s" of the write index "%s" has no rollover action`, policyName, writeIndex))
+}
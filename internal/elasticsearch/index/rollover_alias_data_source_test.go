@@ -0,0 +1,83 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRolloverAlias(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRolloverAlias(name, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_rollover_alias.test", "alias", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_rollover_alias.test", "write_index", fmt.Sprintf("%s-000001", name)),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_rollover_alias.test", "ilm_policy", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_rollover_alias.test", "indices.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_rollover_alias.test", "valid", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_rollover_alias.test", "issues.#", "0"),
+				),
+			},
+			{
+				Config: testAccDataSourceRolloverAlias(name, "other"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_rollover_alias.test", "valid", "false"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_rollover_alias.test", "issues.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceRolloverAlias(name, rolloverAlias string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%[1]s"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%[1]s-000001"
+
+  alias {
+    name           = "%[1]s"
+    is_write_index = true
+  }
+
+  settings {
+    setting {
+      name  = "index.lifecycle.name"
+      value = elasticstack_elasticsearch_index_lifecycle.test.name
+    }
+    setting {
+      name  = "index.lifecycle.rollover_alias"
+      value = "%[2]s"
+    }
+  }
+}
+
+data "elasticstack_elasticsearch_index_rollover_alias" "test" {
+  alias = "%[1]s"
+
+  depends_on = [elasticstack_elasticsearch_index.test]
+}
+	`, name, rolloverAlias)
+}
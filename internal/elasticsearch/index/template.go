@@ -3,12 +3,14 @@ package index
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -35,6 +37,20 @@ func ResourceTemplate() *schema.Resource {
 				Type: schema.TypeString,
 			},
 		},
+		"ignore_missing_component_templates": {
+			Description: "The component templates of `composed_of`, which are ignored when they don't exist. Available since Elasticsearch 8.7.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"deprecated": {
+			Description: "Marks the template as deprecated, Elasticsearch then warns when it's used, e.g. by the creation of the indices or by the other templates. Available since Elasticsearch 8.12.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
 		"data_stream": {
 			Description: "If this object is included, the template is used to create data streams and their backing indices. Supports an empty object.",
 			Type:        schema.TypeList,
@@ -77,6 +93,7 @@ func ResourceTemplate() *schema.Resource {
 			ValidateFunc: validation.IntAtLeast(0),
 			Optional:     true,
 		},
+		mappingsFileHashAttr: mappingsFileHashSchema(),
 		"template": {
 			Description: "Template to be applied. It may optionally include an aliases, mappings, or settings configuration.",
 			Type:        schema.TypeList,
@@ -140,8 +157,14 @@ func ResourceTemplate() *schema.Resource {
 						Description:      "Mapping for fields in the index.",
 						Type:             schema.TypeString,
 						Optional:         true,
-						DiffSuppressFunc: utils.DiffJsonSuppress,
-						ValidateFunc:     validation.StringIsJSON,
+						DiffSuppressFunc: suppressMappingsFromFile("template.0.mappings_file"),
+						ValidateFunc:     validation.All(validation.StringIsJSON, validateMappingsJson),
+					},
+					"mappings_file": {
+						Description:   "The path of the JSON file with the mappings, instead of the inline `mappings`. The file is read and its structure is checked during the plan, the changes of its content and the drift of the mappings in the cluster are tracked by `mappings_file_sha256`.",
+						Type:          schema.TypeString,
+						Optional:      true,
+						ConflictsWith: []string{"template.0.mappings"},
 					},
 					"settings": {
 						Description:      "Configuration options for the index. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html#index-modules-settings",
@@ -150,9 +173,45 @@ func ResourceTemplate() *schema.Resource {
 						DiffSuppressFunc: utils.DiffIndexSettingSuppress,
 						ValidateFunc:     validation.StringIsJSON,
 					},
+					"analysis": indexAnalysisSchema(),
+					"lifecycle": {
+						Description: "The data stream lifecycle of the data streams created from the template. Requires the `data_stream` block and Elasticsearch 8.11 or higher. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/data-stream-lifecycle.html",
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"data_retention": {
+									Description:  "How long the data of the data stream is kept, e.g. `30d`. The data is kept forever when it's not set.",
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: utils.StringIsElasticDuration,
+								},
+								"enabled": {
+									Description: "Whether the data stream lifecycle manages the data streams.",
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Default:     true,
+								},
+							},
+						},
+					},
+					"prefer_ilm": {
+						Description: "Sets the `index.lifecycle.prefer_ilm` setting, which chooses whether the ILM policy or the data stream lifecycle governs the backing indices when both of them apply. ILM is preferred when it's not set.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+					},
+					"default_pipeline": indexPipelineSchema()["default_pipeline"],
+					"final_pipeline":   indexPipelineSchema()["final_pipeline"],
 				},
 			},
 		},
+		"rollover_on_change": {
+			Description: "Roll over the data streams created from the template once `composed_of` or the `template` block changes, so the changes apply to their write indices immediately instead of on the next rollover.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
 		"version": {
 			Description: "Version number used to manage index templates externally.",
 			Type:        schema.TypeInt,
@@ -170,10 +229,14 @@ func ResourceTemplate() *schema.Resource {
 		ReadContext:   resourceIndexTemplateRead,
 		DeleteContext: resourceIndexTemplateDelete,
 
+		CustomizeDiff: customdiff.All(planMappingsFile("template.0.mappings_file"), validateTemplateLifecycle, validateTemplateAnalysis, validateTemplatePipelines, validateIgnoreMissingComponentTemplates),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
 		Schema: templateSchema,
 	}
 }
@@ -185,7 +248,7 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 	templateId := d.Get("name").(string)
-	id, diags := client.ID(templateId)
+	id, diags := client.ID(ctx, templateId)
 	if diags.HasError() {
 		return diags
 	}
@@ -200,6 +263,11 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 	}
 	indexTemplate.ComposedOf = compsOf
 
+	for _, c := range d.Get("ignore_missing_component_templates").([]interface{}) {
+		indexTemplate.IgnoreMissingComponentTemplates = append(indexTemplate.IgnoreMissingComponentTemplates, c.(string))
+	}
+	indexTemplate.Deprecated = d.Get("deprecated").(bool)
+
 	if v, ok := d.GetOk("data_stream"); ok {
 		// 8.x workaround
 		hasAllowCustomRouting := false
@@ -265,7 +333,13 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 		}
 		templ.Aliases = aliases
 
-		if mappings, ok := definedTempl["mappings"]; ok {
+		if path, ok := definedTempl["mappings_file"].(string); ok && path != "" {
+			maps, _, err := readMappingsFile(path)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			templ.Mappings = maps
+		} else if mappings, ok := definedTempl["mappings"]; ok {
 			if mappings.(string) != "" {
 				maps := make(map[string]interface{})
 				if err := json.Unmarshal([]byte(mappings.(string)), &maps); err != nil {
@@ -285,20 +359,81 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 			}
 		}
 
+		analysis, err := expandIndexAnalysis(definedTempl["analysis"])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(analysis) > 0 {
+			if templ.Settings == nil {
+				templ.Settings = make(map[string]interface{})
+			}
+			for setting, value := range analysis {
+				templ.Settings[setting] = value
+			}
+		}
+
+		if pipelines := expandIndexPipelines(definedTempl); len(pipelines) > 0 {
+			if templ.Settings == nil {
+				templ.Settings = make(map[string]interface{})
+			}
+			for setting, value := range pipelines {
+				templ.Settings[setting] = value
+			}
+		}
+
+		templ.Lifecycle = expandTemplateLifecycle(definedTempl["lifecycle"])
+
 		indexTemplate.Template = &templ
 	}
 
+	preferIlm := configuredTemplatePreferIlm(d.GetRawConfig())
+	if preferIlm != nil {
+		if indexTemplate.Template.Settings == nil {
+			indexTemplate.Template.Settings = make(map[string]interface{})
+		}
+		indexTemplate.Template.Settings[indexSettingPreferIlm] = *preferIlm
+	}
+
 	if v, ok := d.GetOk("version"); ok {
 		definedVer := v.(int)
 		indexTemplate.Version = &definedVer
 	}
 
-	if diags := client.PutElasticsearchIndexTemplate(&indexTemplate); diags.HasError() {
-		return diags
+	if indexTemplate.Template != nil && indexTemplate.Template.Settings != nil {
+		if diags := checkTemplatePipelines(ctx, client, indexTemplate.Template.Settings); diags.HasError() {
+			return diags
+		}
+	}
+
+	// the overlaps are only reported when they may have changed, not to repeat the warnings on every apply
+	var overlapDiags diag.Diagnostics
+	if d.IsNewResource() || d.HasChanges("index_patterns", "priority") {
+		overlapDiags = checkTemplateOverlap(ctx, client, &indexTemplate)
+		if overlapDiags.HasError() {
+			return overlapDiags
+		}
+	}
+	if d.IsNewResource() || d.HasChanges("composed_of", "data_stream", "template", mappingsFileHashAttr) {
+		lifecycleDiags := checkTemplateLifecycle(ctx, client, &indexTemplate, preferIlm)
+		if lifecycleDiags.HasError() {
+			return lifecycleDiags
+		}
+		overlapDiags = append(overlapDiags, lifecycleDiags...)
+	}
+
+	putDiags := client.PutElasticsearchIndexTemplate(ctx, &indexTemplate)
+	if putDiags.HasError() {
+		return putDiags
+	}
+	overlapDiags = append(overlapDiags, putDiags...)
+	if templateNeedsRollover(d) {
+		if diags := rolloverTemplateDataStreams(ctx, client, templateId); diags.HasError() {
+			return diags
+		}
 	}
 
 	d.SetId(id.String())
-	return resourceIndexTemplateRead(ctx, d, meta)
+	return append(resourceIndexTemplateRead(ctx, d, meta), overlapDiags...)
 }
 
 func resourceIndexTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -313,7 +448,7 @@ func resourceIndexTemplateRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 	templateId := compId.ResourceId
 
-	tpl, diags := client.GetElasticsearchIndexTemplate(templateId)
+	tpl, diags := client.GetElasticsearchIndexTemplate(ctx, templateId)
 	if tpl == nil && diags == nil {
 		d.SetId("")
 		return diags
@@ -329,6 +464,12 @@ func resourceIndexTemplateRead(ctx context.Context, d *schema.ResourceData, meta
 	if err := d.Set("composed_of", tpl.IndexTemplate.ComposedOf); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("ignore_missing_component_templates", tpl.IndexTemplate.IgnoreMissingComponentTemplates); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("deprecated", tpl.IndexTemplate.Deprecated); err != nil {
+		return diag.FromErr(err)
+	}
 	if stream := tpl.IndexTemplate.DataStream; stream != nil {
 		ds := make([]interface{}, 1)
 		dSettings := make(map[string]interface{})
@@ -359,16 +500,60 @@ func resourceIndexTemplateRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	if tpl.IndexTemplate.Template != nil {
-		template, diags := flattenTemplateData(tpl.IndexTemplate.Template)
+	if t := tpl.IndexTemplate.Template; t != nil {
+		// prefer_ilm is kept in the settings when it's configured there, otherwise it's managed by the attribute
+		preferIlm := false
+		if t.Settings != nil && !configuredIndexSetting(d.Get("template.0.settings").(string), indexSettingPreferIlm) {
+			if v, ok := removeIndexSetting(t.Settings, indexSettingPreferIlm); ok {
+				preferIlm = fmt.Sprint(v) == "true"
+			}
+		}
+		// the pipelines are kept in the settings when they are configured there, otherwise they are managed by the attributes
+		pipelines := make(map[string]interface{})
+		for attr, setting := range indexPipelineAttributes {
+			pipelines[attr] = ""
+			if t.Settings != nil && !configuredIndexSetting(d.Get("template.0.settings").(string), setting) {
+				if v, ok := removeIndexSetting(t.Settings, setting); ok {
+					pipelines[attr] = fmt.Sprint(v)
+				}
+			}
+		}
+		// the analysis settings are managed by the analysis block when it's configured
+		analysis := d.Get("template.0.analysis").([]interface{})
+		if t.Settings != nil && len(analysis) > 0 {
+			removeIndexSetting(t.Settings, "index.analysis")
+		}
+		if len(t.Settings) == 0 {
+			t.Settings = nil
+		}
+		template, diags := flattenTemplateData(t)
 		if diags.HasError() {
 			return diags
 		}
+		template[0].(map[string]interface{})["analysis"] = analysis
+		template[0].(map[string]interface{})["mappings_file"] = d.Get("template.0.mappings_file")
+		template[0].(map[string]interface{})["lifecycle"] = flattenTemplateLifecycle(t.Lifecycle)
+		template[0].(map[string]interface{})["prefer_ilm"] = preferIlm
+		for attr, value := range pipelines {
+			template[0].(map[string]interface{})[attr] = value
+		}
 		if err := d.Set("template", template); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
+	var foundMappings map[string]interface{}
+	if tpl.IndexTemplate.Template != nil {
+		foundMappings = tpl.IndexTemplate.Template.Mappings
+	}
+	hash, err := flattenMappingsFileHash(d.Get("template.0.mappings_file").(string), foundMappings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(mappingsFileHashAttr, hash); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("version", tpl.IndexTemplate.Version); err != nil {
 		return diag.FromErr(err)
 	}
@@ -417,9 +602,26 @@ func resourceIndexTemplateDelete(ctx context.Context, d *schema.ResourceData, me
 	if diags.HasError() {
 		return diags
 	}
-	if diags := client.DeleteElasticsearchIndexTemplate(compId.ResourceId); diags.HasError() {
+	if diags := client.DeleteElasticsearchIndexTemplate(ctx, compId.ResourceId); diags.HasError() {
 		return diags
 	}
 	d.SetId("")
 	return diags
 }
+
+// Only the component templates the template is composed of can be ignored when they are missing
+func validateIgnoreMissingComponentTemplates(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("composed_of") || !d.NewValueKnown("ignore_missing_component_templates") {
+		return nil
+	}
+	composedOf := make(map[string]bool)
+	for _, c := range d.Get("composed_of").([]interface{}) {
+		composedOf[c.(string)] = true
+	}
+	for _, c := range d.Get("ignore_missing_component_templates").([]interface{}) {
+		if !composedOf[c.(string)] {
+			return fmt.Errorf(`the ignored component template "%s" is not in composed_of`, c)
+		}
+	}
+	return nil
+}
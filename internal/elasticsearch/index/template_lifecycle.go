@@ -0,0 +1,161 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	indexSettingLifecycleName = "index.lifecycle.name"
+	indexSettingPreferIlm     = "index.lifecycle.prefer_ilm"
+)
+
+// The data stream lifecycle applies only to the data streams, and prefer_ilm can be managed either by the attribute or in the settings
+func validateTemplateLifecycle(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if v, ok := d.GetOk("template.0.lifecycle"); ok && len(v.([]interface{})) > 0 {
+		if ds, ok := d.GetOk("data_stream"); !ok || len(ds.([]interface{})) == 0 {
+			return fmt.Errorf("the lifecycle of the template applies only to the data streams, the data_stream block must be set too")
+		}
+	}
+	if configuredTemplatePreferIlm(d.GetRawConfig()) == nil || !d.NewValueKnown("template.0.settings") {
+		return nil
+	}
+	if configuredIndexSetting(d.Get("template.0.settings").(string), indexSettingPreferIlm) {
+		return fmt.Errorf("%s is set both by prefer_ilm and in the settings of the template, only one of them can be used", indexSettingPreferIlm)
+	}
+	return nil
+}
+
+// Whether the index setting is set in the settings JSON of the configuration
+func configuredIndexSetting(settings, name string) bool {
+	if settings == "" {
+		return false
+	}
+	sets := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(settings), &sets); err != nil {
+		return false
+	}
+	_, ok := utils.NormalizeIndexSettings(utils.FlattenMap(sets))[name]
+	return ok
+}
+
+// Returns the prefer_ilm set in the configuration of the template block, nil when it's not set
+func configuredTemplatePreferIlm(config cty.Value) *bool {
+	if !config.IsKnown() || config.IsNull() {
+		return nil
+	}
+	template := config.GetAttr("template")
+	if !template.IsKnown() || template.IsNull() || template.LengthInt() == 0 {
+		return nil
+	}
+	preferIlm := template.Index(cty.NumberIntVal(0)).GetAttr("prefer_ilm")
+	if !preferIlm.IsKnown() || preferIlm.IsNull() {
+		return nil
+	}
+	v := preferIlm.True()
+	return &v
+}
+
+func expandTemplateLifecycle(v interface{}) *models.DataStreamLifecycle {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	lifecycle := l[0].(map[string]interface{})
+	enabled := lifecycle["enabled"].(bool)
+	return &models.DataStreamLifecycle{
+		Enabled:       &enabled,
+		DataRetention: lifecycle["data_retention"].(string),
+	}
+}
+
+func flattenTemplateLifecycle(lifecycle *models.DataStreamLifecycle) []interface{} {
+	if lifecycle == nil {
+		return []interface{}{}
+	}
+	enabled := true
+	if lifecycle.Enabled != nil {
+		enabled = *lifecycle.Enabled
+	}
+	return []interface{}{map[string]interface{}{
+		"enabled":        enabled,
+		"data_retention": lifecycle.DataRetention,
+	}}
+}
+
+// Removes the index setting from the settings, either in the flat or in the nested form, with or without the `index.` prefix
+func removeIndexSetting(settings map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := removeSettingPath(settings, strings.Split(name, ".")); ok {
+		return v, ok
+	}
+	return removeSettingPath(settings, strings.Split(strings.TrimPrefix(name, "index."), "."))
+}
+
+func removeSettingPath(settings map[string]interface{}, path []string) (interface{}, bool) {
+	for i := 1; i <= len(path); i++ {
+		key := strings.Join(path[:i], ".")
+		v, ok := settings[key]
+		if !ok {
+			continue
+		}
+		if i == len(path) {
+			delete(settings, key)
+			return v, true
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			if value, ok := removeSettingPath(nested, path[i:]); ok {
+				if len(nested) == 0 {
+					delete(settings, key)
+				}
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Simulates the template of the data streams to find out which lifecycle governs their backing indices. When both the ILM policy
+// and the data stream lifecycle apply, e.g. one of them comes from the component templates, ILM takes precedence unless prefer_ilm
+// is false, which is easily missed, so it's reported unless prefer_ilm is set explicitly.
+func checkTemplateLifecycle(ctx context.Context, client *clients.ApiClient, template *models.IndexTemplate, preferIlm *bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if template.DataStream == nil || preferIlm != nil {
+		return diags
+	}
+	simulated, diags := client.SimulateElasticsearchIndexTemplate(ctx, template)
+	if diags.HasError() {
+		return diags
+	}
+	if simulated.Template == nil {
+		return diags
+	}
+
+	lifecycle := simulated.Template.Lifecycle
+	if lifecycle == nil || (lifecycle.Enabled != nil && !*lifecycle.Enabled) {
+		return diags
+	}
+	settings := utils.NormalizeIndexSettings(utils.FlattenMap(simulated.Template.Settings))
+	policy, ok := settings[indexSettingLifecycleName]
+	if !ok || policy == "" {
+		return diags
+	}
+	governs := fmt.Sprintf(`the ILM policy "%s"`, policy)
+	if settings[indexSettingPreferIlm] == "false" {
+		governs = "the data stream lifecycle"
+	}
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf(`The data streams of "%s" have both the ILM policy and the data stream lifecycle`, template.Name),
+		Detail: fmt.Sprintf(`The settings of the template, including its component templates, set the ILM policy "%s" while the template has the data stream lifecycle too. The backing indices are governed by %s. Set prefer_ilm in the template block to choose the lifecycle explicitly.`,
+			policy, governs),
+	})
+}
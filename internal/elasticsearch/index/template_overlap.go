@@ -0,0 +1,55 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Simulates the index template before it's stored, to report the existing templates with the overlapping index patterns.
+// Only one template is applied to the new index, the one with the highest priority, so the overlaps usually mean that
+// either template silently loses its settings and mappings. The overlaps with the same priority are rejected by the simulation.
+func checkTemplateOverlap(ctx context.Context, client *clients.ApiClient, template *models.IndexTemplate) diag.Diagnostics {
+	var diags diag.Diagnostics
+	simulated, diags := client.SimulateElasticsearchIndexTemplate(ctx, template)
+	if diags.HasError() {
+		return diags
+	}
+
+	priority := templatePriority(template)
+	for _, overlapping := range simulated.Overlapping {
+		other, ds := client.GetElasticsearchIndexTemplate(ctx, overlapping.Name)
+		if ds.HasError() {
+			return ds
+		}
+		// legacy templates are reported as overlapping too, but they are ignored once any composable template matches
+		if other == nil {
+			continue
+		}
+		otherPriority := templatePriority(&other.IndexTemplate)
+		winner, loser := template.Name, other.Name
+		if otherPriority > priority {
+			winner, loser = other.Name, template.Name
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf(`The index template "%s" overlaps with "%s"`, template.Name, other.Name),
+			Detail: fmt.Sprintf(`The index patterns [%s] of "%s" (priority %d) overlap with the index patterns [%s] of "%s" (priority %d). The new indices matching both are created only from "%s", while "%s" is ignored for them.`,
+				strings.Join(template.IndexPatterns, ", "), template.Name, priority,
+				strings.Join(overlapping.IndexPatterns, ", "), other.Name, otherPriority,
+				winner, loser),
+		})
+	}
+	return diags
+}
+
+func templatePriority(template *models.IndexTemplate) int {
+	if template.Priority == nil {
+		return 0
+	}
+	return *template.Priority
+}
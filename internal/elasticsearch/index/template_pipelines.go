@@ -0,0 +1,97 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// the index settings referencing the ingest pipelines
+var pipelineSettings = []string{"index.default_pipeline", "index.final_pipeline"}
+
+// Checks that the ingest pipelines set as `default_pipeline` or `final_pipeline` in the template settings exist, right before the template is stored,
+// since Elasticsearch only checks them once the new index is created, and the index then rejects all the writes.
+// The pipelines managed in the same configuration must be referenced by their resource, e.g. `elasticstack_elasticsearch_ingest_pipeline.logs.name`,
+// so Terraform creates them before the template.
+func checkTemplatePipelines(ctx context.Context, client *clients.ApiClient, settings map[string]interface{}) diag.Diagnostics {
+	return checkPipelines(ctx, client, settings, "the new indices", "template")
+}
+
+// Checks that the ingest pipelines set as `default_pipeline` or `final_pipeline` of the index exist, right before the settings are stored,
+// since Elasticsearch doesn't check them at all, and the index rejects all the writes.
+func checkIndexPipelines(ctx context.Context, client *clients.ApiClient, settings map[string]interface{}) diag.Diagnostics {
+	// the settings removed by the update are reset
+	set := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		if v != nil {
+			set[k] = v
+		}
+	}
+	return checkPipelines(ctx, client, set, "the index", "index")
+}
+
+func checkPipelines(ctx context.Context, client *clients.ApiClient, settings map[string]interface{}, rejecting, target string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	refs := templatePipelineReferences(string(raw))
+	if len(refs) == 0 {
+		return diags
+	}
+	missing, diags := missingPipelines(ctx, client, refs)
+	if diags.HasError() {
+		return diags
+	}
+
+	settingNames := make([]string, 0, len(missing))
+	for setting := range missing {
+		settingNames = append(settingNames, setting)
+	}
+	sort.Strings(settingNames)
+	for _, setting := range settingNames {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Referenced ingest pipeline does not exist",
+			Detail:   fmt.Sprintf(`The pipeline "%s" set as %s does not exist, %s would reject the writes until it is created. If the pipeline is managed in the same configuration, use the name attribute of its resource, so it's created before the %s.`, missing[setting], setting, rejecting, target),
+		})
+	}
+	return diags
+}
+
+// Returns the pipelines referenced by the index settings, keyed by the setting. The special `_none` pipeline is skipped.
+func templatePipelineReferences(settings string) map[string]string {
+	var s map[string]interface{}
+	if err := json.Unmarshal([]byte(settings), &s); err != nil {
+		return nil
+	}
+	normalized := utils.NormalizeIndexSettings(utils.FlattenMap(s))
+
+	refs := make(map[string]string)
+	for _, setting := range pipelineSettings {
+		if name, ok := normalized[setting].(string); ok && name != "" && name != "_none" {
+			refs[setting] = name
+		}
+	}
+	return refs
+}
+
+func missingPipelines(ctx context.Context, client *clients.ApiClient, refs map[string]string) (map[string]string, diag.Diagnostics) {
+	pipelines, diags := client.GetElasticsearchIngestPipelines(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+	missing := make(map[string]string)
+	for setting, name := range refs {
+		if _, ok := pipelines[name]; !ok {
+			missing[setting] = name
+		}
+	}
+	return missing, diags
+}
@@ -0,0 +1,51 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The changes of the template which only apply to the new backing indices of the data streams
+var templateRolloverChanges = []string{"composed_of", "template"}
+
+// Whether the data streams of the template must be rolled over once the template is updated
+func templateNeedsRollover(d *schema.ResourceData) bool {
+	return !d.IsNewResource() && d.Get("rollover_on_change").(bool) && d.HasChanges(templateRolloverChanges...)
+}
+
+// Rolls over the data streams created from the template, so their write indices use the updated template
+func rolloverTemplateDataStreams(ctx context.Context, client *clients.ApiClient, templateName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	dataStreams, diags := client.GetElasticsearchDataStreams(ctx, "*", true)
+	if diags.HasError() {
+		return diags
+	}
+	names := make([]string, 0)
+	for _, ds := range dataStreams {
+		if ds.Template == templateName {
+			names = append(names, ds.Name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		response, diags := client.RolloverElasticsearchIndex(ctx, name, "", &models.Rollover{}, false)
+		if diags.HasError() {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to roll over the data streams of the template",
+				Detail:   fmt.Sprintf(`The template "%s" was updated, but the data stream "%s" was not rolled over. The data streams before it in [%s] were rolled over.`, templateName, name, strings.Join(names, ", ")),
+			})
+		}
+		tflog.Info(ctx, fmt.Sprintf(`rolled over the data stream "%s" of the template "%s" into "%s"`, name, templateName, response.NewIndex))
+	}
+	return diags
+}
@@ -1,7 +1,9 @@
 package index_test
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -115,6 +117,415 @@ resource "elasticstack_elasticsearch_index_template" "test2" {
 	`, name, name, name)
 }
 
+func TestAccResourceIndexTemplatePipelines(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexTemplateDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexTemplateMissingPipeline(templateName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`The pipeline "%s-missing" set as index.default_pipeline does not exist`, templateName)),
+			},
+			{
+				Config: testAccResourceIndexTemplateManagedPipeline(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "name", templateName),
+				),
+			},
+			{
+				Config: testAccResourceIndexTemplatePipelineAttributes(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.default_pipeline", "_none"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.final_pipeline", fmt.Sprintf("%s-final", templateName)),
+				),
+			},
+			{
+				Config:      testAccResourceIndexTemplatePipelineConflict(templateName),
+				ExpectError: regexp.MustCompile(`\[index.default_pipeline\] are set both by the pipeline attributes and in the settings`),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexTemplatePipelineAttributes(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test" {
+  name = "%[1]s-final"
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "event.ingested"
+        value = "{{_ingest.timestamp}}"
+      }
+    })
+  ]
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%[1]s"
+  index_patterns = ["%[1]s-logs-*"]
+
+  template {
+    default_pipeline = "_none"
+    final_pipeline   = elasticstack_elasticsearch_ingest_pipeline.test.name
+  }
+}
+	`, name)
+}
+
+func testAccResourceIndexTemplatePipelineConflict(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%[1]s"
+  index_patterns = ["%[1]s-logs-*"]
+
+  template {
+    default_pipeline = "_none"
+    settings = jsonencode({
+      "index.default_pipeline" = "_none"
+    })
+  }
+}
+	`, name)
+}
+
+func testAccResourceIndexTemplateMissingPipeline(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%[1]s"
+  index_patterns = ["%[1]s-logs-*"]
+
+  template {
+    settings = jsonencode({
+      index = {
+        default_pipeline = "%[1]s-missing"
+      }
+    })
+  }
+}
+	`, name)
+}
+
+func testAccResourceIndexTemplateManagedPipeline(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test" {
+  name = "%[1]s-final"
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "event.ingested"
+        value = "{{_ingest.timestamp}}"
+      }
+    })
+  ]
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%[1]s"
+  index_patterns = ["%[1]s-logs-*"]
+
+  template {
+    settings = jsonencode({
+      "index.final_pipeline" = elasticstack_elasticsearch_ingest_pipeline.test.name
+    })
+  }
+}
+	`, name)
+}
+
+func TestAccResourceIndexTemplateOverlap(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexTemplateDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexTemplateOverlap(templateName, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.specific", "priority", "100"),
+				),
+			},
+			{
+				Config:      testAccResourceIndexTemplateOverlap(templateName, 50),
+				ExpectError: regexp.MustCompile(`same priority`),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexTemplateOverlap(name string, priority int) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "generic" {
+  name           = "%[1]s-generic"
+  priority       = 50
+  index_patterns = ["%[1]s-*"]
+}
+
+resource "elasticstack_elasticsearch_index_template" "specific" {
+  name           = "%[1]s-specific"
+  priority       = %[2]d
+  index_patterns = ["%[1]s-logs-*"]
+
+  depends_on = [elasticstack_elasticsearch_index_template.generic]
+}
+	`, name, priority)
+}
+
+func TestAccResourceIndexTemplateLifecycle(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexTemplateDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexTemplateLifecycleWithoutDataStream(templateName),
+				ExpectError: regexp.MustCompile(`applies only to the data streams`),
+			},
+			{
+				Config: testAccResourceIndexTemplateLifecycle(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.lifecycle.0.data_retention", "30d"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.lifecycle.0.enabled", "true"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.prefer_ilm", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.settings", `{"index":{"number_of_shards":"1"}}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexTemplateLifecycleWithoutDataStream(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%s"
+  index_patterns = ["%s-logs-*"]
+
+  template {
+    lifecycle {
+      data_retention = "30d"
+    }
+  }
+}
+	`, name, name)
+}
+
+func testAccResourceIndexTemplateLifecycle(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%s"
+  index_patterns = ["%s-logs-*"]
+
+  data_stream {}
+
+  template {
+    settings = jsonencode({
+      number_of_shards = 1
+    })
+    prefer_ilm = false
+
+    lifecycle {
+      data_retention = "30d"
+    }
+  }
+}
+	`, name, name)
+}
+
+func TestAccResourceIndexTemplateAnalysis(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexTemplateDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexTemplateAnalysis(templateName, `{"index":{"analysis":{"analyzer":{"default":{"type":"simple"}}}}}`),
+				ExpectError: regexp.MustCompile(`are managed by the analysis block`),
+			},
+			{
+				Config: testAccResourceIndexTemplateAnalysis(templateName, `{"index":{"number_of_shards":"1"}}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.analysis.0.analyzer.0.name", "autocomplete"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.analysis.0.tokenizer.0.name", "autocomplete_edge"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.settings", `{"index":{"number_of_shards":"1"}}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexTemplateAnalysis(name, settings string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%s"
+  index_patterns = ["%s-*"]
+
+  template {
+    settings = %q
+
+    analysis {
+      analyzer {
+        name      = "autocomplete"
+        tokenizer = "autocomplete_edge"
+        filter    = ["lowercase"]
+      }
+
+      tokenizer {
+        name       = "autocomplete_edge"
+        type       = "edge_ngram"
+        parameters = jsonencode({ min_gram = 2, max_gram = 10, token_chars = ["letter"] })
+      }
+    }
+  }
+}
+	`, name, name, settings)
+}
+
+func TestAccResourceIndexTemplateIgnoreMissingComponentTemplates(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexTemplateDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexTemplateIgnoreMissing(templateName, "other@custom"),
+				ExpectError: regexp.MustCompile(`the ignored component template "other@custom" is not in composed_of`),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexTemplateIgnoreMissing(name, ignored string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%[1]s"
+  index_patterns = ["%[1]s-*"]
+
+  composed_of                        = ["%[1]s@custom"]
+  ignore_missing_component_templates = ["%[2]s"]
+}
+	`, name, ignored)
+}
+
+func TestAccResourceIndexTemplateRolloverOnChange(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIndexTemplateDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexTemplateRolloverOnChange(templateName, "keyword"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "rollover_on_change", "true"),
+					checkDataStreamBackingIndices(templateName, 1),
+				),
+			},
+			{
+				Config: testAccResourceIndexTemplateRolloverOnChange(templateName, "text"),
+				Check:  checkDataStreamBackingIndices(templateName, 2),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexTemplateRolloverOnChange(name, messageType string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name = "%s"
+
+  index_patterns = ["%s*"]
+
+  template {
+    mappings = jsonencode({
+      properties = {
+        message = { type = "%s" }
+      }
+    })
+  }
+
+  data_stream {}
+
+  rollover_on_change = true
+}
+
+resource "elasticstack_elasticsearch_data_stream" "test" {
+  name = "%s"
+
+  depends_on = [
+    elasticstack_elasticsearch_index_template.test
+  ]
+}
+	`, name, name, messageType, name)
+}
+
+func checkDataStreamBackingIndices(name string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := acctest.Provider.Meta().(*clients.ApiClient)
+		ds, diags := client.GetElasticsearchDataStream(context.Background(), name)
+		if diags.HasError() {
+			return fmt.Errorf("Unable to get the data stream: %v", diags)
+		}
+		if ds == nil || len(ds.Indices) != count {
+			return fmt.Errorf("Expected the data stream %s to have %d backing indices, got: %+v", name, count, ds)
+		}
+		return nil
+	}
+}
+
 func checkResourceIndexTemplateDestroy(s *terraform.State) error {
 	client := acctest.Provider.Meta().(*clients.ApiClient)
 
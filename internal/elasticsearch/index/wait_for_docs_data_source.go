@@ -0,0 +1,140 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceWaitForDocs() *schema.Resource {
+	waitSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Comma-separated list of the indices, data streams or aliases to count the documents in. Supports wildcards (`*`).",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"query": {
+			Description:      "The query the documents must match to be counted, e.g. `jsonencode({ term = { \"agent.id\" = \"...\" } })`. All the documents are counted by default.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"min_count": {
+			Description:  "The number of the documents to wait for.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"timeout": {
+			Description:  "How long to wait for the documents, e.g. `10m`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "5m",
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"poll_interval": {
+			Description:  "How often to count the documents, e.g. `10s`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "10s",
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"doc_count": {
+			Description: "The number of the matching documents found.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(waitSchema)
+
+	return &schema.Resource{
+		Description: "Waits until the number of the documents matching the query reaches the threshold, e.g. to gate the downstream resources on the data actually being ingested. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/search-count.html",
+
+		ReadContext: dataSourceWaitForDocsRead,
+
+		Schema: waitSchema,
+	}
+}
+
+func dataSourceWaitForDocsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	index := d.Get("index").(string)
+	id, diags := client.ID(ctx, index)
+	if diags.HasError() {
+		return diags
+	}
+
+	var query map[string]interface{}
+	if v, ok := d.GetOk("query"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &query); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	minCount := int64(d.Get("min_count").(int))
+	timeout, err := utils.ParseElasticDuration(d.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pollInterval, err := utils.ParseElasticDuration(d.Get("poll_interval").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	count, diags := waitForDocs(ctx, client, index, query, minCount, timeout, pollInterval)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("doc_count", count); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+func waitForDocs(ctx context.Context, client *clients.ApiClient, index string, query map[string]interface{}, minCount int64, timeout, pollInterval time.Duration) (int64, diag.Diagnostics) {
+	deadline := time.Now().Add(timeout)
+	for {
+		count, diags := client.CountElasticsearchDocuments(ctx, index, query)
+		if diags.HasError() {
+			return 0, diags
+		}
+		if count >= minCount {
+			return count, diags
+		}
+		if time.Now().Add(pollInterval).After(deadline) {
+			return 0, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf(`Timed out waiting for the documents in "%s"`, index),
+				Detail:   fmt.Sprintf("Found %d matching documents after %s, expected at least %d.", count, timeout, minCount),
+			}}
+		}
+		tflog.Trace(ctx, fmt.Sprintf("found %d of %d documents in '%s', retrying in %s", count, minCount, index, pollInterval))
+
+		select {
+		case <-ctx.Done():
+			return 0, diag.FromErr(ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
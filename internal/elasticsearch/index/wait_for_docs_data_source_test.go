@@ -0,0 +1,53 @@
+package index_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceWaitForDocs(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceWaitForDocs(name, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_wait_for_docs.test", "index", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_wait_for_docs.test", "doc_count", "0"),
+				),
+			},
+			{
+				Config:      testAccDataSourceWaitForDocs(name, 1),
+				ExpectError: regexp.MustCompile("Timed out waiting for the documents"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceWaitForDocs(name string, minCount int) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+}
+
+data "elasticstack_elasticsearch_wait_for_docs" "test" {
+  index         = elasticstack_elasticsearch_index.test.name
+  query         = jsonencode({ match_all = {} })
+  min_count     = %d
+  timeout       = "2s"
+  poll_interval = "1s"
+}
+	`, name, minCount)
+}
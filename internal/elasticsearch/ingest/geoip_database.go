@@ -0,0 +1,185 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	geoipProviderMaxmind = "maxmind"
+	geoipProviderIpinfo  = "ipinfo"
+)
+
+func ResourceGeoipDatabase() *schema.Resource {
+	databaseSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"database_id": {
+			Description: "The identifier of the database configuration.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "The name of the database to download from the provider, e.g. `GeoIP2-Domain` or `asn`. The `geoip` processors refer to the database by its name followed by `.mmdb`.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"provider_type": {
+			Description:  "The provider of the database: `maxmind` or `ipinfo` (Elasticsearch 8.16+).",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      geoipProviderMaxmind,
+			ValidateFunc: validation.StringInSlice([]string{geoipProviderMaxmind, geoipProviderIpinfo}, false),
+		},
+		"account_id": {
+			Description: "The MaxMind account ID, required with the `maxmind` provider. The license key must be added to the keystore of every node as the `ingest.geoip.downloader.maxmind.license_key` secure setting.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"version": {
+			Description: "The version of the database configuration, incremented on every update.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(databaseSchema)
+
+	return &schema.Resource{
+		Description: "Creates or updates the configuration of the custom database downloaded for the `geoip` and `ip_location` processors. Requires Elasticsearch 8.15 or higher. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/put-geoip-database-api.html",
+
+		CreateContext: resourceGeoipDatabasePut,
+		UpdateContext: resourceGeoipDatabasePut,
+		ReadContext:   resourceGeoipDatabaseRead,
+		DeleteContext: resourceGeoipDatabaseDelete,
+
+		CustomizeDiff: validateGeoipDatabaseProvider,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: databaseSchema,
+	}
+}
+
+func validateGeoipDatabaseProvider(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("account_id") {
+		return nil
+	}
+	accountId := d.Get("account_id").(string)
+	switch d.Get("provider_type").(string) {
+	case geoipProviderMaxmind:
+		if accountId == "" {
+			return fmt.Errorf("account_id is required with the %s provider", geoipProviderMaxmind)
+		}
+	case geoipProviderIpinfo:
+		if accountId != "" {
+			return fmt.Errorf("account_id is not supported with the %s provider", geoipProviderIpinfo)
+		}
+	}
+	return nil
+}
+
+func resourceGeoipDatabasePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	databaseId := d.Get("database_id").(string)
+	id, diags := client.ID(ctx, databaseId)
+	if diags.HasError() {
+		return diags
+	}
+
+	database := models.GeoipDatabase{
+		Id:   databaseId,
+		Name: d.Get("name").(string),
+	}
+	if d.Get("provider_type").(string) == geoipProviderIpinfo {
+		database.Ipinfo = &models.GeoipDatabaseIpinfo{}
+	} else {
+		database.Maxmind = &models.GeoipDatabaseMaxmind{AccountId: d.Get("account_id").(string)}
+	}
+
+	if diags := client.PutElasticsearchGeoipDatabase(ctx, &database); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceGeoipDatabaseRead(ctx, d, meta)
+}
+
+func resourceGeoipDatabaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	database, diags := client.GetElasticsearchGeoipDatabase(ctx, compId.ResourceId)
+	if database == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("database_id", database.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", database.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	providerType, accountId := geoipProviderMaxmind, ""
+	if database.Ipinfo != nil {
+		providerType = geoipProviderIpinfo
+	} else if database.Maxmind != nil {
+		accountId = database.Maxmind.AccountId
+	}
+	if err := d.Set("provider_type", providerType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("account_id", accountId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", database.Version); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceGeoipDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchGeoipDatabase(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
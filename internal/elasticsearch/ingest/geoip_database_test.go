@@ -0,0 +1,84 @@
+package ingest_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceGeoipDatabase(t *testing.T) {
+	databaseId := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceGeoipDatabaseDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceGeoipDatabase(databaseId, "GeoIP2-Domain", ""),
+				ExpectError: regexp.MustCompile("account_id is required with the maxmind provider"),
+			},
+			{
+				Config: testAccResourceGeoipDatabase(databaseId, "GeoIP2-Domain", "1234567"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_geoip_database.test", "database_id", databaseId),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_geoip_database.test", "name", "GeoIP2-Domain"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_geoip_database.test", "provider_type", "maxmind"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_geoip_database.test", "account_id", "1234567"),
+				),
+			},
+			{
+				Config: testAccResourceGeoipDatabase(databaseId, "GeoIP2-ISP", "1234567"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_geoip_database.test", "name", "GeoIP2-ISP"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_geoip_database.test", "version", "2"),
+				),
+			},
+			{
+				ResourceName:      "elasticstack_elasticsearch_geoip_database.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceGeoipDatabase(databaseId, name, accountId string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_geoip_database" "test" {
+  database_id = "%s"
+  name        = "%s"
+  account_id  = "%s"
+}
+	`, databaseId, name, accountId)
+}
+
+func checkResourceGeoipDatabaseDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_geoip_database" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+		database, diags := client.GetElasticsearchGeoipDatabase(context.Background(), compId.ResourceId)
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get geoip database configuration: %v", diags)
+		}
+		if database != nil {
+			return fmt.Errorf("Geoip database configuration (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
@@ -72,10 +72,14 @@ func ResourceIngestPipeline() *schema.Resource {
 		ReadContext:   resourceIngestPipelineTemplateRead,
 		DeleteContext: resourceIngestPipelineTemplateDelete,
 
+		CustomizeDiff: validatePipelineSelfReference,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
 		Schema: pipelineSchema,
 	}
 }
@@ -86,7 +90,7 @@ func resourceIngestPipelineTemplatePut(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 	pipelineId := d.Get("name").(string)
-	id, diags := client.ID(pipelineId)
+	id, diags := client.ID(ctx, pipelineId)
 	if diags.HasError() {
 		return diags
 	}
@@ -126,12 +130,17 @@ func resourceIngestPipelineTemplatePut(ctx context.Context, d *schema.ResourceDa
 		pipeline.Metadata = metadata
 	}
 
-	if diags := client.PutElasticsearchIngestPipeline(&pipeline); diags.HasError() {
+	diags = checkPipelineReferences(ctx, client, &pipeline)
+	if diags.HasError() {
+		return diags
+	}
+
+	if diags := client.PutElasticsearchIngestPipeline(ctx, &pipeline); diags.HasError() {
 		return diags
 	}
 
 	d.SetId(id.String())
-	return resourceIngestPipelineTemplateRead(ctx, d, meta)
+	return append(diags, resourceIngestPipelineTemplateRead(ctx, d, meta)...)
 }
 
 func resourceIngestPipelineTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -146,7 +155,7 @@ func resourceIngestPipelineTemplateRead(ctx context.Context, d *schema.ResourceD
 		return diags
 	}
 
-	pipeline, diags := client.GetElasticsearchIngestPipeline(&compId.ResourceId)
+	pipeline, diags := client.GetElasticsearchIngestPipeline(ctx, &compId.ResourceId)
 	if pipeline == nil && diags == nil {
 		d.SetId("")
 		return diags
@@ -214,7 +223,7 @@ func resourceIngestPipelineTemplateDelete(ctx context.Context, d *schema.Resourc
 		return diags
 	}
 
-	if diags := client.DeleteElasticsearchIngestPipeline(&compId.ResourceId); diags.HasError() {
+	if diags := client.DeleteElasticsearchIngestPipeline(ctx, &compId.ResourceId); diags.HasError() {
 		return diags
 	}
 
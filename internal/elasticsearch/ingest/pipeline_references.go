@@ -0,0 +1,152 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Validates at plan time that the pipeline does not call itself via the `pipeline` processor
+func validatePipelineSelfReference(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	name := d.Get("name").(string)
+	if name == "" {
+		return nil
+	}
+	processors := make([]map[string]interface{}, 0)
+	for _, attr := range []string{"processors", "on_failure"} {
+		for _, p := range d.Get(attr).([]interface{}) {
+			// the values which are not known yet cannot be checked
+			proc, ok := p.(string)
+			if !ok || proc == "" {
+				continue
+			}
+			item := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(proc), &item); err != nil {
+				continue
+			}
+			processors = append(processors, item)
+		}
+	}
+	for _, ref := range pipelineReferences(processors) {
+		if ref == name {
+			return fmt.Errorf(`the ingest pipeline "%s" references itself in the pipeline processor, which would create an endless loop`, name)
+		}
+	}
+	return nil
+}
+
+// Checks the references to the other pipelines before the pipeline is stored: the reference cycles are reported as errors,
+// since Elasticsearch fails every document going through the cycle, and the pipelines missing in the cluster as warnings,
+// since those can still be created later on. It runs on apply, because the plan cannot report warnings and the pipelines
+// created by the same configuration don't exist in the cluster while it's planned.
+func checkPipelineReferences(ctx context.Context, client *clients.ApiClient, pipeline *models.IngestPipeline) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	refs := pipelineReferences(append(append([]map[string]interface{}{}, pipeline.Processors...), pipeline.OnFailure...))
+	if len(refs) == 0 {
+		return diags
+	}
+
+	pipelines, diags := client.GetElasticsearchIngestPipelines(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	graph := make(map[string][]string, len(pipelines)+1)
+	for name, p := range pipelines {
+		graph[name] = pipelineReferences(append(append([]map[string]interface{}{}, p.Processors...), p.OnFailure...))
+	}
+	graph[pipeline.Name] = refs
+
+	for _, ref := range refs {
+		if _, ok := graph[ref]; !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Referenced ingest pipeline does not exist",
+				Detail:   fmt.Sprintf(`The ingest pipeline "%s" references the pipeline "%s", which does not exist in the cluster. The documents will fail to be ingested until it is created.`, pipeline.Name, ref),
+			})
+		}
+	}
+
+	if cycle := findPipelineCycle(pipeline.Name, graph); cycle != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Ingest pipeline reference cycle",
+			Detail:   fmt.Sprintf(`The ingest pipeline "%s" would create the reference cycle: %s`, pipeline.Name, strings.Join(cycle, " -> ")),
+		})
+	}
+	return diags
+}
+
+// Collects the names of the pipelines referenced by the `pipeline` processors, including the nested ones in `on_failure` and `foreach`.
+// The names using the templates (e.g. "{{ pipeline }}") cannot be resolved, and are skipped.
+func pipelineReferences(processors []map[string]interface{}) []string {
+	refs := make(map[string]struct{})
+	var walk func(processors []interface{})
+	walk = func(processors []interface{}) {
+		for _, p := range processors {
+			proc, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for typ, b := range proc {
+				body, ok := b.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if name, ok := body["name"].(string); typ == "pipeline" && ok && !strings.Contains(name, "{{") {
+					refs[name] = struct{}{}
+				}
+				if onFailure, ok := body["on_failure"].([]interface{}); ok {
+					walk(onFailure)
+				}
+				if nested, ok := body["processor"].(map[string]interface{}); typ == "foreach" && ok {
+					walk([]interface{}{nested})
+				}
+			}
+		}
+	}
+	procs := make([]interface{}, len(processors))
+	for i, p := range processors {
+		procs[i] = p
+	}
+	walk(procs)
+
+	result := make([]string, 0, len(refs))
+	for name := range refs {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Returns the path of the reference cycle going through the pipeline, or nil if there is none.
+func findPipelineCycle(start string, graph map[string][]string) []string {
+	visited := make(map[string]bool)
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		path = append(path, name)
+		defer func() { path = path[:len(path)-1] }()
+		for _, ref := range graph[name] {
+			if ref == start {
+				return append(append([]string{}, path...), ref)
+			}
+			if visited[ref] {
+				continue
+			}
+			visited[ref] = true
+			if cycle := visit(ref); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return visit(start)
+}
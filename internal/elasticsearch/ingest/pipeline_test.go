@@ -2,6 +2,7 @@ package ingest_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -92,6 +93,81 @@ resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
 	`, name)
 }
 
+func TestAccResourceIngestPipelineReferences(t *testing.T) {
+	pipelineName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceIngestPipelineDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIngestPipelineSelfReference(pipelineName),
+				ExpectError: regexp.MustCompile("references itself in the pipeline processor"),
+			},
+			{
+				Config: testAccResourceIngestPipelineChain(pipelineName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_caller", "processors.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIngestPipelineSelfReference(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
+  name = "%[1]s"
+
+  processors = [
+    jsonencode({
+      pipeline = {
+        name = "%[1]s"
+      }
+    }),
+  ]
+}
+	`, name)
+}
+
+func testAccResourceIngestPipelineChain(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
+  name = "%[1]s"
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "_meta"
+        value = "indexed"
+      }
+    }),
+  ]
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test_caller" {
+  name = "%[1]s-caller"
+
+  processors = [
+    jsonencode({
+      pipeline = {
+        name = elasticstack_elasticsearch_ingest_pipeline.test_pipeline.name
+      }
+    }),
+  ]
+}
+	`, name)
+}
+
 func checkResourceIngestPipelineDestroy(s *terraform.State) error {
 	client := acctest.Provider.Meta().(*clients.ApiClient)
 
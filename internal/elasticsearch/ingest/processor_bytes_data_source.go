@@ -3,13 +3,11 @@ package ingest
 import (
 	"context"
 	"encoding/json"
-	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func DataSourceProcessorBytes() *schema.Resource {
@@ -35,38 +33,6 @@ func DataSourceProcessorBytes() *schema.Resource {
 			Optional:    true,
 			Default:     false,
 		},
-		"description": {
-			Description: "Description of the processor. ",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"if": {
-			Description: "Conditionally execute the processor",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"ignore_failure": {
-			Description: "Ignore failures for the processor. ",
-			Type:        schema.TypeBool,
-			Optional:    true,
-			Default:     false,
-		},
-		"on_failure": {
-			Description: "Handle failures for the processor.",
-			Type:        schema.TypeList,
-			Optional:    true,
-			MinItems:    1,
-			Elem: &schema.Schema{
-				Type:             schema.TypeString,
-				ValidateFunc:     validation.StringIsJSON,
-				DiffSuppressFunc: utils.DiffJsonSuppress,
-			},
-		},
-		"tag": {
-			Description: "Identifier for the processor.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
 		"json": {
 			Description: "JSON representation of this data source.",
 			Type:        schema.TypeString,
@@ -74,6 +40,8 @@ func DataSourceProcessorBytes() *schema.Resource {
 		},
 	}
 
+	addCommonProcessorSchema(processorSchema)
+
 	return &schema.Resource{
 		Description: "Converts a human readable byte value (e.g. 1kb) to its value in bytes (e.g. 1024). See: https://www.elastic.co/guide/en/elasticsearch/reference/current/bytes-processor.html",
 
@@ -88,32 +56,17 @@ func dataSourceProcessorBytesRead(ctx context.Context, d *schema.ResourceData, m
 
 	processor := &models.ProcessorBytes{}
 
+	common, err := expandCommonProcessor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	processor.CommonProcessor = common
+
 	processor.Field = d.Get("field").(string)
-	processor.IgnoreFailure = d.Get("ignore_failure").(bool)
 	processor.IgnoreMissing = d.Get("ignore_missing").(bool)
 	if v, ok := d.GetOk("target_field"); ok {
 		processor.TargetField = v.(string)
 	}
-	if v, ok := d.GetOk("description"); ok {
-		processor.Description = v.(string)
-	}
-	if v, ok := d.GetOk("if"); ok {
-		processor.If = v.(string)
-	}
-	if v, ok := d.GetOk("tag"); ok {
-		processor.Tag = v.(string)
-	}
-	if v, ok := d.GetOk("on_failure"); ok {
-		onFailure := make([]map[string]interface{}, len(v.([]interface{})))
-		for i, f := range v.([]interface{}) {
-			item := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(f.(string))).Decode(&item); err != nil {
-				return diag.FromErr(err)
-			}
-			onFailure[i] = item
-		}
-		processor.OnFailure = onFailure
-	}
 
 	processorJson, err := json.MarshalIndent(map[string]*models.ProcessorBytes{"bytes": processor}, "", " ")
 	if err != nil {
@@ -3,7 +3,6 @@ package ingest
 import (
 	"context"
 	"encoding/json"
-	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
@@ -46,38 +45,6 @@ func DataSourceProcessorCircle() *schema.Resource {
 			Required:     true,
 			ValidateFunc: validation.StringInSlice([]string{"geo_shape", "shape"}, false),
 		},
-		"description": {
-			Description: "Description of the processor. ",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"if": {
-			Description: "Conditionally execute the processor",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"ignore_failure": {
-			Description: "Ignore failures for the processor. ",
-			Type:        schema.TypeBool,
-			Optional:    true,
-			Default:     false,
-		},
-		"on_failure": {
-			Description: "Handle failures for the processor.",
-			Type:        schema.TypeList,
-			Optional:    true,
-			MinItems:    1,
-			Elem: &schema.Schema{
-				Type:             schema.TypeString,
-				ValidateFunc:     validation.StringIsJSON,
-				DiffSuppressFunc: utils.DiffJsonSuppress,
-			},
-		},
-		"tag": {
-			Description: "Identifier for the processor.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
 		"json": {
 			Description: "JSON representation of this data source.",
 			Type:        schema.TypeString,
@@ -85,6 +52,8 @@ func DataSourceProcessorCircle() *schema.Resource {
 		},
 	}
 
+	addCommonProcessorSchema(processorSchema)
+
 	return &schema.Resource{
 		Description: "Converts circle definitions of shapes to regular polygons which approximate them. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest-circle-processor.html",
 
@@ -99,8 +68,13 @@ func dataSourceProcessorCircleRead(ctx context.Context, d *schema.ResourceData,
 
 	processor := &models.ProcessorCircle{}
 
+	common, err := expandCommonProcessor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	processor.CommonProcessor = common
+
 	processor.Field = d.Get("field").(string)
-	processor.IgnoreFailure = d.Get("ignore_failure").(bool)
 	processor.IgnoreMissing = d.Get("ignore_missing").(bool)
 	processor.ErrorDistance = d.Get("error_distance").(float64)
 	processor.ShapeType = d.Get("shape_type").(string)
@@ -108,26 +82,6 @@ func dataSourceProcessorCircleRead(ctx context.Context, d *schema.ResourceData,
 	if v, ok := d.GetOk("target_field"); ok {
 		processor.TargetField = v.(string)
 	}
-	if v, ok := d.GetOk("description"); ok {
-		processor.Description = v.(string)
-	}
-	if v, ok := d.GetOk("if"); ok {
-		processor.If = v.(string)
-	}
-	if v, ok := d.GetOk("tag"); ok {
-		processor.Tag = v.(string)
-	}
-	if v, ok := d.GetOk("on_failure"); ok {
-		onFailure := make([]map[string]interface{}, len(v.([]interface{})))
-		for i, f := range v.([]interface{}) {
-			item := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(f.(string))).Decode(&item); err != nil {
-				return diag.FromErr(err)
-			}
-			onFailure[i] = item
-		}
-		processor.OnFailure = onFailure
-	}
 
 	processorJson, err := json.MarshalIndent(map[string]*models.ProcessorCircle{"circle": processor}, "", " ")
 	if err != nil {
@@ -0,0 +1,74 @@
+package ingest
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Adds the fields supported by every processor, so they are defined the same way by all the processor data sources
+func addCommonProcessorSchema(s map[string]*schema.Schema) {
+	s["description"] = &schema.Schema{
+		Description: "Description of the processor. ",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+	s["if"] = &schema.Schema{
+		Description: "Conditionally execute the processor",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+	s["ignore_failure"] = &schema.Schema{
+		Description: "Ignore failures for the processor. ",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	s["on_failure"] = &schema.Schema{
+		Description: "Handle failures for the processor.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MinItems:    1,
+		Elem: &schema.Schema{
+			Type:             schema.TypeString,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+	}
+	s["tag"] = &schema.Schema{
+		Description: "Identifier for the processor.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+}
+
+// Returns the fields supported by every processor, as they are set in the processor data source
+func expandCommonProcessor(d *schema.ResourceData) (models.CommonProcessor, error) {
+	processor := models.CommonProcessor{}
+	processor.IgnoreFailure = d.Get("ignore_failure").(bool)
+	if v, ok := d.GetOk("description"); ok {
+		processor.Description = v.(string)
+	}
+	if v, ok := d.GetOk("if"); ok {
+		processor.If = v.(string)
+	}
+	if v, ok := d.GetOk("tag"); ok {
+		processor.Tag = v.(string)
+	}
+	if v, ok := d.GetOk("on_failure"); ok {
+		onFailure := make([]map[string]interface{}, len(v.([]interface{})))
+		for i, f := range v.([]interface{}) {
+			item := make(map[string]interface{})
+			if err := json.NewDecoder(strings.NewReader(f.(string))).Decode(&item); err != nil {
+				return processor, err
+			}
+			onFailure[i] = item
+		}
+		processor.OnFailure = onFailure
+	}
+	return processor, nil
+}
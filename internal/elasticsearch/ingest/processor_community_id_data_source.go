@@ -3,7 +3,6 @@ package ingest
 import (
 	"context"
 	"encoding/json"
-	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
@@ -77,38 +76,6 @@ func DataSourceProcessorCommunityId() *schema.Resource {
 			Optional:    true,
 			Default:     false,
 		},
-		"description": {
-			Description: "Description of the processor. ",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"if": {
-			Description: "Conditionally execute the processor",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"ignore_failure": {
-			Description: "Ignore failures for the processor. ",
-			Type:        schema.TypeBool,
-			Optional:    true,
-			Default:     false,
-		},
-		"on_failure": {
-			Description: "Handle failures for the processor.",
-			Type:        schema.TypeList,
-			Optional:    true,
-			MinItems:    1,
-			Elem: &schema.Schema{
-				Type:             schema.TypeString,
-				ValidateFunc:     validation.StringIsJSON,
-				DiffSuppressFunc: utils.DiffJsonSuppress,
-			},
-		},
-		"tag": {
-			Description: "Identifier for the processor.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
 		"json": {
 			Description: "JSON representation of this data source.",
 			Type:        schema.TypeString,
@@ -116,6 +83,8 @@ func DataSourceProcessorCommunityId() *schema.Resource {
 		},
 	}
 
+	addCommonProcessorSchema(processorSchema)
+
 	return &schema.Resource{
 		Description: "Computes the Community ID for network flow data as defined in the Community ID Specification. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/community-id-processor.html",
 
@@ -130,7 +99,12 @@ func dataSourceProcessorCommunityIdRead(ctx context.Context, d *schema.ResourceD
 
 	processor := &models.ProcessorCommunityId{}
 
-	processor.IgnoreFailure = d.Get("ignore_failure").(bool)
+	common, err := expandCommonProcessor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	processor.CommonProcessor = common
+
 	processor.IgnoreMissing = d.Get("ignore_missing").(bool)
 	seed := d.Get("seed").(int)
 	processor.Seed = &seed
@@ -166,26 +140,6 @@ func dataSourceProcessorCommunityIdRead(ctx context.Context, d *schema.ResourceD
 	if v, ok := d.GetOk("target_field"); ok {
 		processor.TargetField = v.(string)
 	}
-	if v, ok := d.GetOk("description"); ok {
-		processor.Description = v.(string)
-	}
-	if v, ok := d.GetOk("if"); ok {
-		processor.If = v.(string)
-	}
-	if v, ok := d.GetOk("tag"); ok {
-		processor.Tag = v.(string)
-	}
-	if v, ok := d.GetOk("on_failure"); ok {
-		onFailure := make([]map[string]interface{}, len(v.([]interface{})))
-		for i, f := range v.([]interface{}) {
-			item := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(f.(string))).Decode(&item); err != nil {
-				return diag.FromErr(err)
-			}
-			onFailure[i] = item
-		}
-		processor.OnFailure = onFailure
-	}
 
 	processorJson, err := json.MarshalIndent(map[string]*models.ProcessorCommunityId{"community_id": processor}, "", " ")
 	if err != nil {
@@ -3,7 +3,6 @@ package ingest
 import (
 	"context"
 	"encoding/json"
-	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
@@ -41,38 +40,6 @@ func DataSourceProcessorConvert() *schema.Resource {
 			Required:     true,
 			ValidateFunc: validation.StringInSlice([]string{"integer", "long", "float", "double", "string", "boolean", "ip", "auto"}, true),
 		},
-		"description": {
-			Description: "Description of the processor. ",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"if": {
-			Description: "Conditionally execute the processor",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"ignore_failure": {
-			Description: "Ignore failures for the processor. ",
-			Type:        schema.TypeBool,
-			Optional:    true,
-			Default:     false,
-		},
-		"on_failure": {
-			Description: "Handle failures for the processor.",
-			Type:        schema.TypeList,
-			Optional:    true,
-			MinItems:    1,
-			Elem: &schema.Schema{
-				Type:             schema.TypeString,
-				ValidateFunc:     validation.StringIsJSON,
-				DiffSuppressFunc: utils.DiffJsonSuppress,
-			},
-		},
-		"tag": {
-			Description: "Identifier for the processor.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
 		"json": {
 			Description: "JSON representation of this data source.",
 			Type:        schema.TypeString,
@@ -80,6 +47,8 @@ func DataSourceProcessorConvert() *schema.Resource {
 		},
 	}
 
+	addCommonProcessorSchema(processorSchema)
+
 	return &schema.Resource{
 		Description: "Converts a field in the currently ingested document to a different type, such as converting a string to an integer. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/convert-processor.html",
 
@@ -94,34 +63,19 @@ func dataSourceProcessorConvertRead(ctx context.Context, d *schema.ResourceData,
 
 	processor := &models.ProcessorConvert{}
 
+	common, err := expandCommonProcessor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	processor.CommonProcessor = common
+
 	processor.Field = d.Get("field").(string)
-	processor.IgnoreFailure = d.Get("ignore_failure").(bool)
 	processor.IgnoreMissing = d.Get("ignore_missing").(bool)
 	processor.Type = d.Get("type").(string)
 
 	if v, ok := d.GetOk("target_field"); ok {
 		processor.TargetField = v.(string)
 	}
-	if v, ok := d.GetOk("description"); ok {
-		processor.Description = v.(string)
-	}
-	if v, ok := d.GetOk("if"); ok {
-		processor.If = v.(string)
-	}
-	if v, ok := d.GetOk("tag"); ok {
-		processor.Tag = v.(string)
-	}
-	if v, ok := d.GetOk("on_failure"); ok {
-		onFailure := make([]map[string]interface{}, len(v.([]interface{})))
-		for i, f := range v.([]interface{}) {
-			item := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(f.(string))).Decode(&item); err != nil {
-				return diag.FromErr(err)
-			}
-			onFailure[i] = item
-		}
-		processor.OnFailure = onFailure
-	}
 
 	processorJson, err := json.MarshalIndent(map[string]*models.ProcessorConvert{"convert": processor}, "", " ")
 	if err != nil {
@@ -60,6 +60,8 @@ func DataSourceProcessorGeoip() *schema.Resource {
 		},
 	}
 
+	addCommonProcessorSchema(processorSchema)
+
 	return &schema.Resource{
 		Description: "The geoip processor adds information about the geographical location of an IPv4 or IPv6 address. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/geoip-processor.html",
 
@@ -74,6 +76,12 @@ func dataSourceProcessorGeoipRead(ctx context.Context, d *schema.ResourceData, m
 
 	processor := &models.ProcessorGeoip{}
 
+	common, err := expandCommonProcessor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	processor.CommonProcessor = common
+
 	processor.IgnoreMissing = d.Get("ignore_missing").(bool)
 	processor.FirstOnly = d.Get("first_only").(bool)
 	processor.Field = d.Get("field").(string)
@@ -27,6 +27,7 @@ const expectedJsonGeoip = `{
   "geoip": {
 		"field": "ip",
 		"first_only": true,
+		"ignore_failure": false,
 		"ignore_missing": false,
 		"target_field": "geoip"
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -45,38 +46,12 @@ func DataSourceProcessorScript() *schema.Resource {
 			ValidateFunc:     validation.StringIsJSON,
 			DiffSuppressFunc: utils.DiffJsonSuppress,
 		},
-		"description": {
-			Description: "Description of the processor. ",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"if": {
-			Description: "Conditionally execute the processor",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"ignore_failure": {
-			Description: "Ignore failures for the processor. ",
+		"validate": {
+			Description: "Compile the script, and the `if` condition, in the cluster when the data source is read, to catch the syntax errors during the plan. The stored script referenced by `script_id` must exist.",
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
 		},
-		"on_failure": {
-			Description: "Handle failures for the processor.",
-			Type:        schema.TypeList,
-			Optional:    true,
-			MinItems:    1,
-			Elem: &schema.Schema{
-				Type:             schema.TypeString,
-				ValidateFunc:     validation.StringIsJSON,
-				DiffSuppressFunc: utils.DiffJsonSuppress,
-			},
-		},
-		"tag": {
-			Description: "Identifier for the processor.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
 		"json": {
 			Description: "JSON representation of this data source.",
 			Type:        schema.TypeString,
@@ -84,6 +59,8 @@ func DataSourceProcessorScript() *schema.Resource {
 		},
 	}
 
+	addCommonProcessorSchema(processorSchema)
+
 	return &schema.Resource{
 		Description: "Runs an inline or stored script on incoming documents. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/script-processor.html",
 
@@ -98,7 +75,11 @@ func dataSourceProcessorScriptRead(ctx context.Context, d *schema.ResourceData,
 
 	processor := &models.ProcessorScript{}
 
-	processor.IgnoreFailure = d.Get("ignore_failure").(bool)
+	common, err := expandCommonProcessor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	processor.CommonProcessor = common
 
 	if v, ok := d.GetOk("lang"); ok {
 		processor.Lang = v.(string)
@@ -116,26 +97,6 @@ func dataSourceProcessorScriptRead(ctx context.Context, d *schema.ResourceData,
 		}
 		processor.Params = params
 	}
-	if v, ok := d.GetOk("description"); ok {
-		processor.Description = v.(string)
-	}
-	if v, ok := d.GetOk("if"); ok {
-		processor.If = v.(string)
-	}
-	if v, ok := d.GetOk("tag"); ok {
-		processor.Tag = v.(string)
-	}
-	if v, ok := d.GetOk("on_failure"); ok {
-		onFailure := make([]map[string]interface{}, len(v.([]interface{})))
-		for i, f := range v.([]interface{}) {
-			item := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(f.(string))).Decode(&item); err != nil {
-				return diag.FromErr(err)
-			}
-			onFailure[i] = item
-		}
-		processor.OnFailure = onFailure
-	}
 
 	processorJson, err := json.MarshalIndent(map[string]*models.ProcessorScript{"script": processor}, "", " ")
 	if err != nil {
@@ -145,6 +106,12 @@ func dataSourceProcessorScriptRead(ctx context.Context, d *schema.ResourceData,
 		diag.FromErr(err)
 	}
 
+	if d.Get("validate").(bool) {
+		if diags := validateScriptProcessor(ctx, meta.(*clients.ApiClient), processor); diags.HasError() {
+			return diags
+		}
+	}
+
 	hash, err := utils.StringToHash(string(processorJson))
 	if err != nil {
 		return diag.FromErr(err)
@@ -154,3 +121,26 @@ func dataSourceProcessorScriptRead(ctx context.Context, d *schema.ResourceData,
 
 	return diags
 }
+
+// Compiles the script by simulating the pipeline with the processor, the runtime errors caused by the empty test document are ignored.
+func validateScriptProcessor(ctx context.Context, client *clients.ApiClient, processor *models.ProcessorScript) diag.Diagnostics {
+	var diags diag.Diagnostics
+	processorMap := make(map[string]interface{})
+	processorJson, err := json.Marshal(processor)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(processorJson, &processorMap); err != nil {
+		return diag.FromErr(err)
+	}
+	pipeline := models.IngestPipeline{
+		Processors: []map[string]interface{}{{"script": processorMap}},
+	}
+	if _, diags := client.SimulateElasticsearchIngestPipeline(ctx, &pipeline, []map[string]interface{}{{}}); diags.HasError() {
+		for i := range diags {
+			diags[i].Summary = "Invalid script"
+		}
+		return diags
+	}
+	return diags
+}
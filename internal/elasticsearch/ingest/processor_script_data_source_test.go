@@ -1,6 +1,7 @@
 package ingest_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -18,6 +19,10 @@ func TestAccDataSourceIngestProcessorScript(t *testing.T) {
 					CheckResourceJson("data.elasticstack_elasticsearch_ingest_processor_script.test", "json", expectedJsonScript),
 				),
 			},
+			{
+				Config:      testAccDataSourceIngestProcessorScriptInvalid,
+				ExpectError: regexp.MustCompile("Invalid script"),
+			},
 		},
 	})
 }
@@ -58,3 +63,14 @@ EOF
 
 }
 `
+
+const testAccDataSourceIngestProcessorScriptInvalid = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_ingest_processor_script" "test" {
+  source   = "ctx['tags'] = ctx['env'].splitOnToken("
+  validate = true
+}
+`
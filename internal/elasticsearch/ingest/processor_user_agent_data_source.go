@@ -60,6 +60,8 @@ func DataSourceProcessorUserAgent() *schema.Resource {
 		},
 	}
 
+	addCommonProcessorSchema(processorSchema)
+
 	return &schema.Resource{
 		Description: "Extracts details from the user agent string a browser sends with its web requests. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/user-agent-processor.html",
 
@@ -74,6 +76,12 @@ func dataSourceProcessorUserAgentRead(ctx context.Context, d *schema.ResourceDat
 
 	processor := &models.ProcessorUserAgent{}
 
+	common, err := expandCommonProcessor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	processor.CommonProcessor = common
+
 	processor.Field = d.Get("field").(string)
 	processor.IgnoreMissing = d.Get("ignore_missing").(bool)
 
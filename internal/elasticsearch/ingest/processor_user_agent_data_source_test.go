@@ -26,6 +26,7 @@ func TestAccDataSourceIngestProcessorUserAgent(t *testing.T) {
 const expectedJsonUserAgent = `{
 	"user_agent": {
 		"field": "agent",
+		"ignore_failure": false,
 		"ignore_missing": false
 	}
 }`
@@ -0,0 +1,171 @@
+package ml
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// the identifiers of the machine learning objects, e.g. the calendars and the jobs
+var mlIdRegexp = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9_\-]*[a-z0-9])?$`)
+
+func ResourceCalendar() *schema.Resource {
+	calendarSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"calendar_id": {
+			Description:  "The identifier of the calendar. It can contain the lowercase alphanumeric characters, hyphens and underscores, and must start and end with an alphanumeric character.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(mlIdRegexp, "must contain only the lowercase alphanumeric characters, hyphens and underscores, and start and end with an alphanumeric character"),
+		},
+		"description": {
+			Description: "The description of the calendar.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"job_ids": {
+			Description: "The identifiers of the anomaly detection jobs or the job groups, which skip the results and the model updates during the events of the calendar. Use `_all` for all the jobs.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(calendarSchema)
+
+	return &schema.Resource{
+		Description: "Creates a machine learning calendar, which defines the scheduled events, e.g. the maintenance windows, when the anomaly detection jobs do not generate the anomalies. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/ml-put-calendar.html",
+
+		CreateContext: resourceCalendarCreate,
+		UpdateContext: resourceCalendarUpdate,
+		ReadContext:   resourceCalendarRead,
+		DeleteContext: resourceCalendarDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: calendarSchema,
+	}
+}
+
+func resourceCalendarCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	calendar := models.MlCalendar{
+		CalendarId:  d.Get("calendar_id").(string),
+		Description: d.Get("description").(string),
+		JobIds:      expandJobIds(d.Get("job_ids").(*schema.Set)),
+	}
+	id, diags := client.ID(ctx, calendar.CalendarId)
+	if diags.HasError() {
+		return diags
+	}
+
+	if diags := client.PutElasticsearchMlCalendar(ctx, &calendar); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceCalendarRead(ctx, d, meta)
+}
+
+// Only the jobs of the calendar can be changed, they are added to and removed from the existing calendar
+func resourceCalendarUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	calendarId := d.Get("calendar_id").(string)
+
+	if d.HasChange("job_ids") {
+		oldJobs, newJobs := d.GetChange("job_ids")
+		if removed := expandJobIds(oldJobs.(*schema.Set).Difference(newJobs.(*schema.Set))); len(removed) > 0 {
+			if diags := client.RemoveElasticsearchMlCalendarJobs(ctx, calendarId, removed); diags.HasError() {
+				return diags
+			}
+		}
+		if added := expandJobIds(newJobs.(*schema.Set).Difference(oldJobs.(*schema.Set))); len(added) > 0 {
+			if diags := client.AddElasticsearchMlCalendarJobs(ctx, calendarId, added); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return resourceCalendarRead(ctx, d, meta)
+}
+
+func resourceCalendarRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	calendar, diags := client.GetElasticsearchMlCalendar(ctx, compId.ResourceId)
+	if calendar == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("calendar_id", calendar.CalendarId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", calendar.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("job_ids", calendar.JobIds); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceCalendarDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchMlCalendar(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandJobIds(jobs *schema.Set) []string {
+	jobIds := make([]string, 0, jobs.Len())
+	for _, job := range jobs.List() {
+		jobIds = append(jobIds, job.(string))
+	}
+	return jobIds
+}
@@ -0,0 +1,222 @@
+package ml
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceCalendarEvent() *schema.Resource {
+	eventSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"calendar_id": {
+			Description: "The identifier of the calendar the event belongs to.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"description": {
+			Description: "The description of the event.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"start_time": {
+			Description:      "The start of the event in the RFC 3339 format, e.g. `2024-05-04T22:00:00Z`.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateFunc:     validation.IsRFC3339Time,
+			DiffSuppressFunc: suppressEqualTimes,
+		},
+		"end_time": {
+			Description:      "The end of the event in the RFC 3339 format, e.g. `2024-05-05T02:00:00Z`. It must be after the start of the event.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateFunc:     validation.IsRFC3339Time,
+			DiffSuppressFunc: suppressEqualTimes,
+		},
+		"event_id": {
+			Description: "The identifier of the event generated by Elasticsearch.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(eventSchema)
+
+	return &schema.Resource{
+		Description: "Adds a scheduled event, e.g. a maintenance window, to a machine learning calendar. The events cannot be changed, so any change replaces the event. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/ml-post-calendar-event.html",
+
+		CreateContext: resourceCalendarEventCreate,
+		// the event cannot be changed, only the connection can
+		UpdateContext: resourceCalendarEventRead,
+		ReadContext:   resourceCalendarEventRead,
+		DeleteContext: resourceCalendarEventDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: validateCalendarEventTimes,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: eventSchema,
+	}
+}
+
+// The resource identifier of the event is in the format: <calendar_id>:<event_id>
+func calendarEventIdFromStr(resourceId string) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	idParts := strings.SplitN(resourceId, ":", 2)
+	if len(idParts) != 2 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Wrong resource ID.",
+			Detail:   "Resource ID must have following format: <cluster_uuid>/<calendar_id>:<event_id>",
+		})
+		return "", "", diags
+	}
+	return idParts[0], idParts[1], diags
+}
+
+func suppressEqualTimes(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+// Fails at plan time when the event would end before it starts
+func validateCalendarEventTimes(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("start_time") || !d.NewValueKnown("end_time") {
+		return nil
+	}
+	start, err := time.Parse(time.RFC3339, d.Get("start_time").(string))
+	if err != nil {
+		return nil
+	}
+	end, err := time.Parse(time.RFC3339, d.Get("end_time").(string))
+	if err != nil {
+		return nil
+	}
+	if !end.After(start) {
+		return fmt.Errorf(`the end_time "%s" of the event must be after its start_time "%s"`, d.Get("end_time"), d.Get("start_time"))
+	}
+	return nil
+}
+
+func resourceCalendarEventCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	calendarId := d.Get("calendar_id").(string)
+	start, err := time.Parse(time.RFC3339, d.Get("start_time").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	end, err := time.Parse(time.RFC3339, d.Get("end_time").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	event := models.MlCalendarEvent{
+		Description: d.Get("description").(string),
+		StartTime:   start.UnixMilli(),
+		EndTime:     end.UnixMilli(),
+	}
+
+	created, diags := client.PostElasticsearchMlCalendarEvent(ctx, calendarId, &event)
+	if diags.HasError() {
+		return diags
+	}
+	id, diags := client.ID(ctx, fmt.Sprintf("%s:%s", calendarId, created.EventId))
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceCalendarEventRead(ctx, d, meta)
+}
+
+func resourceCalendarEventRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	calendarId, eventId, diags := calendarEventIdFromStr(compId.ResourceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	event, diags := client.GetElasticsearchMlCalendarEvent(ctx, calendarId, eventId)
+	if event == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("calendar_id", calendarId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("event_id", event.EventId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", event.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("start_time", time.UnixMilli(event.StartTime).UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("end_time", time.UnixMilli(event.EndTime).UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceCalendarEventDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	calendarId, eventId, diags := calendarEventIdFromStr(compId.ResourceId)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchMlCalendarEvent(ctx, calendarId, eventId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,94 @@
+package ml_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceMlCalendar(t *testing.T) {
+	// generate a random name
+	calendarId := strings.ToLower(sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha))
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceMlCalendarDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceMlCalendar(calendarId, "_all", "2030-01-02T00:00:00Z"),
+				ExpectError: regexp.MustCompile(`must be after its start_time`),
+			},
+			{
+				Config: testAccResourceMlCalendar(calendarId, "_all", "2030-01-01T04:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ml_calendar.test", "calendar_id", calendarId),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ml_calendar.test", "job_ids.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ml_calendar_event.test", "start_time", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ml_calendar_event.test", "end_time", "2030-01-01T04:00:00Z"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_ml_calendar_event.test", "event_id"),
+				),
+			},
+			{
+				Config: testAccResourceMlCalendar(calendarId, "", "2030-01-01T06:00:00+02:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ml_calendar.test", "job_ids.#", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ml_calendar_event.test", "end_time", "2030-01-01T04:00:00Z"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceMlCalendar(calendarId, jobId, endTime string) string {
+	jobIds := "[]"
+	if jobId != "" {
+		jobIds = fmt.Sprintf(`["%s"]`, jobId)
+	}
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ml_calendar" "test" {
+  calendar_id = "%s"
+  description = "Planned maintenance"
+  job_ids     = %s
+}
+
+resource "elasticstack_elasticsearch_ml_calendar_event" "test" {
+  calendar_id = elasticstack_elasticsearch_ml_calendar.test.calendar_id
+  description = "Upgrade of the cluster"
+  start_time  = "2030-01-01T00:00:00Z"
+  end_time    = "%s"
+}
+	`, calendarId, jobIds, endTime)
+}
+
+func checkResourceMlCalendarDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_ml_calendar" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		calendar, diags := client.GetElasticsearchMlCalendar(context.Background(), compId.ResourceId)
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get ML calendar: %v", diags)
+		}
+		if calendar != nil {
+			return fmt.Errorf("ML calendar (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
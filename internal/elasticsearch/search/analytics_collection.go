@@ -0,0 +1,116 @@
+package search
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceAnalyticsCollection() *schema.Resource {
+	collectionSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "The name of the behavioral analytics collection.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"event_data_stream": {
+			Description: "The name of the data stream the analytics events are stored in.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(collectionSchema)
+
+	return &schema.Resource{
+		Description: "Creates a behavioral analytics collection. Requires Elasticsearch 8.8 or higher. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/put-analytics-collection.html",
+
+		CreateContext: resourceAnalyticsCollectionCreate,
+		// the collection has no updatable settings, only the connection can change
+		UpdateContext: resourceAnalyticsCollectionRead,
+		ReadContext:   resourceAnalyticsCollectionRead,
+		DeleteContext: resourceAnalyticsCollectionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: collectionSchema,
+	}
+}
+
+func resourceAnalyticsCollectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	collectionName := d.Get("name").(string)
+	id, diags := client.ID(ctx, collectionName)
+	if diags.HasError() {
+		return diags
+	}
+
+	if diags := client.PutElasticsearchAnalyticsCollection(ctx, collectionName); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceAnalyticsCollectionRead(ctx, d, meta)
+}
+
+func resourceAnalyticsCollectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	collection, diags := client.GetElasticsearchAnalyticsCollection(ctx, compId.ResourceId)
+	if collection == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("name", collection.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("event_data_stream", collection.EventDataStream.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceAnalyticsCollectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchAnalyticsCollection(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,424 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceConnector() *schema.Resource {
+	scheduleSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": {
+						Description: "Whether the sync is scheduled.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+					"interval": {
+						Description:  "The schedule of the sync as the cron expression, e.g. `0 0 0 * * ?`.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "0 0 0 * * ?",
+						ValidateFunc: utils.StringIsCronExpression,
+					},
+				},
+			},
+		}
+	}
+
+	connectorSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"connector_id": {
+			Description: "The identifier of the connector.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "The name of the connector.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"description": {
+			Description: "The description of the connector.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"index_name": {
+			Description: "The name of the index the connector syncs the data into.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"service_type": {
+			Description: "The type of the third-party service the connector syncs from, e.g. `google_drive` or `sharepoint_online`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"is_native": {
+			Description: "Whether the connector is run by Elastic (native connector), instead of the self-managed connector service.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"language": {
+			Description: "The language of the synced data, used by the analyzers of the index.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"scheduling": {
+			Description: "The schedules of the syncs.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"full":           scheduleSchema("The schedule of the full content syncs."),
+					"incremental":    scheduleSchema("The schedule of the incremental content syncs."),
+					"access_control": scheduleSchema("The schedule of the access control syncs."),
+				},
+			},
+		},
+		"pipeline": {
+			Description: "The ingest pipeline settings of the synced documents.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "The name of the ingest pipeline.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "search-default-ingestion",
+					},
+					"extract_binary_content": {
+						Description: "Whether to extract the content of the binary files.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+					"reduce_whitespace": {
+						Description: "Whether to reduce the whitespace in the extracted content.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+					"run_ml_inference": {
+						Description: "Whether to run the machine learning inference processors of the pipeline.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+				},
+			},
+		},
+		"configuration": {
+			Description: "The values of the configuration fields of the connector, defined by its service type, e.g. the credentials of the third-party service.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Sensitive:   true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"status": {
+			Description: "The status of the connector, e.g. `created`, `configured` or `connected`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(connectorSchema)
+
+	return &schema.Resource{
+		Description: "Creates and configures an Elastic connector, which syncs the data from a third-party service into an index. Requires Elasticsearch 8.12 or higher. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/connector-apis.html",
+
+		CreateContext: resourceConnectorCreate,
+		UpdateContext: resourceConnectorUpdate,
+		ReadContext:   resourceConnectorRead,
+		DeleteContext: resourceConnectorDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: connectorSchema,
+	}
+}
+
+func resourceConnectorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	connectorId := d.Get("connector_id").(string)
+	id, diags := client.ID(ctx, connectorId)
+	if diags.HasError() {
+		return diags
+	}
+
+	connector := models.Connector{
+		Id:          connectorId,
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		ServiceType: d.Get("service_type").(string),
+		IsNative:    d.Get("is_native").(bool),
+		Language:    d.Get("language").(string),
+	}
+	if v, ok := d.GetOk("index_name"); ok {
+		indexName := v.(string)
+		connector.IndexName = &indexName
+	}
+	if diags := client.PutElasticsearchConnector(ctx, &connector); diags.HasError() {
+		return diags
+	}
+	d.SetId(id.String())
+
+	if diags := updateConnectorSettings(ctx, client, d, false); diags.HasError() {
+		return diags
+	}
+
+	return resourceConnectorRead(ctx, d, meta)
+}
+
+func resourceConnectorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	connectorId := d.Get("connector_id").(string)
+
+	if d.HasChanges("name", "description") {
+		body := map[string]interface{}{
+			"name":        d.Get("name").(string),
+			"description": d.Get("description").(string),
+		}
+		if diags := client.UpdateElasticsearchConnector(ctx, connectorId, "_name", body); diags.HasError() {
+			return diags
+		}
+	}
+	if d.HasChange("index_name") {
+		var indexName *string
+		if v, ok := d.GetOk("index_name"); ok {
+			name := v.(string)
+			indexName = &name
+		}
+		body := map[string]interface{}{"index_name": indexName}
+		if diags := client.UpdateElasticsearchConnector(ctx, connectorId, "_index_name", body); diags.HasError() {
+			return diags
+		}
+	}
+	if d.HasChange("service_type") {
+		body := map[string]interface{}{"service_type": d.Get("service_type").(string)}
+		if diags := client.UpdateElasticsearchConnector(ctx, connectorId, "_service_type", body); diags.HasError() {
+			return diags
+		}
+	}
+	if d.HasChange("is_native") {
+		body := map[string]interface{}{"is_native": d.Get("is_native").(bool)}
+		if diags := client.UpdateElasticsearchConnector(ctx, connectorId, "_native", body); diags.HasError() {
+			return diags
+		}
+	}
+
+	if diags := updateConnectorSettings(ctx, client, d, true); diags.HasError() {
+		return diags
+	}
+
+	return resourceConnectorRead(ctx, d, meta)
+}
+
+// Sends the scheduling, the pipeline and the configuration of the connector, which are updated with the dedicated APIs.
+// On update only the changed parts are sent.
+func updateConnectorSettings(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData, onlyChanged bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	connectorId := d.Get("connector_id").(string)
+
+	if v, ok := d.GetOk("scheduling"); ok && (!onlyChanged || d.HasChange("scheduling")) {
+		scheduling := expandConnectorScheduling(v.([]interface{})[0].(map[string]interface{}))
+		body := map[string]interface{}{"scheduling": scheduling}
+		if diags := client.UpdateElasticsearchConnector(ctx, connectorId, "_scheduling", body); diags.HasError() {
+			return diags
+		}
+	}
+	if v, ok := d.GetOk("pipeline"); ok && (!onlyChanged || d.HasChange("pipeline")) {
+		p := v.([]interface{})[0].(map[string]interface{})
+		body := map[string]interface{}{
+			"pipeline": models.ConnectorPipeline{
+				Name:                 p["name"].(string),
+				ExtractBinaryContent: p["extract_binary_content"].(bool),
+				ReduceWhitespace:     p["reduce_whitespace"].(bool),
+				RunMlInference:       p["run_ml_inference"].(bool),
+			},
+		}
+		if diags := client.UpdateElasticsearchConnector(ctx, connectorId, "_pipeline", body); diags.HasError() {
+			return diags
+		}
+	}
+	if v, ok := d.GetOk("configuration"); ok && (!onlyChanged || d.HasChange("configuration")) {
+		body := map[string]interface{}{"values": v.(map[string]interface{})}
+		if diags := client.UpdateElasticsearchConnector(ctx, connectorId, "_configuration", body); diags.HasError() {
+			return diags
+		}
+	}
+	return diags
+}
+
+func resourceConnectorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	connector, diags := client.GetElasticsearchConnector(ctx, compId.ResourceId)
+	if connector == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("connector_id", compId.ResourceId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", connector.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", connector.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if connector.IndexName != nil {
+		if err := d.Set("index_name", *connector.IndexName); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("service_type", connector.ServiceType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_native", connector.IsNative); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", connector.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Elasticsearch populates the defaults of the scheduling and the pipeline, track them only when they are configured
+	if _, ok := d.GetOk("scheduling"); ok && connector.Scheduling != nil {
+		if err := d.Set("scheduling", flattenConnectorScheduling(d, connector.Scheduling)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if _, ok := d.GetOk("pipeline"); ok && connector.Pipeline != nil {
+		pipeline := map[string]interface{}{
+			"name":                   connector.Pipeline.Name,
+			"extract_binary_content": connector.Pipeline.ExtractBinaryContent,
+			"reduce_whitespace":      connector.Pipeline.ReduceWhitespace,
+			"run_ml_inference":       connector.Pipeline.RunMlInference,
+		}
+		if err := d.Set("pipeline", []interface{}{pipeline}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	// the configuration contains all the fields of the service type, only the configured ones are tracked,
+	// and the sensitive ones keep the configured value, since they may be returned masked
+	if v, ok := d.GetOk("configuration"); ok {
+		configuration := make(map[string]interface{})
+		for k, configured := range v.(map[string]interface{}) {
+			field, ok := connector.Configuration[k]
+			if !ok {
+				continue
+			}
+			if field.Sensitive {
+				configuration[k] = configured
+			} else if field.Value != nil {
+				configuration[k] = fmt.Sprint(field.Value)
+			}
+		}
+		if err := d.Set("configuration", configuration); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceConnectorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchConnector(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandConnectorScheduling(scheduling map[string]interface{}) models.ConnectorScheduling {
+	expandSchedule := func(v interface{}) models.ConnectorSchedule {
+		// the syncs not configured are disabled
+		schedule := models.ConnectorSchedule{Enabled: false, Interval: "0 0 0 * * ?"}
+		if s := v.([]interface{}); len(s) > 0 && s[0] != nil {
+			m := s[0].(map[string]interface{})
+			schedule.Enabled = m["enabled"].(bool)
+			schedule.Interval = m["interval"].(string)
+		}
+		return schedule
+	}
+	return models.ConnectorScheduling{
+		Full:          expandSchedule(scheduling["full"]),
+		Incremental:   expandSchedule(scheduling["incremental"]),
+		AccessControl: expandSchedule(scheduling["access_control"]),
+	}
+}
+
+// Only the schedules present in the configuration are flattened, the others are disabled by the provider
+func flattenConnectorScheduling(d *schema.ResourceData, scheduling *models.ConnectorScheduling) []interface{} {
+	schedules := map[string]models.ConnectorSchedule{
+		"full":           scheduling.Full,
+		"incremental":    scheduling.Incremental,
+		"access_control": scheduling.AccessControl,
+	}
+	result := make(map[string]interface{})
+	for name, s := range schedules {
+		if _, ok := d.GetOk(fmt.Sprintf("scheduling.0.%s", name)); !ok {
+			continue
+		}
+		result[name] = []interface{}{
+			map[string]interface{}{
+				"enabled":  s.Enabled,
+				"interval": s.Interval,
+			},
+		}
+	}
+	return []interface{}{result}
+}
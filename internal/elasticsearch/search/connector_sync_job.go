@@ -0,0 +1,165 @@
+package search
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceConnectorSyncJob() *schema.Resource {
+	syncJobSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"connector_id": {
+			Description: "The identifier of the connector to sync.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"job_type": {
+			Description:  "The type of the sync: `full`, `incremental` or `access_control`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "full",
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"full", "incremental", "access_control"}, false),
+		},
+		"triggers": {
+			Description: "Arbitrary map of values that, when changed, will trigger a new sync job.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"job_id": {
+			Description: "The identifier of the sync job.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"status": {
+			Description: "The status of the sync job, e.g. `pending`, `in_progress`, `completed` or `error`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"error": {
+			Description: "The error of the failed sync job.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"indexed_document_count": {
+			Description: "The number of the documents indexed by the sync job.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"deleted_document_count": {
+			Description: "The number of the documents deleted by the sync job.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(syncJobSchema)
+
+	return &schema.Resource{
+		Description: "Triggers an on-demand sync job of an Elastic connector. Requires Elasticsearch 8.12 or higher. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/create-connector-sync-job-api.html",
+
+		CreateContext: resourceConnectorSyncJobCreate,
+		// the sync job cannot be changed, only the connection can
+		UpdateContext: resourceConnectorSyncJobRead,
+		ReadContext:   resourceConnectorSyncJobRead,
+		DeleteContext: resourceConnectorSyncJobDelete,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: syncJobSchema,
+	}
+}
+
+func resourceConnectorSyncJobCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	jobId, diags := client.CreateElasticsearchConnectorSyncJob(ctx, d.Get("connector_id").(string), d.Get("job_type").(string))
+	if diags.HasError() {
+		return diags
+	}
+	id, diags := client.ID(ctx, jobId)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceConnectorSyncJobRead(ctx, d, meta)
+}
+
+func resourceConnectorSyncJobRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	job, diags := client.GetElasticsearchConnectorSyncJob(ctx, compId.ResourceId)
+	if job == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("job_id", job.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("connector_id", job.Connector.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("job_type", job.JobType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", job.Status); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("error", job.Error); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("indexed_document_count", job.IndexedDocumentCount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("deleted_document_count", job.DeletedDocumentCount); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceConnectorSyncJobDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchConnectorSyncJob(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
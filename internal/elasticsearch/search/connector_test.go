@@ -0,0 +1,106 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceConnector(t *testing.T) {
+	// generate a random name
+	connectorId := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceConnectorDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConnectorCreate(connectorId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_connector.test", "connector_id", connectorId),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_connector.test", "name", "Test connector"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_connector.test", "index_name", fmt.Sprintf("search-%s", connectorId)),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_connector.test", "status"),
+				),
+			},
+			{
+				Config: testAccResourceConnectorUpdate(connectorId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_connector.test", "name", "Updated connector"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_connector.test", "scheduling.0.full.0.enabled", "true"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_connector.test", "scheduling.0.full.0.interval", "0 0 2 * * ?"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_connector.test", "pipeline.0.run_ml_inference", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceConnectorCreate(id string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_connector" "test" {
+  connector_id = "%[1]s"
+  name         = "Test connector"
+  index_name   = "search-%[1]s"
+  service_type = "google_drive"
+}
+	`, id)
+}
+
+func testAccResourceConnectorUpdate(id string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_connector" "test" {
+  connector_id = "%[1]s"
+  name         = "Updated connector"
+  description  = "Synced from Google Drive"
+  index_name   = "search-%[1]s"
+  service_type = "google_drive"
+
+  scheduling {
+    full {
+      enabled  = true
+      interval = "0 0 2 * * ?"
+    }
+  }
+
+  pipeline {
+    run_ml_inference = false
+  }
+}
+	`, id)
+}
+
+func checkResourceConnectorDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_connector" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		connector, diags := client.GetElasticsearchConnector(context.Background(), compId.ResourceId)
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get connector: %v", diags)
+		}
+		if connector != nil {
+			return fmt.Errorf("Connector (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceQueryRuleset() *schema.Resource {
+	criteriaSchema := map[string]*schema.Schema{
+		"type": {
+			Description:  "The type of the criteria: `exact`, `fuzzy`, `prefix`, `suffix`, `contains`, `lt`, `lte`, `gt`, `gte` or `always`.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"exact", "fuzzy", "prefix", "suffix", "contains", "lt", "lte", "gt", "gte", "always"}, false),
+		},
+		"metadata": {
+			Description: "The metadata field of the rule query to match, e.g. `user_query`. Not used with the `always` criteria.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"values": {
+			Description: "The values to match against the metadata field. Only one value must match for the criteria to be met. Not used with the `always` criteria.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	actionsSchema := map[string]*schema.Schema{
+		"ids": {
+			Description: "The IDs of the documents to pin or exclude.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"docs": {
+			Description: "The documents to pin or exclude, when the documents are searched across multiple indices.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Description: "The index of the document.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"id": {
+						Description: "The ID of the document.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+
+	rulesetSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "The name of the query ruleset.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"rule": {
+			Description: "The query rules of the ruleset, applied in the order they are defined.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"rule_id": {
+						Description: "The identifier of the query rule.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"type": {
+						Description:  "The type of the query rule: `pinned` to promote the documents to the top of the results, or `exclude` to remove them from the results.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"pinned", "exclude"}, false),
+					},
+					"criteria": {
+						Description: "The criteria the rule query must match for the rule to be applied. All the criteria must be met.",
+						Type:        schema.TypeList,
+						Required:    true,
+						MinItems:    1,
+						Elem: &schema.Resource{
+							Schema: criteriaSchema,
+						},
+					},
+					"actions": {
+						Description: "The documents the rule applies to.",
+						Type:        schema.TypeList,
+						Required:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: actionsSchema,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(rulesetSchema)
+
+	return &schema.Resource{
+		Description: "Creates or updates a query ruleset, which pins or excludes the documents in the searches using the `rule` query. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/put-query-ruleset.html",
+
+		CreateContext: resourceQueryRulesetPut,
+		UpdateContext: resourceQueryRulesetPut,
+		ReadContext:   resourceQueryRulesetRead,
+		DeleteContext: resourceQueryRulesetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: rulesetSchema,
+	}
+}
+
+func resourceQueryRulesetPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rulesetName := d.Get("name").(string)
+	id, diags := client.ID(ctx, rulesetName)
+	if diags.HasError() {
+		return diags
+	}
+
+	ruleset := models.QueryRuleset{Id: rulesetName}
+	for _, r := range d.Get("rule").([]interface{}) {
+		ruleset.Rules = append(ruleset.Rules, expandQueryRule(r.(map[string]interface{})))
+	}
+
+	if diags := client.PutElasticsearchQueryRuleset(ctx, &ruleset); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceQueryRulesetRead(ctx, d, meta)
+}
+
+func resourceQueryRulesetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	ruleset, diags := client.GetElasticsearchQueryRuleset(ctx, compId.ResourceId)
+	if ruleset == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("name", compId.ResourceId); err != nil {
+		return diag.FromErr(err)
+	}
+	rules := make([]interface{}, len(ruleset.Rules))
+	for i, r := range ruleset.Rules {
+		rules[i] = flattenQueryRule(r)
+	}
+	if err := d.Set("rule", rules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceQueryRulesetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchQueryRuleset(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandQueryRule(rule map[string]interface{}) models.QueryRule {
+	r := models.QueryRule{
+		Id:       rule["rule_id"].(string),
+		Type:     rule["type"].(string),
+		Criteria: make([]models.QueryRuleCriteria, 0),
+	}
+	for _, c := range rule["criteria"].([]interface{}) {
+		criteria := c.(map[string]interface{})
+		rc := models.QueryRuleCriteria{
+			Type:     criteria["type"].(string),
+			Metadata: criteria["metadata"].(string),
+		}
+		for _, v := range criteria["values"].([]interface{}) {
+			rc.Values = append(rc.Values, v)
+		}
+		r.Criteria = append(r.Criteria, rc)
+	}
+	// there is always exactly one actions block
+	actions := rule["actions"].([]interface{})[0].(map[string]interface{})
+	for _, id := range actions["ids"].([]interface{}) {
+		r.Actions.Ids = append(r.Actions.Ids, id.(string))
+	}
+	for _, d := range actions["docs"].([]interface{}) {
+		doc := d.(map[string]interface{})
+		r.Actions.Docs = append(r.Actions.Docs, models.QueryRuleActionDoc{
+			Index: doc["index"].(string),
+			Id:    doc["id"].(string),
+		})
+	}
+	return r
+}
+
+func flattenQueryRule(rule models.QueryRule) map[string]interface{} {
+	criteria := make([]interface{}, len(rule.Criteria))
+	for i, c := range rule.Criteria {
+		// the numeric values used with the range criteria are returned as numbers
+		values := make([]string, len(c.Values))
+		for j, v := range c.Values {
+			values[j] = fmt.Sprint(v)
+		}
+		criteria[i] = map[string]interface{}{
+			"type":     c.Type,
+			"metadata": c.Metadata,
+			"values":   values,
+		}
+	}
+	docs := make([]interface{}, len(rule.Actions.Docs))
+	for i, d := range rule.Actions.Docs {
+		docs[i] = map[string]interface{}{
+			"index": d.Index,
+			"id":    d.Id,
+		}
+	}
+	return map[string]interface{}{
+		"rule_id":  rule.Id,
+		"type":     rule.Type,
+		"criteria": criteria,
+		"actions": []interface{}{
+			map[string]interface{}{
+				"ids":  rule.Actions.Ids,
+				"docs": docs,
+			},
+		},
+	}
+}
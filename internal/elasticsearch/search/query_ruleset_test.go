@@ -0,0 +1,132 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceQueryRuleset(t *testing.T) {
+	// generate a random name
+	rulesetName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceQueryRulesetDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceQueryRulesetCreate(rulesetName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_query_ruleset.test", "name", rulesetName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_query_ruleset.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_query_ruleset.test", "rule.0.actions.0.ids.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceQueryRulesetUpdate(rulesetName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_query_ruleset.test", "rule.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_query_ruleset.test", "rule.1.criteria.0.type", "always"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_query_ruleset.test", "rule.1.actions.0.docs.0.index", "products"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceQueryRulesetCreate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_query_ruleset" "test" {
+  name = "%s"
+
+  rule {
+    rule_id = "promote-laptops"
+    type    = "pinned"
+
+    criteria {
+      type     = "contains"
+      metadata = "user_query"
+      values   = ["laptop", "notebook"]
+    }
+
+    actions {
+      ids = ["id1", "id2"]
+    }
+  }
+}
+	`, name)
+}
+
+func testAccResourceQueryRulesetUpdate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_query_ruleset" "test" {
+  name = "%s"
+
+  rule {
+    rule_id = "promote-laptops"
+    type    = "pinned"
+
+    criteria {
+      type     = "contains"
+      metadata = "user_query"
+      values   = ["laptop", "notebook"]
+    }
+
+    actions {
+      ids = ["id1", "id2"]
+    }
+  }
+
+  rule {
+    rule_id = "always-promote-sale"
+    type    = "pinned"
+
+    criteria {
+      type = "always"
+    }
+
+    actions {
+      docs {
+        index = "products"
+        id    = "sale"
+      }
+    }
+  }
+}
+	`, name)
+}
+
+func checkResourceQueryRulesetDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_query_ruleset" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		ruleset, diags := client.GetElasticsearchQueryRuleset(context.Background(), compId.ResourceId)
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get query ruleset: %v", diags)
+		}
+		if ruleset != nil {
+			return fmt.Errorf("Query ruleset (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceSearchApplication() *schema.Resource {
+	searchApplicationSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "The name of the search application.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"indices": {
+			Description: "The indices associated with this search application. All indices need to exist in order to be added to a search application.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"analytics_collection_name": {
+			Description: "The analytics collection associated to the search application.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"template": {
+			Description:      "The search template associated with the search application, serialized as JSON. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/search-application-api.html",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"updated_at_millis": {
+			Description: "Last time the search application was updated, in milliseconds since the epoch.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(searchApplicationSchema)
+
+	return &schema.Resource{
+		Description: "Creates or updates a search application. Requires Elasticsearch 8.8 or higher. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/put-search-application.html",
+
+		CreateContext: resourceSearchApplicationPut,
+		UpdateContext: resourceSearchApplicationPut,
+		ReadContext:   resourceSearchApplicationRead,
+		DeleteContext: resourceSearchApplicationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: searchApplicationSchema,
+	}
+}
+
+func resourceSearchApplicationPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	appName := d.Get("name").(string)
+	id, diags := client.ID(ctx, appName)
+	if diags.HasError() {
+		return diags
+	}
+
+	var app models.SearchApplication
+	app.Name = appName
+	indices := make([]string, 0)
+	for _, i := range d.Get("indices").(*schema.Set).List() {
+		indices = append(indices, i.(string))
+	}
+	app.Indices = indices
+	if v, ok := d.GetOk("analytics_collection_name"); ok {
+		app.AnalyticsCollectionName = v.(string)
+	}
+	if v, ok := d.GetOk("template"); ok {
+		template := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(v.(string)), &template); err != nil {
+			return diag.FromErr(err)
+		}
+		app.Template = template
+	}
+
+	if diags := client.PutElasticsearchSearchApplication(ctx, &app); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceSearchApplicationRead(ctx, d, meta)
+}
+
+func resourceSearchApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	app, diags := client.GetElasticsearchSearchApplication(ctx, compId.ResourceId)
+	if app == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("name", app.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("indices", app.Indices); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("analytics_collection_name", app.AnalyticsCollectionName); err != nil {
+		return diag.FromErr(err)
+	}
+	if app.Template != nil {
+		template, err := json.Marshal(app.Template)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("template", string(template)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("updated_at_millis", app.UpdatedAtMillis); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceSearchApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchSearchApplication(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
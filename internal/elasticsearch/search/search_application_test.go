@@ -0,0 +1,131 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceSearchApplication(t *testing.T) {
+	// generate a random name
+	appName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceSearchApplicationDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSearchApplicationCreate(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_search_application.test", "name", appName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_search_application.test", "indices.#", "1"),
+				),
+			},
+			{
+				Config: testAccResourceSearchApplicationUpdate(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_search_application.test", "name", appName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_search_application.test", "indices.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_search_application.test", "analytics_collection_name", appName),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_analytics_collection.test", "event_data_stream"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSearchApplicationCreate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_one" {
+  name = "%[1]s-one"
+}
+
+resource "elasticstack_elasticsearch_search_application" "test" {
+  name    = "%[1]s"
+  indices = [elasticstack_elasticsearch_index.test_one.name]
+}
+	`, name)
+}
+
+func testAccResourceSearchApplicationUpdate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_one" {
+  name = "%[1]s-one"
+}
+
+resource "elasticstack_elasticsearch_index" "test_two" {
+  name = "%[1]s-two"
+}
+
+resource "elasticstack_elasticsearch_analytics_collection" "test" {
+  name = "%[1]s"
+}
+
+resource "elasticstack_elasticsearch_search_application" "test" {
+  name    = "%[1]s"
+  indices = [
+    elasticstack_elasticsearch_index.test_one.name,
+    elasticstack_elasticsearch_index.test_two.name,
+  ]
+  analytics_collection_name = elasticstack_elasticsearch_analytics_collection.test.name
+
+  template = jsonencode({
+    script = {
+      source = {
+        query = {
+          query_string = {
+            query = "{{query_string}}"
+          }
+        }
+      }
+      params = {
+        query_string = "*"
+      }
+    }
+  })
+}
+	`, name)
+}
+
+func checkResourceSearchApplicationDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		switch rs.Type {
+		case "elasticstack_elasticsearch_search_application":
+			app, diags := client.GetElasticsearchSearchApplication(context.Background(), compId.ResourceId)
+			if diags.HasError() {
+				return fmt.Errorf("Failed to get search application: %v", diags)
+			}
+			if app != nil {
+				return fmt.Errorf("Search application (%s) still exists", compId.ResourceId)
+			}
+		case "elasticstack_elasticsearch_analytics_collection":
+			collection, diags := client.GetElasticsearchAnalyticsCollection(context.Background(), compId.ResourceId)
+			if diags.HasError() {
+				return fmt.Errorf("Failed to get analytics collection: %v", diags)
+			}
+			if collection != nil {
+				return fmt.Errorf("Analytics collection (%s) still exists", compId.ResourceId)
+			}
+		}
+	}
+	return nil
+}
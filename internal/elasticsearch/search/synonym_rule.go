@@ -0,0 +1,166 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceSynonymRule() *schema.Resource {
+	synonymRuleSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"set_id": {
+			Description: "The name of the synonyms set the rule belongs to. The set is created if it does not exist.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"rule_id": {
+			Description: "The identifier of the synonym rule.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"synonyms": {
+			Description: "The synonyms of the rule in the Solr format, e.g. `hello, hi` or `i-pod, i pod => ipod`.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"reloaded_indices": {
+			Description: "The indices which search analyzers were reloaded after the last change of the rule.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(synonymRuleSchema)
+
+	return &schema.Resource{
+		Description: "Creates or updates a single synonym rule in a synonyms set. The search analyzers using the set are reloaded on every change, without closing the indices. Requires Elasticsearch 8.10 or higher. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/put-synonym-rule.html",
+
+		CreateContext: resourceSynonymRulePut,
+		UpdateContext: resourceSynonymRulePut,
+		ReadContext:   resourceSynonymRuleRead,
+		DeleteContext: resourceSynonymRuleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: synonymRuleSchema,
+	}
+}
+
+// The resource identifier of the rule is in the format: <set_id>:<rule_id>
+func synonymRuleIdFromStr(resourceId string) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	idParts := strings.SplitN(resourceId, ":", 2)
+	if len(idParts) != 2 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Wrong resource ID.",
+			Detail:   "Resource ID must have following format: <cluster_uuid>/<set_id>:<rule_id>",
+		})
+		return "", "", diags
+	}
+	return idParts[0], idParts[1], diags
+}
+
+func resourceSynonymRulePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setId := d.Get("set_id").(string)
+	rule := models.SynonymRule{
+		Id:       d.Get("rule_id").(string),
+		Synonyms: d.Get("synonyms").(string),
+	}
+	id, diags := client.ID(ctx, fmt.Sprintf("%s:%s", setId, rule.Id))
+	if diags.HasError() {
+		return diags
+	}
+
+	result, diags := client.PutElasticsearchSynonymRule(ctx, setId, &rule)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("reloaded_indices", reloadedIndices(result)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return resourceSynonymRuleRead(ctx, d, meta)
+}
+
+func resourceSynonymRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	setId, ruleId, diags := synonymRuleIdFromStr(compId.ResourceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	rule, diags := client.GetElasticsearchSynonymRule(ctx, setId, ruleId)
+	if rule == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("set_id", setId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("rule_id", rule.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("synonyms", rule.Synonyms); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceSynonymRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	setId, ruleId, diags := synonymRuleIdFromStr(compId.ResourceId)
+	if diags.HasError() {
+		return diags
+	}
+	if _, diags := client.DeleteElasticsearchSynonymRule(ctx, setId, ruleId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,166 @@
+package search
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceSynonymsSet() *schema.Resource {
+	synonymsSetSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "The name of the synonyms set.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"rule": {
+			Description: "The synonym rules of the set.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description: "The identifier of the synonym rule.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"synonyms": {
+						Description: "The synonyms of the rule in the Solr format, e.g. `hello, hi` or `i-pod, i pod => ipod`.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+				},
+			},
+		},
+		"reloaded_indices": {
+			Description: "The indices which search analyzers were reloaded after the last change of the set.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(synonymsSetSchema)
+
+	return &schema.Resource{
+		Description: "Creates or updates a synonyms set. The search analyzers using the set are reloaded on every change, without closing the indices. Requires Elasticsearch 8.10 or higher. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/put-synonyms-set.html",
+
+		CreateContext: resourceSynonymsSetPut,
+		UpdateContext: resourceSynonymsSetPut,
+		ReadContext:   resourceSynonymsSetRead,
+		DeleteContext: resourceSynonymsSetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: synonymsSetSchema,
+	}
+}
+
+func resourceSynonymsSetPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setName := d.Get("name").(string)
+	id, diags := client.ID(ctx, setName)
+	if diags.HasError() {
+		return diags
+	}
+
+	set := models.SynonymsSet{Id: setName, Rules: make([]models.SynonymRule, 0)}
+	for _, r := range d.Get("rule").(*schema.Set).List() {
+		rule := r.(map[string]interface{})
+		set.Rules = append(set.Rules, models.SynonymRule{
+			Id:       rule["id"].(string),
+			Synonyms: rule["synonyms"].(string),
+		})
+	}
+
+	result, diags := client.PutElasticsearchSynonymsSet(ctx, &set)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("reloaded_indices", reloadedIndices(result)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return resourceSynonymsSetRead(ctx, d, meta)
+}
+
+func resourceSynonymsSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	set, diags := client.GetElasticsearchSynonymsSet(ctx, compId.ResourceId)
+	if set == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("name", set.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	rules := make([]interface{}, len(set.Rules))
+	for i, r := range set.Rules {
+		rules[i] = map[string]interface{}{
+			"id":       r.Id,
+			"synonyms": r.Synonyms,
+		}
+	}
+	if err := d.Set("rule", rules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceSynonymsSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := client.DeleteElasticsearchSynonymsSet(ctx, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func reloadedIndices(result *models.SynonymsUpdateResult) []string {
+	indices := make([]string, 0)
+	for _, r := range result.ReloadAnalyzersDetails.ReloadDetails {
+		indices = append(indices, r.Index)
+	}
+	return indices
+}
@@ -0,0 +1,112 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceSynonymsSet(t *testing.T) {
+	// generate a random name
+	setName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceSynonymsSetDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSynonymsSetCreate(setName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_synonyms_set.test", "name", setName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_synonyms_set.test", "rule.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_synonym_rule.test", "synonyms", "laptop, notebook"),
+				),
+			},
+			{
+				Config: testAccResourceSynonymsSetUpdate(setName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_synonyms_set.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_synonym_rule.test", "synonyms", "laptop, notebook, portable"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSynonymsSetCreate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_synonyms_set" "test" {
+  name = "%[1]s"
+
+  rule {
+    id       = "greetings"
+    synonyms = "hello, hi"
+  }
+
+  rule {
+    id       = "ipod"
+    synonyms = "i-pod, i pod => ipod"
+  }
+}
+
+resource "elasticstack_elasticsearch_synonym_rule" "test" {
+  set_id   = "%[1]s-rules"
+  rule_id  = "laptop"
+  synonyms = "laptop, notebook"
+}
+	`, name)
+}
+
+func testAccResourceSynonymsSetUpdate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_synonyms_set" "test" {
+  name = "%[1]s"
+
+  rule {
+    id       = "greetings"
+    synonyms = "hello, hi, hey"
+  }
+}
+
+resource "elasticstack_elasticsearch_synonym_rule" "test" {
+  set_id   = "%[1]s-rules"
+  rule_id  = "laptop"
+  synonyms = "laptop, notebook, portable"
+}
+	`, name)
+}
+
+func checkResourceSynonymsSetDestroy(s *terraform.State) error {
+	client := acctest.Provider.Meta().(*clients.ApiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_synonyms_set" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		set, diags := client.GetElasticsearchSynonymsSet(context.Background(), compId.ResourceId)
+		if diags.HasError() {
+			return fmt.Errorf("Failed to get synonyms set: %v", diags)
+		}
+		if set != nil {
+			return fmt.Errorf("Synonyms set (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package security
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceApiKeyInvalidation() *schema.Resource {
+	invalidationSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ids": {
+			Description:   "The IDs of the API keys to invalidate.",
+			Type:          schema.TypeSet,
+			Optional:      true,
+			ForceNew:      true,
+			MinItems:      1,
+			ConflictsWith: []string{"name", "realm_name", "username"},
+			AtLeastOneOf:  []string{"ids", "name", "realm_name", "username", "owner"},
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"name": {
+			Description: "Invalidate the API keys with the name, wildcards are supported, e.g. `ci-*`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"realm_name": {
+			Description:   "Invalidate the API keys owned by the users of the realm.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"owner"},
+		},
+		"username": {
+			Description:   "Invalidate the API keys owned by the user.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"owner"},
+		},
+		"owner": {
+			Description: "Invalidate only the API keys owned by the user of the provider.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+		},
+		"triggers": {
+			Description: "Arbitrary map of values that, when changed, will invalidate the API keys again.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"invalidated_api_keys": {
+			Description: "The IDs of the API keys invalidated by the resource.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"invalidated_count": {
+			Description: "The number of the API keys invalidated by the resource.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"previously_invalidated_api_keys": {
+			Description: "The IDs of the matching API keys, which were already invalidated.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"previously_invalidated_count": {
+			Description: "The number of the matching API keys, which were already invalidated.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(invalidationSchema)
+
+	return &schema.Resource{
+		Description: "Invalidates the API keys, e.g. the leaked ones. Destroying the resource doesn't restore the API keys. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-invalidate-api-key.html",
+
+		CreateContext: resourceApiKeyInvalidationCreate,
+		// the invalidation cannot be changed, only the connection can
+		UpdateContext: resourceApiKeyInvalidationRead,
+		ReadContext:   resourceApiKeyInvalidationRead,
+		DeleteContext: resourceApiKeyInvalidationDelete,
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: invalidationSchema,
+	}
+}
+
+func resourceApiKeyInvalidationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	invalidation := models.ApiKeyInvalidation{
+		Name:      d.Get("name").(string),
+		RealmName: d.Get("realm_name").(string),
+		Username:  d.Get("username").(string),
+		Owner:     d.Get("owner").(bool),
+	}
+	for _, id := range d.Get("ids").(*schema.Set).List() {
+		invalidation.Ids = append(invalidation.Ids, id.(string))
+	}
+
+	response, diags := client.InvalidateElasticsearchApiKeysMatching(ctx, &invalidation)
+	if diags.HasError() {
+		return diags
+	}
+
+	name := invalidation.Name
+	if name == "" {
+		name = "api-key-invalidation"
+	}
+	id, diags := client.ID(ctx, name)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("invalidated_api_keys", response.InvalidatedApiKeys); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("invalidated_count", len(response.InvalidatedApiKeys)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("previously_invalidated_api_keys", response.PreviouslyInvalidatedApiKeys); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("previously_invalidated_count", len(response.PreviouslyInvalidatedApiKeys)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id.String())
+	return resourceApiKeyInvalidationRead(ctx, d, meta)
+}
+
+// The invalidation is done once the request succeeds, there is nothing to read back
+func resourceApiKeyInvalidationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+// The invalidated API keys cannot be restored
+func resourceApiKeyInvalidationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,42 @@
+package security_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSecurityApiKeyInvalidation(t *testing.T) {
+	keyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecurityApiKeyInvalidation(keyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key_invalidation.test", "id"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key_invalidation.test", "invalidated_count", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key_invalidation.test", "previously_invalidated_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityApiKeyInvalidation(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_api_key_invalidation" "test" {
+  name     = "%s-*"
+  username = "elastic"
+}
+	`, name)
+}
@@ -0,0 +1,234 @@
+package security
+
+import (
+	"context"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceApiKeyUsage() *schema.Resource {
+	usageSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"username": {
+			Description: "Only report the API keys owned by this user.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"realm_name": {
+			Description: "Only report the API keys owned by the users of this realm.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"stale_after": {
+			Description:  "The API key is considered stale, if it has not been used (or, when the usage is unknown, created) for longer than this time value, e.g. `90d`.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"audit": {
+			Description: "Derive the last usage of the API keys from the audit logs indexed into the cluster, e.g. by Filebeat or Elastic Agent.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Description: "Name of the index, alias or data stream (wildcards are supported) containing the audit logs.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"api_key_id_field": {
+						Description: "Field of the audit event holding the ID of the authenticated API key.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "elasticsearch.audit.authentication.api_key.id",
+					},
+					"timestamp_field": {
+						Description: "Field of the audit event holding the time of the event.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "@timestamp",
+					},
+				},
+			},
+		},
+		"api_keys": {
+			Description: "The active (not invalidated) API keys.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description: "ID of the API key.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"name": {
+						Description: "Name of the API key.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"username": {
+						Description: "Owner of the API key.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"realm": {
+						Description: "Realm of the owner of the API key.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"creation": {
+						Description: "Creation time of the API key (RFC 3339).",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"expiration": {
+						Description: "Expiration time of the API key (RFC 3339), empty if the API key never expires.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"last_used": {
+						Description: "Time of the last authentication with the API key found in the audit logs (RFC 3339), empty if unknown.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"expired": {
+						Description: "Whether the API key is already expired.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"stale": {
+						Description: "Whether the API key is expired or has not been used for longer than `stale_after`.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"stale_ids": {
+			Description: "IDs of the stale API keys.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(usageSchema)
+
+	return &schema.Resource{
+		Description: "Reports the usage of the API keys in the cluster to find the stale credentials. The last usage of the API keys is derived from the audit logs, when those are indexed into the cluster. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-query-api-key.html",
+
+		ReadContext: dataSourceSecurityApiKeyUsageRead,
+
+		Schema: usageSchema,
+	}
+}
+
+func dataSourceSecurityApiKeyUsageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "api-key-usage")
+	if diags.HasError() {
+		return diags
+	}
+
+	staleAfter, err := utils.ParseElasticDuration(d.Get("stale_after").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filters := []interface{}{
+		map[string]interface{}{"term": map[string]interface{}{"invalidated": false}},
+	}
+	if v, ok := d.GetOk("username"); ok {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"username": v.(string)}})
+	}
+	if v, ok := d.GetOk("realm_name"); ok {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"realm": v.(string)}})
+	}
+	apiKeys, diags := client.QueryElasticsearchApiKeys(ctx, map[string]interface{}{"bool": map[string]interface{}{"filter": filters}})
+	if diags.HasError() {
+		return diags
+	}
+
+	lastUsed := make(map[string]time.Time)
+	if v, ok := d.GetOk("audit"); ok && len(apiKeys) > 0 {
+		audit := v.([]interface{})[0].(map[string]interface{})
+		ids := make([]string, len(apiKeys))
+		for i, apiKey := range apiKeys {
+			ids[i] = apiKey.Id
+		}
+		lastUsed, diags = client.GetElasticsearchApiKeysLastUsed(ctx, audit["index"].(string), audit["api_key_id_field"].(string), audit["timestamp_field"].(string), ids)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	now := time.Now().UTC()
+	keys := make([]interface{}, len(apiKeys))
+	staleIds := make([]string, 0)
+	for i, apiKey := range apiKeys {
+		key, stale := flattenApiKeyUsage(apiKey, lastUsed, staleAfter, now)
+		if stale {
+			staleIds = append(staleIds, apiKey.Id)
+		}
+		keys[i] = key
+	}
+
+	if err := d.Set("api_keys", keys); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("stale_ids", staleIds); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+func flattenApiKeyUsage(apiKey models.ApiKey, lastUsed map[string]time.Time, staleAfter time.Duration, now time.Time) (map[string]interface{}, bool) {
+	creation := time.UnixMilli(apiKey.Creation).UTC()
+	key := map[string]interface{}{
+		"id":         apiKey.Id,
+		"name":       apiKey.Name,
+		"username":   apiKey.Username,
+		"realm":      apiKey.Realm,
+		"creation":   creation.Format(time.RFC3339),
+		"expiration": "",
+		"last_used":  "",
+		"expired":    false,
+	}
+
+	expired := false
+	if apiKey.Expiration > 0 {
+		expiration := time.UnixMilli(apiKey.Expiration).UTC()
+		key["expiration"] = expiration.Format(time.RFC3339)
+		expired = expiration.Before(now)
+	}
+	key["expired"] = expired
+
+	// without the known usage the API key can only be considered stale by its age
+	lastActivity := creation
+	if t, ok := lastUsed[apiKey.Id]; ok {
+		lastActivity = t
+		key["last_used"] = t.Format(time.RFC3339)
+	}
+	stale := expired || now.Sub(lastActivity) > staleAfter
+	key["stale"] = stale
+	return key, stale
+}
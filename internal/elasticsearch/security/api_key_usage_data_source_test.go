@@ -0,0 +1,37 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityApiKeyUsage(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityApiKeyUsage,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_api_key_usage.test", "id"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_api_key_usage.test", "username", "elastic"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_api_key_usage.test", "api_keys.#"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_api_key_usage.test", "stale_ids.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSecurityApiKeyUsage = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_api_key_usage" "test" {
+  username    = "elastic"
+  stale_after = "90d"
+}
+`
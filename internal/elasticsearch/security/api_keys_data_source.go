@@ -0,0 +1,211 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceApiKeys() *schema.Resource {
+	apiKeysSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Only return the API keys with this name, wildcards (`*` and `?`) are supported.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"username": {
+			Description: "Only return the API keys owned by this user.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"realm_name": {
+			Description: "Only return the API keys owned by the users of this realm.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"metadata": {
+			Description: "Only return the API keys having all these values in their metadata.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"include_invalidated": {
+			Description: "Also return the invalidated API keys.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"api_keys": {
+			Description: "The API keys matching the filters, in the order of their creation.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description: "ID of the API key.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"name": {
+						Description: "Name of the API key.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"username": {
+						Description: "Owner of the API key.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"realm": {
+						Description: "Realm of the owner of the API key.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"creation": {
+						Description: "Creation time of the API key (RFC 3339).",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"expiration": {
+						Description: "Expiration time of the API key (RFC 3339), empty if the API key never expires.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"invalidated": {
+						Description: "Whether the API key is invalidated.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"metadata": {
+						Description: "Metadata of the API key as JSON.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"ids": {
+			Description: "IDs of the API keys matching the filters.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(apiKeysSchema)
+
+	return &schema.Resource{
+		Description: "Returns the API keys of the cluster matching the filters, e.g. to inventory the credentials. All the matching API keys are returned, however many there are. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-query-api-key.html",
+
+		ReadContext: dataSourceSecurityApiKeysRead,
+
+		Schema: apiKeysSchema,
+	}
+}
+
+func dataSourceSecurityApiKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "api-keys")
+	if diags.HasError() {
+		return diags
+	}
+
+	apiKeys, diags := client.QueryElasticsearchApiKeys(ctx, expandApiKeysQuery(d))
+	if diags.HasError() {
+		return diags
+	}
+
+	keys := make([]interface{}, len(apiKeys))
+	ids := make([]string, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		key, err := flattenApiKey(apiKey)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		keys[i] = key
+		ids[i] = apiKey.Id
+	}
+
+	if err := d.Set("api_keys", keys); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// Renders the filters of the data source as the query of the API keys
+func expandApiKeysQuery(d *schema.ResourceData) map[string]interface{} {
+	filters := make([]interface{}, 0)
+	term := func(field string, value interface{}) {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{field: value}})
+	}
+	if !d.Get("include_invalidated").(bool) {
+		term("invalidated", false)
+	}
+	if v, ok := d.GetOk("name"); ok {
+		if name := v.(string); strings.ContainsAny(name, "*?") {
+			filters = append(filters, map[string]interface{}{"wildcard": map[string]interface{}{"name": name}})
+		} else {
+			term("name", name)
+		}
+	}
+	if v, ok := d.GetOk("username"); ok {
+		term("username", v.(string))
+	}
+	if v, ok := d.GetOk("realm_name"); ok {
+		term("realm", v.(string))
+	}
+	for k, v := range d.Get("metadata").(map[string]interface{}) {
+		term(fmt.Sprintf("metadata.%s", k), v)
+	}
+	return map[string]interface{}{"bool": map[string]interface{}{"filter": filters}}
+}
+
+func flattenApiKey(apiKey models.ApiKey) (map[string]interface{}, error) {
+	key := map[string]interface{}{
+		"id":          apiKey.Id,
+		"name":        apiKey.Name,
+		"username":    apiKey.Username,
+		"realm":       apiKey.Realm,
+		"creation":    time.UnixMilli(apiKey.Creation).UTC().Format(time.RFC3339),
+		"expiration":  "",
+		"invalidated": apiKey.Invalidated,
+		"metadata":    "{}",
+	}
+	if apiKey.Expiration > 0 {
+		key["expiration"] = time.UnixMilli(apiKey.Expiration).UTC().Format(time.RFC3339)
+	}
+	if len(apiKey.Metadata) > 0 {
+		metadata, err := json.Marshal(apiKey.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		key["metadata"] = string(metadata)
+	}
+	return key, nil
+}
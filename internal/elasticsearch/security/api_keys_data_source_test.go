@@ -0,0 +1,38 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityApiKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityApiKeys,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_api_keys.test", "id"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_api_keys.test", "username", "elastic"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_api_keys.test", "include_invalidated", "false"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_api_keys.test", "api_keys.#"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_api_keys.test", "ids.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSecurityApiKeys = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_api_keys" "test" {
+  name     = "*"
+  username = "elastic"
+}
+`
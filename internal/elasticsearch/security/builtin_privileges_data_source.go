@@ -0,0 +1,83 @@
+package security
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceBuiltinPrivileges() *schema.Resource {
+	privilegesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"cluster": {
+			Description: "The cluster privileges supported by the cluster.",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"index": {
+			Description: "The index privileges supported by the cluster.",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"remote_cluster": {
+			Description: "The remote cluster privileges supported by the cluster, empty before Elasticsearch 8.15.",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(privilegesSchema)
+
+	return &schema.Resource{
+		Description: "Gets the cluster and index privileges supported by the version of the cluster, e.g. to validate the privileges of the roles before they're applied. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-get-builtin-privileges.html",
+
+		ReadContext: dataSourceBuiltinPrivilegesRead,
+
+		Schema: privilegesSchema,
+	}
+}
+
+func dataSourceBuiltinPrivilegesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "builtin-privileges")
+	if diags.HasError() {
+		return diags
+	}
+
+	privileges, diags := client.GetElasticsearchBuiltinPrivileges(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("cluster", privileges.Cluster); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("index", privileges.Index); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("remote_cluster", privileges.RemoteCluster); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
@@ -0,0 +1,57 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security/roledescriptor"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRoleDescriptor() *schema.Resource {
+	descriptorSchema := utils.MergeSchemaMaps(map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"json": {
+			Description: "JSON representation of the role descriptor.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}, roledescriptor.Schema(), roledescriptor.RestrictionSchema())
+
+	return &schema.Resource{
+		Description: "Helper data source to render the role descriptor, e.g. to be used in the `role_descriptors` of the API keys. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/defining-roles.html#defining-roles",
+
+		ReadContext: dataSourceSecurityRoleDescriptorRead,
+
+		Schema: descriptorSchema,
+	}
+}
+
+func dataSourceSecurityRoleDescriptorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	role, diags := roledescriptor.Expand(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	roleJson, err := json.MarshalIndent(role, "", " ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("json", string(roleJson)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash, err := utils.StringToHash(string(roleJson))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*hash)
+	return diags
+}
@@ -0,0 +1,115 @@
+package security_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityRoleDescriptor(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityRoleDescriptor,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_role_descriptor.test", "cluster.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_role_descriptor.test", "json", expectedJsonRoleDescriptor),
+				),
+			},
+			{
+				Config: testAccDataSourceSecurityRoleDescriptorRestriction,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_role_descriptor.test", "json", expectedJsonRoleDescriptorRestriction),
+				),
+			},
+			{
+				Config:      testAccDataSourceSecurityRoleDescriptorInvalidWorkflow,
+				ExpectError: regexp.MustCompile("expected restriction.0.workflows.* to be one of"),
+			},
+		},
+	})
+}
+
+const expectedJsonRoleDescriptor = `{
+ "cluster": [
+  "monitor"
+ ],
+ "indices": [
+  {
+   "names": [
+    "logs-*"
+   ],
+   "privileges": [
+    "read"
+   ]
+  }
+ ]
+}`
+
+const testAccDataSourceSecurityRoleDescriptor = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_descriptor" "test" {
+  cluster = ["monitor"]
+
+  indices {
+    names      = ["logs-*"]
+    privileges = ["read"]
+  }
+}
+`
+
+const expectedJsonRoleDescriptorRestriction = `{
+ "indices": [
+  {
+   "names": [
+    "my-search-app"
+   ],
+   "privileges": [
+    "read"
+   ]
+  }
+ ],
+ "restriction": {
+  "workflows": [
+   "search_application_query"
+  ]
+ }
+}`
+
+const testAccDataSourceSecurityRoleDescriptorRestriction = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_descriptor" "test" {
+  indices {
+    names      = ["my-search-app"]
+    privileges = ["read"]
+  }
+
+  restriction {
+    workflows = ["search_application_query"]
+  }
+}
+`
+
+const testAccDataSourceSecurityRoleDescriptorInvalidWorkflow = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_descriptor" "test" {
+  cluster = ["monitor"]
+
+  restriction {
+    workflows = ["unknown_workflow"]
+  }
+}
+`
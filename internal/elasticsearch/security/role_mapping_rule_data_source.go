@@ -0,0 +1,237 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The user fields the role mapping rules can match, besides the `metadata.*` fields
+var roleMappingRuleFields = []string{"username", "dn", "groups", "realm.name"}
+
+// The rules which can be composed by the data source
+var roleMappingRuleTypes = []string{"any", "all", "field", "except"}
+
+func DataSourceRoleMappingRule() *schema.Resource {
+	ruleSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"any": {
+			Description: "The rules as JSON, e.g. the `json` of the other `elasticstack_elasticsearch_security_role_mapping_rule` data sources, where at least one of them must match.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: utils.DiffJsonSuppress,
+			},
+			ExactlyOneOf: []string{"any", "all", "field", "except"},
+		},
+		"all": {
+			Description: "The rules as JSON, e.g. the `json` of the other `elasticstack_elasticsearch_security_role_mapping_rule` data sources, where all of them must match.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: utils.DiffJsonSuppress,
+			},
+			ExactlyOneOf: []string{"any", "all", "field", "except"},
+		},
+		"field": {
+			Description: "Matches the field of the user against the values.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "The field of the user, one of `username`, `dn`, `groups`, `realm.name` or `metadata.*`.",
+						Type:        schema.TypeString,
+						Required:    true,
+						ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+							if err := validateRoleMappingRuleField(v.(string)); err != nil {
+								return nil, []error{fmt.Errorf("%s: %w", k, err)}
+							}
+							return nil, nil
+						},
+					},
+					"values": {
+						Description: "The values, where at least one of them must match. The values support the wildcards `*`, the regular expressions enclosed in `/` and the DN patterns.",
+						Type:        schema.TypeList,
+						Required:    true,
+						MinItems:    1,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+			ExactlyOneOf: []string{"any", "all", "field", "except"},
+		},
+		"except": {
+			Description:      "The rule as JSON, which must not match. The `except` rule can only be used within the `all` rule.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ExactlyOneOf:     []string{"any", "all", "field", "except"},
+		},
+		"json": {
+			Description: "JSON representation of the rule, which can be used in the `rules` of the role mapping or in the other rules.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Helper data source to compose the rules of the role mappings. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/role-mapping-resources.html",
+
+		ReadContext: dataSourceSecurityRoleMappingRuleRead,
+
+		Schema: ruleSchema,
+	}
+}
+
+func dataSourceSecurityRoleMappingRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rule, err := expandRoleMappingRule(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateRoleMappingRule(rule, "rule", true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	ruleJson, err := json.MarshalIndent(rule, "", " ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("json", string(ruleJson)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash, err := utils.StringToHash(string(ruleJson))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*hash)
+	return diags
+}
+
+func expandRoleMappingRule(d *schema.ResourceData) (map[string]interface{}, error) {
+	for _, kind := range []string{"any", "all"} {
+		v, ok := d.GetOk(kind)
+		if !ok {
+			continue
+		}
+		rules := make([]interface{}, 0)
+		for _, r := range v.([]interface{}) {
+			rule, err := unmarshalRoleMappingRule(r)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", kind, err)
+			}
+			rules = append(rules, rule)
+		}
+		return map[string]interface{}{kind: rules}, nil
+	}
+	if v, ok := d.GetOk("field"); ok {
+		field := v.([]interface{})[0].(map[string]interface{})
+		return map[string]interface{}{
+			"field": map[string]interface{}{
+				field["name"].(string): field["values"].([]interface{}),
+			},
+		}, nil
+	}
+	rule, err := unmarshalRoleMappingRule(d.Get("except"))
+	if err != nil {
+		return nil, fmt.Errorf("except: %w", err)
+	}
+	return map[string]interface{}{"except": rule}, nil
+}
+
+func unmarshalRoleMappingRule(v interface{}) (interface{}, error) {
+	s, _ := v.(string)
+	if s == "" {
+		return nil, fmt.Errorf("the rule cannot be empty")
+	}
+	var rule interface{}
+	if err := json.Unmarshal([]byte(s), &rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Checks the whole tree of the rules, so the typos in the nested rules are reported before the role mapping is created.
+// The `except` rule is only allowed within the `all` rule, except at the root, which can be nested later.
+func validateRoleMappingRule(v interface{}, path string, exceptAllowed bool) error {
+	rule, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be an object", path)
+	}
+	if len(rule) != 1 {
+		return fmt.Errorf("%s must have exactly one of %s", path, strings.Join(roleMappingRuleTypes, ", "))
+	}
+	for kind, value := range rule {
+		switch kind {
+		case "any", "all":
+			rules, ok := value.([]interface{})
+			if !ok || len(rules) == 0 {
+				return fmt.Errorf("%s.%s must be a non-empty list of rules", path, kind)
+			}
+			for i, r := range rules {
+				if err := validateRoleMappingRule(r, fmt.Sprintf("%s.%s[%d]", path, kind, i), kind == "all"); err != nil {
+					return err
+				}
+			}
+		case "field":
+			fields, ok := value.(map[string]interface{})
+			if !ok || len(fields) != 1 {
+				return fmt.Errorf("%s.field must have exactly one field", path)
+			}
+			for name := range fields {
+				if err := validateRoleMappingRuleField(name); err != nil {
+					return fmt.Errorf("%s.field: %w", path, err)
+				}
+			}
+		case "except":
+			if !exceptAllowed {
+				return fmt.Errorf("%s.except can only be used within the all rule", path)
+			}
+			if err := validateRoleMappingRule(value, path+".except", false); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf(`%s has the unknown rule "%s", expected one of %s`, path, kind, strings.Join(roleMappingRuleTypes, ", "))
+		}
+	}
+	return nil
+}
+
+func validateRoleMappingRuleField(name string) error {
+	if strings.HasPrefix(name, "metadata.") && len(name) > len("metadata.") {
+		return nil
+	}
+	for _, f := range roleMappingRuleFields {
+		if name == f {
+			return nil
+		}
+	}
+	fields := append([]string{"metadata.*"}, roleMappingRuleFields...)
+	sort.Strings(fields)
+	return fmt.Errorf(`unknown field "%s", expected one of %s`, name, strings.Join(fields, ", "))
+}
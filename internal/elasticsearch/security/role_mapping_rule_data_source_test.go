@@ -0,0 +1,145 @@
+package security_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityRoleMappingRule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityRoleMappingRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_role_mapping_rule.test", "json", expectedJsonRoleMappingRule),
+				),
+			},
+			{
+				Config:      testAccDataSourceSecurityRoleMappingRuleInvalidField,
+				ExpectError: regexp.MustCompile(`unknown field "group"`),
+			},
+			{
+				Config:      testAccDataSourceSecurityRoleMappingRuleInvalidExcept,
+				ExpectError: regexp.MustCompile(`rule.any\[0\].except can only be used within the all rule`),
+			},
+		},
+	})
+}
+
+const expectedJsonRoleMappingRule = `{
+ "all": [
+  {
+   "field": {
+    "realm.name": [
+     "saml1"
+    ]
+   }
+  },
+  {
+   "any": [
+    {
+     "field": {
+      "groups": [
+       "admins",
+       "ops"
+      ]
+     }
+    },
+    {
+     "field": {
+      "metadata.department": [
+       "engineering"
+      ]
+     }
+    }
+   ]
+  },
+  {
+   "except": {
+    "field": {
+     "username": [
+      "guest*"
+     ]
+    }
+   }
+  }
+ ]
+}`
+
+const testAccDataSourceSecurityRoleMappingRule = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "realm" {
+  field {
+    name   = "realm.name"
+    values = ["saml1"]
+  }
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "groups" {
+  field {
+    name   = "groups"
+    values = ["admins", "ops"]
+  }
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "department" {
+  field {
+    name   = "metadata.department"
+    values = ["engineering"]
+  }
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "guests" {
+  except = jsonencode({
+    field = { username = ["guest*"] }
+  })
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "group_or_department" {
+  any = [
+    data.elasticstack_elasticsearch_security_role_mapping_rule.groups.json,
+    data.elasticstack_elasticsearch_security_role_mapping_rule.department.json,
+  ]
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "test" {
+  all = [
+    data.elasticstack_elasticsearch_security_role_mapping_rule.realm.json,
+    data.elasticstack_elasticsearch_security_role_mapping_rule.group_or_department.json,
+    data.elasticstack_elasticsearch_security_role_mapping_rule.guests.json,
+  ]
+}
+`
+
+const testAccDataSourceSecurityRoleMappingRuleInvalidField = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "test" {
+  field {
+    name   = "group"
+    values = ["admins"]
+  }
+}
+`
+
+const testAccDataSourceSecurityRoleMappingRuleInvalidExcept = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_mapping_rule" "test" {
+  any = [
+    jsonencode({ except = { field = { username = "guest" } } }),
+  ]
+}
+`
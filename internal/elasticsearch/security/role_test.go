@@ -2,6 +2,7 @@ package security_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -99,6 +100,58 @@ resource "elasticstack_elasticsearch_security_role" "test" {
 	`, roleName)
 }
 
+func TestAccResourceSecurityRoleFieldSecurity(t *testing.T) {
+	roleName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		CheckDestroy:      checkResourceSecurityRoleDestroy,
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceSecurityRoleFieldSecurity(roleName, `grant = ["customer.name"]`),
+				ExpectError: regexp.MustCompile(`the field_security exception "customer.\*" is not a subset of the granted fields`),
+			},
+			{
+				// all the fields are granted when only the exceptions are set
+				Config: testAccResourceSecurityRoleFieldSecurity(roleName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_role.test", "indices.*.field_security.0.except.*", "customer.*"),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_security_role.test", "indices.*", map[string]string{"field_security.0.grant.#": "0"}),
+				),
+			},
+			{
+				Config: testAccResourceSecurityRoleFieldSecurity(roleName, `grant = ["customer.*", "order.*"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_role.test", "indices.*.field_security.0.grant.*", "order.*"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityRoleFieldSecurity(roleName, grant string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_role" "test" {
+  name = "%s"
+
+  indices {
+    names      = ["orders"]
+    privileges = ["read"]
+
+    field_security {
+      %s
+      except = ["customer.*"]
+    }
+  }
+}
+	`, roleName, grant)
+}
+
 func checkResourceSecurityRoleDestroy(s *terraform.State) error {
 	client := acctest.Provider.Meta().(*clients.ApiClient)
 
@@ -0,0 +1,136 @@
+package roledescriptor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// the fields granted by Elasticsearch when only the exceptions are given
+var defaultFieldGrant = []string{"*"}
+
+// Builds the field level security of the indices entry, all the fields are granted when only the exceptions are defined
+func expandFieldSecurity(fieldSec map[string]interface{}) *models.FieldSecurity {
+	var fieldSecurity models.FieldSecurity
+	if gr, ok := fieldSec["grant"].(*schema.Set); ok && gr != nil {
+		for _, grant := range gr.List() {
+			fieldSecurity.Grant = append(fieldSecurity.Grant, grant.(string))
+		}
+	}
+	if exp, ok := fieldSec["except"].(*schema.Set); ok && exp != nil {
+		for _, except := range exp.List() {
+			fieldSecurity.Except = append(fieldSecurity.Except, except.(string))
+		}
+	}
+	if len(fieldSecurity.Grant) == 0 && len(fieldSecurity.Except) > 0 {
+		fieldSecurity.Grant = append([]string{}, defaultFieldGrant...)
+	}
+	return &fieldSecurity
+}
+
+// Checks that every exception is a subset of the granted fields, which Elasticsearch requires. The regular expressions, i.e. `/.../`, are not checked.
+func validateFieldSecurity(grant, except []string) error {
+	if len(grant) == 0 {
+		grant = defaultFieldGrant
+	}
+	for _, patterns := range [][]string{grant, except} {
+		for _, pattern := range patterns {
+			if strings.HasPrefix(pattern, "/") {
+				return nil
+			}
+		}
+	}
+	for _, e := range except {
+		covered := false
+		for _, g := range grant {
+			if fieldPatternCovers(g, e) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return fmt.Errorf(`the field_security exception "%s" is not a subset of the granted fields [%s], Elasticsearch rejects the exceptions of the fields which are not granted`, e, strings.Join(grant, ", "))
+		}
+	}
+	return nil
+}
+
+// Whether every field matched by the pattern is matched by the grant, the wildcards are `*` for any string and `?` for any character
+func fieldPatternCovers(grant, pattern string) bool {
+	if grant == "" {
+		return pattern == ""
+	}
+	switch grant[0] {
+	case '*':
+		return fieldPatternCovers(grant[1:], pattern) || (pattern != "" && fieldPatternCovers(grant, pattern[1:]))
+	case '?':
+		return pattern != "" && pattern[0] != '*' && fieldPatternCovers(grant[1:], pattern[1:])
+	}
+	return pattern != "" && pattern[0] == grant[0] && fieldPatternCovers(grant[1:], pattern[1:])
+}
+
+// Fails at plan time when the field level security of any indices entry would be rejected by Elasticsearch
+func ValidateIndicesFieldSecurity(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// the values which are not known yet cannot be checked
+	if !d.NewValueKnown("indices") {
+		return nil
+	}
+	indices, ok := d.Get("indices").(*schema.Set)
+	if !ok {
+		return nil
+	}
+	for _, idx := range indices.List() {
+		index := idx.(map[string]interface{})
+		fieldSec, ok := index["field_security"].([]interface{})
+		if !ok || len(fieldSec) == 0 || fieldSec[0] == nil {
+			continue
+		}
+		fs := expandFieldSecurity(fieldSec[0].(map[string]interface{}))
+		if err := validateFieldSecurity(fs.Grant, fs.Except); err != nil {
+			return fmt.Errorf("indices %s: %w", indicesKey(index), err)
+		}
+	}
+	return nil
+}
+
+// Identifies the indices entry by its sorted names
+func indicesKey(index map[string]interface{}) string {
+	names := make([]string, 0)
+	if definedNames, ok := index["names"].(*schema.Set); ok {
+		for _, name := range definedNames.List() {
+			names = append(names, name.(string))
+		}
+	}
+	return namesKey(names)
+}
+
+func namesKey(names []string) string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	return "[" + strings.Join(sorted, ", ") + "]"
+}
+
+// Returns the keys of the indices entries, which define the field level security without the grants, so the default grant is kept out of the state
+func indicesWithDefaultGrant(d *schema.ResourceData) map[string]bool {
+	keys := make(map[string]bool)
+	indices, ok := d.Get("indices").(*schema.Set)
+	if !ok {
+		return keys
+	}
+	for _, idx := range indices.List() {
+		index := idx.(map[string]interface{})
+		fieldSec, ok := index["field_security"].([]interface{})
+		if !ok || len(fieldSec) == 0 || fieldSec[0] == nil {
+			continue
+		}
+		fs := fieldSec[0].(map[string]interface{})
+		if gr, ok := fs["grant"].(*schema.Set); ok && gr.Len() == 0 {
+			keys[indicesKey(index)] = true
+		}
+	}
+	return keys
+}
@@ -0,0 +1,26 @@
+package roledescriptor
+
+import "testing"
+
+func TestValidateFieldSecurity(t *testing.T) {
+	tests := []struct {
+		grant  []string
+		except []string
+		valid  bool
+	}{
+		{nil, []string{"customer.*"}, true},
+		{[]string{"*"}, []string{"customer.*"}, true},
+		{[]string{"customer.*"}, []string{"customer.address.*", "customer.name"}, true},
+		{[]string{"order.*", "customer.*"}, []string{"customer.?d"}, true},
+		{[]string{"customer.name"}, []string{"customer.*"}, false},
+		{[]string{"customer.?"}, []string{"customer.*"}, false},
+		{[]string{"order.*"}, []string{"customer.name"}, false},
+		{[]string{"/customer\\..*/"}, []string{"customer.*"}, true},
+	}
+
+	for _, tc := range tests {
+		if err := validateFieldSecurity(tc.grant, tc.except); (err == nil) != tc.valid {
+			t.Errorf("grant %v, except %v: expected valid to be %t, got %v", tc.grant, tc.except, tc.valid, err)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package roledescriptor
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Source of the role descriptor attributes, e.g. *schema.ResourceData
+type ResourceData interface {
+	GetOk(string) (interface{}, bool)
+}
+
+// Returns the schema of the role descriptor (cluster, indices, applications, global privileges, metadata and run_as),
+// shared by all the resources and data sources defining the roles.
+func Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"applications": {
+			Description: "A list of application privilege entries.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"application": {
+						Description: "The name of the application to which this entry applies.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"privileges": {
+						Description: "A list of strings, where each element is the name of an application privilege or action.",
+						Type:        schema.TypeSet,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+						Required: true,
+					},
+					"resources": {
+						Description: "A list resources to which the privileges are applied.",
+						Type:        schema.TypeSet,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+						Required: true,
+					},
+				},
+			},
+		},
+		"global": {
+			Description:      "An object defining global privileges.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"cluster": {
+			Description: "A list of cluster privileges. These privileges define the cluster level actions that users with this role are able to execute.",
+			Type:        schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional: true,
+		},
+		"indices": {
+			Description: "A list of indices permissions entries.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"field_security": {
+						Description: "The document fields that the owners of the role have read access to.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"grant": {
+									Description: "List of the fields to grant the access to. All the fields are granted when only `except` is set.",
+									Type:        schema.TypeSet,
+									Optional:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+								"except": {
+									Description: "List of the fields to which the grants will not be applied. Each of them must be a subset of the granted fields, e.g. `customer.*` is not granted by `customer.name`.",
+									Type:        schema.TypeSet,
+									Optional:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+							},
+						},
+					},
+					"names": {
+						Description: "A list of indices (or index name patterns) to which the permissions in this entry apply.",
+						Type:        schema.TypeSet,
+						Required:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"privileges": {
+						Description: "The index level privileges that the owners of the role have on the specified indices.",
+						Type:        schema.TypeSet,
+						Required:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"query": {
+						Description:      "A search query that defines the documents the owners of the role have read access to.",
+						Type:             schema.TypeString,
+						ValidateFunc:     validation.StringIsJSON,
+						DiffSuppressFunc: utils.DiffJsonSuppress,
+						Optional:         true,
+					},
+				},
+			},
+		},
+		"metadata": {
+			Description:      "Optional meta-data.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"run_as": {
+			Description: "A list of users that the owners of this role can impersonate.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+// The workflows the API keys can be restricted to
+var Workflows = []string{"search_application_query"}
+
+// Returns the schema of the restriction of the role descriptor, which is supported only by the role descriptors of the API keys.
+func RestrictionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"restriction": {
+			Description: "Restricts when the role descriptor is effective, supported only by the API keys.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"workflows": {
+						Description: "The workflows the API key is restricted to, e.g. `search_application_query` to only allow querying the search applications.",
+						Type:        schema.TypeSet,
+						Required:    true,
+						MinItems:    1,
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringInSlice(Workflows, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Builds the role descriptor out of the attributes defined by Schema() and RestrictionSchema().
+func Expand(d ResourceData) (*models.Role, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var role models.Role
+
+	if v, ok := d.GetOk("applications"); ok {
+		definedApps := v.(*schema.Set)
+		applications := make([]models.Application, definedApps.Len())
+		for i, app := range definedApps.List() {
+			a := app.(map[string]interface{})
+
+			definedPrivs := a["privileges"].(*schema.Set)
+			privs := make([]string, definedPrivs.Len())
+			for i, pr := range definedPrivs.List() {
+				privs[i] = pr.(string)
+			}
+			definedRess := a["resources"].(*schema.Set)
+			ress := make([]string, definedRess.Len())
+			for i, res := range definedRess.List() {
+				ress[i] = res.(string)
+			}
+
+			newApp := models.Application{
+				Name:       a["application"].(string),
+				Privileges: privs,
+				Resources:  ress,
+			}
+			applications[i] = newApp
+		}
+		role.Applications = applications
+	}
+
+	if v, ok := d.GetOk("global"); ok {
+		global := make(map[string]interface{})
+		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&global); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		role.Global = global
+	}
+
+	if v, ok := d.GetOk("cluster"); ok {
+		definedCluster := v.(*schema.Set)
+		cls := make([]string, definedCluster.Len())
+		for i, cl := range definedCluster.List() {
+			cls[i] = cl.(string)
+		}
+		role.Cluster = cls
+	}
+
+	if v, ok := d.GetOk("indices"); ok {
+		definedIndices := v.(*schema.Set)
+		indices := make([]models.IndexPerms, definedIndices.Len())
+		for i, idx := range definedIndices.List() {
+			index := idx.(map[string]interface{})
+
+			definedNames := index["names"].(*schema.Set)
+			names := make([]string, definedNames.Len())
+			for i, name := range definedNames.List() {
+				names[i] = name.(string)
+			}
+			definedPrivs := index["privileges"].(*schema.Set)
+			privs := make([]string, definedPrivs.Len())
+			for i, pr := range definedPrivs.List() {
+				privs[i] = pr.(string)
+			}
+
+			newIndex := models.IndexPerms{
+				Names:      names,
+				Privileges: privs,
+			}
+
+			if query := index["query"].(string); query != "" {
+				newIndex.Query = &query
+			}
+			if fieldSec := index["field_security"].([]interface{}); len(fieldSec) > 0 && fieldSec[0] != nil {
+				// there must be only 1 entry
+				fieldSecurity := expandFieldSecurity(fieldSec[0].(map[string]interface{}))
+				if err := validateFieldSecurity(fieldSecurity.Grant, fieldSecurity.Except); err != nil {
+					return nil, diag.FromErr(err)
+				}
+				newIndex.FieldSecurity = fieldSecurity
+			}
+			indices[i] = newIndex
+		}
+		role.Indices = indices
+	}
+
+	if v, ok := d.GetOk("metadata"); ok {
+		metadata := make(map[string]interface{})
+		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		role.Metadata = metadata
+	}
+
+	if v, ok := d.GetOk("run_as"); ok {
+		definedRuns := v.(*schema.Set)
+		runs := make([]string, definedRuns.Len())
+		for i, run := range definedRuns.List() {
+			runs[i] = run.(string)
+		}
+		role.RusAs = runs
+	}
+
+	if v, ok := d.GetOk("restriction"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		definedWorkflows := v.([]interface{})[0].(map[string]interface{})["workflows"].(*schema.Set)
+		workflows := make([]string, definedWorkflows.Len())
+		for i, w := range definedWorkflows.List() {
+			workflows[i] = w.(string)
+		}
+		role.Restriction = &models.RoleRestriction{Workflows: workflows}
+	}
+
+	return &role, diags
+}
+
+// Sets the attributes defined by Schema() from the role descriptor.
+func Flatten(d *schema.ResourceData, role *models.Role) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	apps := role.Applications
+	applications := flattenApplicationsData(&apps)
+	if err := d.Set("applications", applications); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("cluster", role.Cluster); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if role.Global != nil {
+		global, err := json.Marshal(role.Global)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("global", string(global)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	indexes := role.Indices
+	indices := flattenIndicesData(&indexes, indicesWithDefaultGrant(d))
+	if err := d.Set("indices", indices); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if role.Metadata != nil {
+		metadata, err := json.Marshal(role.Metadata)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("metadata", string(metadata)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("run_as", role.RusAs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func flattenApplicationsData(apps *[]models.Application) []interface{} {
+	if apps != nil {
+		oapps := make([]interface{}, len(*apps))
+		for i, app := range *apps {
+			oa := make(map[string]interface{})
+			oa["application"] = app.Name
+			oa["privileges"] = app.Privileges
+			oa["resources"] = app.Resources
+			oapps[i] = oa
+		}
+		return oapps
+	}
+	return make([]interface{}, 0)
+}
+
+func flattenIndicesData(indices *[]models.IndexPerms, defaultGrants map[string]bool) []interface{} {
+	if indices != nil {
+		oindx := make([]interface{}, len(*indices))
+
+		for i, index := range *indices {
+			oi := make(map[string]interface{})
+			oi["names"] = index.Names
+			oi["privileges"] = index.Privileges
+			oi["query"] = index.Query
+
+			if index.FieldSecurity != nil {
+				fsec := make(map[string]interface{})
+				fsec["grant"] = index.FieldSecurity.Grant
+				fsec["except"] = index.FieldSecurity.Except
+				// the grant is not set when it was defaulted from the exceptions
+				if defaultGrants[namesKey(index.Names)] && reflect.DeepEqual(index.FieldSecurity.Grant, defaultFieldGrant) {
+					fsec["grant"] = nil
+				}
+				oi["field_security"] = []interface{}{fsec}
+			}
+			oindx[i] = oi
+		}
+		return oindx
+	}
+	return make([]interface{}, 0)
+}
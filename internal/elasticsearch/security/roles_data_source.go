@@ -0,0 +1,130 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRoles() *schema.Resource {
+	rolesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name_pattern": {
+			Description: "Only return the roles with the matching name, wildcards (`*` and `?`) are supported, e.g. `team-*`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "*",
+		},
+		"include_reserved": {
+			Description: "Also return the reserved roles, e.g. `superuser`, which cannot be managed.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"roles": {
+			Description: "The matching roles, sorted by their names.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "The name of the role.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"import_id": {
+						Description: "The ID to import the role into `elasticstack_elasticsearch_security_role`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"reserved": {
+						Description: "Whether the role is reserved, i.e. built-in.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"json": {
+						Description: "JSON representation of the role, i.e. the role descriptor.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(rolesSchema)
+
+	return &schema.Resource{
+		Description: "Lists the roles with their definitions, e.g. to generate the import of the existing roles. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-get-role.html",
+
+		ReadContext: dataSourceSecurityRolesRead,
+
+		Schema: rolesSchema,
+	}
+}
+
+func dataSourceSecurityRolesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pattern := d.Get("name_pattern").(string)
+	id, diags := client.ID(ctx, "roles-"+pattern)
+	if diags.HasError() {
+		return diags
+	}
+
+	roles, diags := client.GetElasticsearchRoles(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	names := make([]string, 0, len(roles))
+	for name, role := range roles {
+		if !utils.MatchesWildcard(pattern, name) || (isReserved(role.Metadata) && !d.Get("include_reserved").(bool)) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		role := roles[name]
+		roleJson, err := json.Marshal(role)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		importId, diags := client.ID(ctx, name)
+		if diags.HasError() {
+			return diags
+		}
+		result = append(result, map[string]interface{}{
+			"name":      name,
+			"import_id": importId.String(),
+			"reserved":  isReserved(role.Metadata),
+			"json":      string(roleJson),
+		})
+	}
+	if err := d.Set("roles", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// The built-in roles and users are marked as reserved in their metadata
+func isReserved(metadata map[string]interface{}) bool {
+	reserved, _ := metadata["_reserved"].(bool)
+	return reserved
+}
@@ -0,0 +1,65 @@
+package security_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityRoles(t *testing.T) {
+	prefix := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityRoles(prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.test", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.test", "roles.0.name", prefix+"-a"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.test", "roles.1.name", prefix+"-b"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.test", "roles.0.reserved", "false"),
+					resource.TestCheckResourceAttrPair("data.elasticstack_elasticsearch_security_roles.test", "roles.0.import_id", "elasticstack_elasticsearch_security_role.a", "id"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.test", "roles.0.json", `{"cluster":["monitor"]}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.reserved", "roles.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.reserved", "roles.0.reserved", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSecurityRoles(prefix string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_role" "a" {
+  name    = "%[1]s-a"
+  cluster = ["monitor"]
+}
+
+resource "elasticstack_elasticsearch_security_role" "b" {
+  name    = "%[1]s-b"
+  cluster = ["monitor"]
+}
+
+data "elasticstack_elasticsearch_security_roles" "test" {
+  name_pattern = "%[1]s-*"
+
+  depends_on = [
+    elasticstack_elasticsearch_security_role.a,
+    elasticstack_elasticsearch_security_role.b,
+  ]
+}
+
+data "elasticstack_elasticsearch_security_roles" "reserved" {
+  name_pattern     = "superuse?"
+  include_reserved = true
+}
+	`, prefix)
+}
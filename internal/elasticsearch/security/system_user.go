@@ -0,0 +1,155 @@
+package security
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceSystemUser() *schema.Resource {
+	userSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"username": {
+			Description: "The name of the built-in user, e.g. `elastic`, `kibana_system` or `logstash_system`.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"password": {
+			Description:   "The new password of the user. Passwords must be at least 6 characters long.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			ValidateFunc:  validation.StringLenBetween(6, 128),
+			ConflictsWith: []string{"password_hash"},
+		},
+		"password_hash": {
+			Description:   "A hash of the new password of the user. This must be produced using the same hashing algorithm as has been configured for password storage (see https://www.elastic.co/guide/en/elasticsearch/reference/current/security-settings.html#hashing-settings).",
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			ValidateFunc:  validation.StringLenBetween(6, 128),
+			ConflictsWith: []string{"password"},
+		},
+		"enabled": {
+			Description: "Specifies whether the user is enabled. The default value is true.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+	}
+
+	utils.AddConnectionSchema(userSchema)
+
+	return &schema.Resource{
+		Description: "Manages the password of the built-in users, e.g. `kibana_system`, and whether they are enabled. The built-in users cannot be created nor deleted, destroying the resource leaves the user as it is. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/built-in-users.html",
+
+		CreateContext: resourceSecuritySystemUserPut,
+		UpdateContext: resourceSecuritySystemUserPut,
+		ReadContext:   resourceSecuritySystemUserRead,
+		DeleteContext: resourceSecuritySystemUserDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
+		Schema: userSchema,
+	}
+}
+
+func resourceSecuritySystemUserPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	usernameId := d.Get("username").(string)
+	id, diags := client.ID(ctx, usernameId)
+	if diags.HasError() {
+		return diags
+	}
+
+	user, diags := client.GetElasticsearchUser(ctx, usernameId)
+	if diags.HasError() {
+		return diags
+	}
+	if user == nil || !isReservedUser(user) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`"%s" is not a built-in user`, usernameId),
+			Detail:   "Only the passwords of the built-in users can be managed by the resource, use elasticstack_elasticsearch_security_user to manage the users of the native realm.",
+		}}
+	}
+
+	// the password cannot be read back, so it's only changed when it changes in the configuration
+	if d.IsNewResource() || d.HasChanges("password", "password_hash") {
+		var password, passwordHash *string
+		if v, ok := d.GetOk("password"); ok {
+			p := v.(string)
+			password = &p
+		}
+		if v, ok := d.GetOk("password_hash"); ok {
+			h := v.(string)
+			passwordHash = &h
+		}
+		if password != nil || passwordHash != nil {
+			if diags := client.ChangeElasticsearchUserPassword(ctx, usernameId, password, passwordHash); diags.HasError() {
+				return diags
+			}
+		}
+	}
+	if diags := setUserEnabled(ctx, client, usernameId, user.Enabled, d.Get("enabled").(bool)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceSecuritySystemUserRead(ctx, d, meta)
+}
+
+// Only whether the user is enabled is read back, the password is kept as it's configured
+func resourceSecuritySystemUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	usernameId := compId.ResourceId
+
+	user, diags := client.GetElasticsearchUser(ctx, usernameId)
+	if user == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("username", usernameId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("enabled", user.Enabled); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// The built-in users cannot be deleted, the user is only removed from the state
+func resourceSecuritySystemUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,51 @@
+package security_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSecuritySystemUser(t *testing.T) {
+	password := sdkacctest.RandStringFromCharSet(16, sdkacctest.CharSetAlphaNum)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecuritySystemUser("remote_monitoring_user", password, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_system_user.test", "username", "remote_monitoring_user"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_system_user.test", "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccResourceSecuritySystemUser("remote_monitoring_user", password, true),
+				Check:  resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_system_user.test", "enabled", "true"),
+			},
+			{
+				Config:      testAccResourceSecuritySystemUser(sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum), password, true),
+				ExpectError: regexp.MustCompile(`is not a built-in user`),
+			},
+		},
+	})
+}
+
+func testAccResourceSecuritySystemUser(username, password string, enabled bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_system_user" "test" {
+  username = "%s"
+  password = "%s"
+  enabled  = %t
+}
+	`, username, password, enabled)
+}
@@ -3,6 +3,7 @@ package security
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -82,6 +83,11 @@ func ResourceUser() *schema.Resource {
 			Optional:    true,
 			Default:     true,
 		},
+		"reserved": {
+			Description: "Whether the user is a built-in reserved user, e.g. `elastic` or `kibana_system`. Only `enabled` can be changed for the reserved users, and destroying the resource leaves the user in the cluster.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
 	}
 
 	utils.AddConnectionSchema(userSchema)
@@ -98,6 +104,8 @@ func ResourceUser() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: utils.ResourceTimeouts(utils.DefaultResourceTimeout, utils.DefaultResourceTimeout),
+
 		Schema: userSchema,
 	}
 }
@@ -108,11 +116,27 @@ func resourceSecurityUserPut(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.FromErr(err)
 	}
 	usernameId := d.Get("username").(string)
-	id, diags := client.ID(usernameId)
+	id, diags := client.ID(ctx, usernameId)
 	if diags.HasError() {
 		return diags
 	}
 
+	existing, diags := client.GetElasticsearchUser(ctx, usernameId)
+	if diags.HasError() {
+		return diags
+	}
+	// the reserved users cannot be updated, they can only be adopted and enabled or disabled
+	if existing != nil && isReservedUser(existing) {
+		if diags := checkReservedUser(d, usernameId, existing); diags.HasError() {
+			return diags
+		}
+		if diags := setUserEnabled(ctx, client, usernameId, existing.Enabled, d.Get("enabled").(bool)); diags.HasError() {
+			return diags
+		}
+		d.SetId(id.String())
+		return resourceSecurityUserRead(ctx, d, meta)
+	}
+
 	var user models.User
 	user.Username = usernameId
 	if v, ok := d.GetOk("password"); ok {
@@ -148,7 +172,7 @@ func resourceSecurityUserPut(ctx context.Context, d *schema.ResourceData, meta i
 		user.Metadata = metadata
 	}
 
-	if diags := client.PutElasticsearchUser(&user); diags.HasError() {
+	if diags := client.PutElasticsearchUser(ctx, &user); diags.HasError() {
 		return diags
 	}
 
@@ -168,7 +192,7 @@ func resourceSecurityUserRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 	usernameId := compId.ResourceId
 
-	user, diags := client.GetElasticsearchUser(usernameId)
+	user, diags := client.GetElasticsearchUser(ctx, usernameId)
 	if user == nil && diags == nil {
 		d.SetId("")
 		return diags
@@ -177,7 +201,7 @@ func resourceSecurityUserRead(ctx context.Context, d *schema.ResourceData, meta
 		return diags
 	}
 
-	metadata, err := json.Marshal(user.Metadata)
+	metadata, err := json.Marshal(userMetadata(user))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -201,6 +225,9 @@ func resourceSecurityUserRead(ctx context.Context, d *schema.ResourceData, meta
 	if err := d.Set("enabled", user.Enabled); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("reserved", isReservedUser(user)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return diags
 }
@@ -216,10 +243,76 @@ func resourceSecurityUserDelete(ctx context.Context, d *schema.ResourceData, met
 		return diags
 	}
 
-	if diags := client.DeleteElasticsearchUser(compId.ResourceId); diags.HasError() {
-		return diags
+	// the reserved users cannot be deleted
+	if !d.Get("reserved").(bool) {
+		if diags := client.DeleteElasticsearchUser(ctx, compId.ResourceId); diags.HasError() {
+			return diags
+		}
 	}
 
 	d.SetId("")
 	return diags
 }
+
+func isReservedUser(user *models.User) bool {
+	reserved, _ := user.Metadata["_reserved"].(bool)
+	return reserved
+}
+
+// Returns the metadata set by the users, the keys starting with `_` are reserved for the system, e.g. `_reserved` or `_deprecated`
+func userMetadata(user *models.User) map[string]interface{} {
+	metadata := make(map[string]interface{}, len(user.Metadata))
+	for k, v := range user.Metadata {
+		if !strings.HasPrefix(k, "_") {
+			metadata[k] = v
+		}
+	}
+	return metadata
+}
+
+func checkReservedUser(d *schema.ResourceData, username string, user *models.User) diag.Diagnostics {
+	var diags diag.Diagnostics
+	changed := make([]string, 0)
+	if _, ok := d.GetOk("password"); ok {
+		changed = append(changed, "password")
+	}
+	if _, ok := d.GetOk("password_hash"); ok {
+		changed = append(changed, "password_hash")
+	}
+	if d.Get("full_name").(string) != user.FullName {
+		changed = append(changed, "full_name")
+	}
+	if d.Get("email").(string) != user.Email {
+		changed = append(changed, "email")
+	}
+	roles := d.Get("roles").(*schema.Set)
+	if roles.Len() != len(user.Roles) {
+		changed = append(changed, "roles")
+	} else {
+		for _, role := range user.Roles {
+			if !roles.Contains(role) {
+				changed = append(changed, "roles")
+				break
+			}
+		}
+	}
+	if len(changed) > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`The user "%s" is reserved`, username),
+			Detail:   fmt.Sprintf("Only enabled can be changed for the reserved users, the configuration differs in: %s.", strings.Join(changed, ", ")),
+		}}
+	}
+	return diags
+}
+
+func setUserEnabled(ctx context.Context, client *clients.ApiClient, username string, current, enabled bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if current == enabled {
+		return diags
+	}
+	if enabled {
+		return client.EnableElasticsearchUser(ctx, username)
+	}
+	return client.DisableElasticsearchUser(ctx, username)
+}
@@ -3,6 +3,7 @@ package security
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
@@ -70,15 +71,22 @@ func dataSourceSecurityUserRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 	usernameId := d.Get("username").(string)
-	id, diags := client.ID(usernameId)
+	id, diags := client.ID(ctx, usernameId)
 	if diags.HasError() {
 		return diags
 	}
 
-	user, diags := client.GetElasticsearchUser(usernameId)
+	user, diags := client.GetElasticsearchUser(ctx, usernameId)
 	if diags.HasError() {
 		return diags
 	}
+	if user == nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to find a user in the cluster.",
+			Detail:   fmt.Sprintf("Unable to get user: '%s' from the cluster.", usernameId),
+		}}
+	}
 
 	metadata, err := json.Marshal(user.Metadata)
 	if err != nil {
@@ -0,0 +1,141 @@
+package security
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceUserProfiles() *schema.Resource {
+	profilesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Only return the profiles matching this name, i.e. the username, the full name or the email of the user. All the profiles are returned when not set.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"size": {
+			Description:  "The maximum number of the profiles to return.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			ValidateFunc: validation.IntBetween(1, 100),
+		},
+		"profiles": {
+			Description: "The enabled user profiles, the best matching first.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"uid": {
+						Description: "Unique ID of the profile.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"username": {
+						Description: "Username of the user.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"full_name": {
+						Description: "Full name of the user.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"email": {
+						Description: "Email of the user.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"realm_name": {
+						Description: "Realm which authenticated the user.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"roles": {
+						Description: "Roles of the user.",
+						Type:        schema.TypeSet,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"uids": {
+			Description: "Unique IDs of the returned profiles.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(profilesSchema)
+
+	return &schema.Resource{
+		Description: "Returns the profiles of the users who have logged in to Kibana, e.g. to inventory the users of the cluster. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-suggest-user-profile.html",
+
+		ReadContext: dataSourceSecurityUserProfilesRead,
+
+		Schema: profilesSchema,
+	}
+}
+
+func dataSourceSecurityUserProfilesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, diags := client.ID(ctx, "user-profiles")
+	if diags.HasError() {
+		return diags
+	}
+
+	userProfiles, diags := client.SuggestElasticsearchUserProfiles(ctx, d.Get("name").(string), d.Get("size").(int))
+	if diags.HasError() {
+		return diags
+	}
+
+	profiles := make([]interface{}, len(userProfiles))
+	uids := make([]string, len(userProfiles))
+	for i, profile := range userProfiles {
+		p := map[string]interface{}{
+			"uid":        profile.Uid,
+			"username":   profile.User.Username,
+			"full_name":  "",
+			"email":      "",
+			"realm_name": profile.User.RealmName,
+			"roles":      profile.User.Roles,
+		}
+		if profile.User.FullName != nil {
+			p["full_name"] = *profile.User.FullName
+		}
+		if profile.User.Email != nil {
+			p["email"] = *profile.User.Email
+		}
+		profiles[i] = p
+		uids[i] = profile.Uid
+	}
+
+	if err := d.Set("profiles", profiles); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("uids", uids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
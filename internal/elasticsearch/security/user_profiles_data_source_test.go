@@ -0,0 +1,36 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityUserProfiles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityUserProfiles,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_user_profiles.test", "id"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_user_profiles.test", "size", "100"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_user_profiles.test", "profiles.#"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_user_profiles.test", "uids.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSecurityUserProfiles = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_user_profiles" "test" {
+  size = 100
+}
+`
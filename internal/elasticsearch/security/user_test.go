@@ -67,6 +67,44 @@ resource "elasticstack_elasticsearch_security_user" "test" {
 	`, username)
 }
 
+func TestAccResourceSecurityUserReserved(t *testing.T) {
+	// the reserved user is left in the cluster on destroy
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecurityUserReserved(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "reserved", "true"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "metadata", "{}"),
+				),
+			},
+			{
+				Config: testAccResourceSecurityUserReserved(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityUserReserved(enabled bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_user" "test" {
+  username = "beats_system"
+  roles    = ["beats_system"]
+  enabled  = %t
+}
+	`, enabled)
+}
+
 func checkResourceSecurityUserDestroy(s *terraform.State) error {
 	client := acctest.Provider.Meta().(*clients.ApiClient)
 
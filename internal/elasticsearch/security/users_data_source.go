@@ -0,0 +1,151 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceUsers() *schema.Resource {
+	usersSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"username_pattern": {
+			Description: "Only return the users with the matching username, wildcards (`*` and `?`) are supported, e.g. `svc-*`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "*",
+		},
+		"include_reserved": {
+			Description: "Also return the reserved users, e.g. `elastic` or `kibana_system`, which are managed by `elasticstack_elasticsearch_security_system_user`.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"users": {
+			Description: "The matching users of the native realm, sorted by their usernames.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"username": {
+						Description: "An identifier for the user.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"import_id": {
+						Description: "The ID to import the user into `elasticstack_elasticsearch_security_user`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"reserved": {
+						Description: "Whether the user is reserved, i.e. built-in.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"full_name": {
+						Description: "The full name of the user.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"email": {
+						Description: "The email of the user.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"roles": {
+						Description: "The roles of the user.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"metadata": {
+						Description: "Arbitrary metadata associated with the user, as JSON.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"enabled": {
+						Description: "Whether the user is enabled.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(usersSchema)
+
+	return &schema.Resource{
+		Description: "Lists the users of the native realm with their definitions, e.g. to generate the import of the existing users. The passwords are never returned. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-get-user.html",
+
+		ReadContext: dataSourceSecurityUsersRead,
+
+		Schema: usersSchema,
+	}
+}
+
+func dataSourceSecurityUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := clients.NewApiClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pattern := d.Get("username_pattern").(string)
+	id, diags := client.ID(ctx, "users-"+pattern)
+	if diags.HasError() {
+		return diags
+	}
+
+	users, diags := client.GetElasticsearchUsers(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	usernames := make([]string, 0, len(users))
+	for username, user := range users {
+		if !utils.MatchesWildcard(pattern, username) || (isReserved(user.Metadata) && !d.Get("include_reserved").(bool)) {
+			continue
+		}
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	result := make([]interface{}, 0, len(usernames))
+	for _, username := range usernames {
+		user := users[username]
+		metadata, err := json.Marshal(user.Metadata)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		importId, diags := client.ID(ctx, username)
+		if diags.HasError() {
+			return diags
+		}
+		result = append(result, map[string]interface{}{
+			"username":  username,
+			"import_id": importId.String(),
+			"reserved":  isReserved(user.Metadata),
+			"full_name": user.FullName,
+			"email":     user.Email,
+			"roles":     user.Roles,
+			"metadata":  string(metadata),
+			"enabled":   user.Enabled,
+		})
+	}
+	if err := d.Set("users", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
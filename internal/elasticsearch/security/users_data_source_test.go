@@ -0,0 +1,57 @@
+package security_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityUsers(t *testing.T) {
+	prefix := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityUsers(prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_users.test", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_users.test", "users.0.username", prefix+"-user"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_users.test", "users.0.full_name", "Test User"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_users.test", "users.0.roles.0", "kibana_user"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_users.test", "users.0.enabled", "true"),
+					resource.TestCheckResourceAttrPair("data.elasticstack_elasticsearch_security_users.test", "users.0.import_id", "elasticstack_elasticsearch_security_user.test", "id"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_users.reserved", "users.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSecurityUsers(prefix string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_user" "test" {
+  username  = "%s-user"
+  password  = "qwerty123"
+  full_name = "Test User"
+  roles     = ["kibana_user"]
+}
+
+data "elasticstack_elasticsearch_security_users" "test" {
+  username_pattern = "%s-*"
+
+  depends_on = [elasticstack_elasticsearch_security_user.test]
+}
+
+data "elasticstack_elasticsearch_security_users" "reserved" {
+  username_pattern = "elastic"
+}
+	`, prefix, prefix)
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindEnum
+	kindInt
+	kindFloat
+	kindBool
+	// the values which cannot be described by the schema, e.g. the dictionaries and the unions, are JSON strings
+	kindJSON
+	kindObject
+	kindList
+)
+
+// The attribute generated from the property of the specification
+type field struct {
+	attr        string
+	jsonName    string
+	goName      string
+	description string
+	required    bool
+	kind        fieldKind
+	enum        []string
+	// the object of the object attributes and of the lists of objects
+	object *object
+	// the element of the lists
+	elem *field
+}
+
+// The model generated from the interface of the specification, together with its schema and the expand and flatten functions
+type object struct {
+	specName    string
+	goName      string
+	description string
+	fields      []*field
+}
+
+type generator struct {
+	spec    *spec
+	objects []*object
+	byName  map[string]*object
+	// the objects being built, the objects referencing themselves are JSON strings
+	building map[string]bool
+}
+
+func newGenerator(s *spec) *generator {
+	return &generator{spec: s, byName: make(map[string]*object), building: make(map[string]bool)}
+}
+
+// Adds the object of the type, e.g. `ingest._types.CsvProcessor`, and the objects of its properties
+func (g *generator) add(name string) (*object, error) {
+	if o, ok := g.byName[name]; ok {
+		return o, nil
+	}
+	t, err := g.spec.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if t.Kind != "interface" {
+		return nil, fmt.Errorf(`the type "%s" is a %s, only the interfaces can be generated`, name, t.Kind)
+	}
+	g.building[name] = true
+	defer delete(g.building, name)
+
+	props, err := g.spec.properties(t)
+	if err != nil {
+		return nil, err
+	}
+	o := &object{specName: name, goName: t.Name.Name, description: firstSentence(t.Description)}
+	for _, p := range props {
+		f, err := g.field(p.Type)
+		if err != nil {
+			return nil, fmt.Errorf(`unable to generate the property "%s" of "%s": %w`, p.Name, name, err)
+		}
+		// the attribute names must start with a letter, e.g. `_meta` is the `meta` attribute
+		f.attr = strings.TrimLeft(p.Name, "_")
+		f.jsonName = p.Name
+		f.goName = goName(p.Name)
+		f.description = firstSentence(p.Description)
+		f.required = p.Required
+		o.fields = append(o.fields, f)
+	}
+	g.byName[name] = o
+	g.objects = append(g.objects, o)
+	return o, nil
+}
+
+func (g *generator) field(v specValueOf) (*field, error) {
+	kind, resolved, err := g.spec.resolve(v)
+	if err != nil {
+		return nil, err
+	}
+	f := &field{kind: kind}
+	switch kind {
+	case kindEnum:
+		f.enum = g.spec.enumMembers(v)
+	case kindObject:
+		name := resolved.Type.String()
+		if g.building[name] {
+			f.kind = kindJSON
+			break
+		}
+		if f.object, err = g.add(name); err != nil {
+			return nil, err
+		}
+	case kindList:
+		elem, err := g.field(*resolved)
+		if err != nil {
+			return nil, err
+		}
+		// the lists of lists and of JSON values are JSON strings as a whole
+		if elem.kind == kindList || elem.kind == kindJSON {
+			f.kind = kindJSON
+			break
+		}
+		f.elem = elem
+		f.object = elem.object
+	}
+	return f, nil
+}
+
+// Returns the Go name of the attribute, e.g. `TargetFields` of `target_fields`
+func goName(attr string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(attr, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func (f *field) goType() string {
+	var t string
+	switch f.kind {
+	case kindString, kindEnum:
+		t = "string"
+	case kindInt:
+		t = "int"
+	case kindFloat:
+		t = "float64"
+	case kindBool:
+		t = "bool"
+	case kindJSON:
+		return "interface{}"
+	case kindObject:
+		return "*" + f.object.goName
+	case kindList:
+		if f.object != nil {
+			return "[]" + f.object.goName
+		}
+		return "[]" + f.elem.goType()
+	}
+	// the optional scalars are left out of the requests when they are not set
+	if !f.required && f.attr != "" {
+		return "*" + t
+	}
+	return t
+}
+
+func (f *field) schemaType() string {
+	switch f.kind {
+	case kindInt:
+		return "schema.TypeInt"
+	case kindFloat:
+		return "schema.TypeFloat"
+	case kindBool:
+		return "schema.TypeBool"
+	case kindObject, kindList:
+		return "schema.TypeList"
+	}
+	return "schema.TypeString"
+}
+
+// Returns the type assertion of the value read from the resource data
+func (f *field) dataType() string {
+	switch f.kind {
+	case kindInt:
+		return "int"
+	case kindFloat:
+		return "float64"
+	case kindBool:
+		return "bool"
+	}
+	return "string"
+}
+
+func (f *field) zeroCheck(v string) string {
+	switch f.kind {
+	case kindInt, kindFloat:
+		return v + " != 0"
+	case kindBool:
+		return v
+	}
+	return v + ` != ""`
+}
+
+type imports struct {
+	json, utils, validation bool
+}
+
+// Renders the models, the schemas and the expand and flatten functions of all the added objects into the formatted Go source
+func (g *generator) render(pkg string) ([]byte, error) {
+	var body bytes.Buffer
+	var imp imports
+	for _, o := range g.objects {
+		g.renderModel(&body, o)
+		g.renderSchema(&body, o, &imp)
+		g.renderExpand(&body, o, &imp)
+		g.renderFlatten(&body, o, &imp)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by internal/gen from the Elasticsearch specification. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	if imp.json {
+		out.WriteString("\t\"encoding/json\"\n\n")
+	}
+	if imp.utils {
+		out.WriteString("\t\"github.com/elastic/terraform-provider-elasticstack/internal/utils\"\n")
+	}
+	out.WriteString("\t\"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema\"\n")
+	if imp.validation {
+		out.WriteString("\t\"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation\"\n")
+	}
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format the generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) renderModel(w *bytes.Buffer, o *object) {
+	w.WriteString("\n")
+	if o.description != "" {
+		fmt.Fprintf(w, "// %s\n", o.description)
+	}
+	fmt.Fprintf(w, "// Generated from %s\n", o.specName)
+	fmt.Fprintf(w, "type %s struct {\n", o.goName)
+	for _, f := range o.fields {
+		tag := f.jsonName
+		if !f.required {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "\t%s %s `json:\"%s\"`\n", f.goName, f.goType(), tag)
+	}
+	w.WriteString("}\n")
+}
+
+func (g *generator) renderSchema(w *bytes.Buffer, o *object, imp *imports) {
+	fmt.Fprintf(w, "\nfunc %sSchema() map[string]*schema.Schema {\n\treturn map[string]*schema.Schema{\n", lowerFirst(o.goName))
+	for _, f := range o.fields {
+		fmt.Fprintf(w, "\t\t%s: {\n", strconv.Quote(f.attr))
+		if f.description != "" {
+			fmt.Fprintf(w, "\t\t\tDescription: %s,\n", strconv.Quote(f.description))
+		}
+		fmt.Fprintf(w, "\t\t\tType: %s,\n", f.schemaType())
+		if f.required {
+			w.WriteString("\t\t\tRequired: true,\n")
+		} else {
+			w.WriteString("\t\t\tOptional: true,\n")
+		}
+		switch f.kind {
+		case kindEnum:
+			if len(f.enum) > 0 {
+				imp.validation = true
+				quoted := make([]string, 0, len(f.enum))
+				for _, m := range f.enum {
+					quoted = append(quoted, strconv.Quote(m))
+				}
+				sort.Strings(quoted)
+				fmt.Fprintf(w, "\t\t\tValidateFunc: validation.StringInSlice([]string{%s}, false),\n", strings.Join(quoted, ", "))
+			}
+		case kindJSON:
+			imp.validation = true
+			imp.utils = true
+			w.WriteString("\t\t\tValidateFunc: validation.StringIsJSON,\n\t\t\tDiffSuppressFunc: utils.DiffJsonSuppress,\n")
+		case kindObject:
+			fmt.Fprintf(w, "\t\t\tMaxItems: 1,\n\t\t\tElem: &schema.Resource{\n\t\t\t\tSchema: %sSchema(),\n\t\t\t},\n", lowerFirst(f.object.goName))
+		case kindList:
+			if f.object != nil {
+				fmt.Fprintf(w, "\t\t\tElem: &schema.Resource{\n\t\t\t\tSchema: %sSchema(),\n\t\t\t},\n", lowerFirst(f.object.goName))
+			} else {
+				fmt.Fprintf(w, "\t\t\tElem: &schema.Schema{\n\t\t\t\tType: %s,\n\t\t\t},\n", f.elem.schemaType())
+			}
+		}
+		w.WriteString("\t\t},\n")
+	}
+	w.WriteString("\t}\n}\n")
+}
+
+func (g *generator) renderExpand(w *bytes.Buffer, o *object, imp *imports) {
+	fmt.Fprintf(w, "\n// Builds the model from the attributes, the zero values of the optional attributes are left out\n")
+	fmt.Fprintf(w, "func expand%s(m map[string]interface{}) (*%s, error) {\n\tv := %s{}\n", o.goName, o.goName, o.goName)
+	for _, f := range o.fields {
+		attr := strconv.Quote(f.attr)
+		switch f.kind {
+		case kindJSON:
+			imp.json = true
+			fmt.Fprintf(w, "\tif s, ok := m[%s].(string); ok && s != \"\" {\n\t\tif err := json.Unmarshal([]byte(s), &v.%s); err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t}\n", attr, f.goName)
+		case kindObject:
+			fmt.Fprintf(w, "\tif l, ok := m[%s].([]interface{}); ok && len(l) > 0 && l[0] != nil {\n", attr)
+			fmt.Fprintf(w, "\t\to, err := expand%s(l[0].(map[string]interface{}))\n\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t\tv.%s = o\n\t}\n", f.object.goName, f.goName)
+		case kindList:
+			fmt.Fprintf(w, "\tif l, ok := m[%s].([]interface{}); ok {\n\t\tfor _, e := range l {\n", attr)
+			if f.object != nil {
+				fmt.Fprintf(w, "\t\t\to, err := expand%s(e.(map[string]interface{}))\n\t\t\tif err != nil {\n\t\t\t\treturn nil, err\n\t\t\t}\n\t\t\tv.%s = append(v.%s, *o)\n", f.object.goName, f.goName, f.goName)
+			} else {
+				fmt.Fprintf(w, "\t\t\tv.%s = append(v.%s, e.(%s))\n", f.goName, f.goName, f.elem.dataType())
+			}
+			w.WriteString("\t\t}\n\t}\n")
+		default:
+			if f.required {
+				fmt.Fprintf(w, "\tv.%s, _ = m[%s].(%s)\n", f.goName, attr, f.dataType())
+			} else {
+				fmt.Fprintf(w, "\tif a, ok := m[%s].(%s); ok && %s {\n\t\tv.%s = &a\n\t}\n", attr, f.dataType(), f.zeroCheck("a"), f.goName)
+			}
+		}
+	}
+	w.WriteString("\treturn &v, nil\n}\n")
+}
+
+func (g *generator) renderFlatten(w *bytes.Buffer, o *object, imp *imports) {
+	fmt.Fprintf(w, "\n// Returns the attributes of the model as it's returned by Elasticsearch\n")
+	fmt.Fprintf(w, "func flatten%s(v *%s) (map[string]interface{}, error) {\n\tm := make(map[string]interface{})\n", o.goName, o.goName)
+	for _, f := range o.fields {
+		attr := strconv.Quote(f.attr)
+		switch f.kind {
+		case kindJSON:
+			imp.json = true
+			fmt.Fprintf(w, "\tif v.%s != nil {\n\t\tb, err := json.Marshal(v.%s)\n\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t\tm[%s] = string(b)\n\t}\n", f.goName, f.goName, attr)
+		case kindObject:
+			fmt.Fprintf(w, "\tif v.%s != nil {\n\t\to, err := flatten%s(v.%s)\n\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t\tm[%s] = []interface{}{o}\n\t}\n", f.goName, f.object.goName, f.goName, attr)
+		case kindList:
+			fmt.Fprintf(w, "\tif v.%s != nil {\n\t\tl := make([]interface{}, len(v.%s))\n", f.goName, f.goName)
+			if f.object != nil {
+				fmt.Fprintf(w, "\t\tfor i := range v.%s {\n\t\t\to, err := flatten%s(&v.%s[i])\n\t\t\tif err != nil {\n\t\t\t\treturn nil, err\n\t\t\t}\n\t\t\tl[i] = o\n\t\t}\n", f.goName, f.object.goName, f.goName)
+			} else {
+				fmt.Fprintf(w, "\t\tfor i, e := range v.%s {\n\t\t\tl[i] = e\n\t\t}\n", f.goName)
+			}
+			fmt.Fprintf(w, "\t\tm[%s] = l\n\t}\n", attr)
+		default:
+			if f.required {
+				fmt.Fprintf(w, "\tm[%s] = v.%s\n", attr, f.goName)
+			} else {
+				fmt.Fprintf(w, "\tif v.%s != nil {\n\t\tm[%s] = *v.%s\n\t}\n", f.goName, attr, f.goName)
+			}
+		}
+	}
+	w.WriteString("\treturn m, nil\n}\n")
+}
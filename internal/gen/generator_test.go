@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	s, err := loadSpec(filepath.Join("testdata", "schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(s, []string{"ingest._types.ConvertProcessor"}, "ingest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "convert_processor.go.golden")
+	if *update {
+		if err := ioutil.WriteFile(golden, src, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(src) != string(expected) {
+		t.Errorf("the generated code differs from %s, run the test with -update to review the changes:\n%s", golden, src)
+	}
+}
+
+func TestGenerateInvalidTypes(t *testing.T) {
+	s, err := loadSpec(filepath.Join("testdata", "schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"ingest._types.Missing", "ingest._types.ConvertType"} {
+		if _, err := generate(s, []string{name}, "ingest"); err == nil {
+			t.Errorf(`expected "%s" to be rejected`, name)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Generates the models, the schemas and the expand and flatten functions of the resources from the schema.json of the
+// Elasticsearch specification (https://github.com/elastic/elasticsearch-specification), so the new APIs, e.g. the
+// ingest processors, the transforms or the watches, don't have to be written by hand. For example:
+//
+//	go run ./internal/gen -spec schema.json -package ingest -type ingest._types.CsvProcessor -out internal/elasticsearch/ingest/processor_csv_gen.go
+//
+// The types are referenced by their namespace and name in the specification, several types are separated by commas.
+// The properties which cannot be described by the schema, e.g. the dictionaries, the unions and the containers,
+// are JSON strings. The generated code is the starting point of the resource, which wires it into its CRUD functions.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "Path to the schema.json of the Elasticsearch specification")
+	types := flag.String("type", "", "Comma-separated types to generate, e.g. ingest._types.CsvProcessor")
+	pkg := flag.String("package", "", "Package of the generated code")
+	out := flag.String("out", "", "File to write the generated code to, the standard output by default")
+	flag.Parse()
+
+	if err := run(*specPath, *types, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, types, pkg, out string) error {
+	if specPath == "" || types == "" || pkg == "" {
+		return fmt.Errorf("-spec, -type and -package are required")
+	}
+	s, err := loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+	src, err := generate(s, strings.Split(types, ","), pkg)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
+
+// Returns the generated code of the types and of the types they reference
+func generate(s *spec, types []string, pkg string) ([]byte, error) {
+	g := newGenerator(s)
+	for _, t := range types {
+		if _, err := g.add(strings.TrimSpace(t)); err != nil {
+			return nil, err
+		}
+	}
+	return g.render(pkg)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// The subset of the schema.json of the Elasticsearch specification used by the generator,
+// see https://github.com/elastic/elasticsearch-specification/tree/main/output/schema
+type specModel struct {
+	Types []specType `json:"types"`
+}
+
+type specTypeName struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (n specTypeName) String() string {
+	return n.Namespace + "." + n.Name
+}
+
+type specType struct {
+	Kind        string         `json:"kind"`
+	Name        specTypeName   `json:"name"`
+	Description string         `json:"description"`
+	Properties  []specProperty `json:"properties"`
+	Inherits    *struct {
+		Type specTypeName `json:"type"`
+	} `json:"inherits"`
+	Variants *struct {
+		Kind string `json:"kind"`
+	} `json:"variants"`
+	// the members of the enums
+	Members []struct {
+		Name string `json:"name"`
+	} `json:"members"`
+	// the aliased type of the type aliases
+	Type *specValueOf `json:"type"`
+}
+
+type specProperty struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Required    bool        `json:"required"`
+	Type        specValueOf `json:"type"`
+}
+
+// The type of the property, one of `instance_of`, `array_of`, `union_of`, `dictionary_of`, `user_defined_value` and `literal_value`
+type specValueOf struct {
+	Kind string       `json:"kind"`
+	Type specTypeName `json:"type"`
+	// the element of the arrays, or the value of the literals
+	Value json.RawMessage `json:"value"`
+	Items []specValueOf   `json:"items"`
+}
+
+type spec struct {
+	types map[string]*specType
+}
+
+func loadSpec(path string) (*spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseSpec(data)
+}
+
+func parseSpec(data []byte) (*spec, error) {
+	var model specModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("unable to parse the specification: %w", err)
+	}
+	s := &spec{types: make(map[string]*specType, len(model.Types))}
+	for i := range model.Types {
+		t := &model.Types[i]
+		s.types[t.Name.String()] = t
+	}
+	return s, nil
+}
+
+// Returns the type by its full name, e.g. `ingest._types.CsvProcessor`
+func (s *spec) lookup(name string) (*specType, error) {
+	t, ok := s.types[name]
+	if !ok {
+		return nil, fmt.Errorf(`the type "%s" is not defined in the specification`, name)
+	}
+	return t, nil
+}
+
+// Returns the properties of the type together with the inherited ones, the inherited properties first
+func (s *spec) properties(t *specType) ([]specProperty, error) {
+	props := make([]specProperty, 0)
+	if t.Inherits != nil {
+		parent, err := s.lookup(t.Inherits.Type.String())
+		if err != nil {
+			return nil, err
+		}
+		inherited, err := s.properties(parent)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, inherited...)
+	}
+	return append(props, t.Properties...), nil
+}
+
+// The numeric types of the specification, which are all aliases of the `number` builtin
+var specIntegerTypes = map[string]bool{"byte": true, "short": true, "integer": true, "long": true, "uint": true, "ulong": true}
+var specFloatTypes = map[string]bool{"float": true, "double": true}
+
+// Resolves the type of the property into the kind of the attribute
+func (s *spec) resolve(v specValueOf) (fieldKind, *specValueOf, error) {
+	switch v.Kind {
+	case "instance_of":
+		if v.Type.Namespace == "_builtins" {
+			switch v.Type.Name {
+			case "string":
+				return kindString, nil, nil
+			case "boolean":
+				return kindBool, nil, nil
+			case "number":
+				return kindFloat, nil, nil
+			}
+			return kindJSON, nil, nil
+		}
+		if specIntegerTypes[v.Type.Name] {
+			return kindInt, nil, nil
+		}
+		if specFloatTypes[v.Type.Name] {
+			return kindFloat, nil, nil
+		}
+		t, err := s.lookup(v.Type.String())
+		if err != nil {
+			return 0, nil, err
+		}
+		switch t.Kind {
+		case "type_alias":
+			if t.Type == nil {
+				return kindJSON, nil, nil
+			}
+			return s.resolve(*t.Type)
+		case "enum":
+			return kindEnum, nil, nil
+		case "interface":
+			// the containers hold one of their variants, e.g. the processors, so they are kept as JSON
+			if t.Variants != nil {
+				return kindJSON, nil, nil
+			}
+			return kindObject, &v, nil
+		}
+		return kindJSON, nil, nil
+	case "array_of":
+		var elem specValueOf
+		if err := json.Unmarshal(v.Value, &elem); err != nil {
+			return 0, nil, fmt.Errorf("invalid array: %w", err)
+		}
+		return kindList, &elem, nil
+	case "union_of":
+		// the unions of the scalar types accepting the strings, e.g. the durations, are strings
+		hasString := false
+		for _, item := range v.Items {
+			// the literal values are the special values of the scalar types, e.g. `-1` of the durations
+			if item.Kind == "literal_value" {
+				continue
+			}
+			kind, _, err := s.resolve(item)
+			if err != nil {
+				return 0, nil, err
+			}
+			switch kind {
+			case kindString, kindEnum:
+				hasString = true
+			case kindInt, kindFloat, kindBool:
+			default:
+				return kindJSON, nil, nil
+			}
+		}
+		if hasString {
+			return kindString, nil, nil
+		}
+		return kindJSON, nil, nil
+	}
+	return kindJSON, nil, nil
+}
+
+// Returns the members of the enum referenced by the property
+func (s *spec) enumMembers(v specValueOf) []string {
+	for v.Kind == "instance_of" {
+		t, ok := s.types[v.Type.String()]
+		if !ok {
+			return nil
+		}
+		if t.Kind == "enum" {
+			members := make([]string, 0, len(t.Members))
+			for _, m := range t.Members {
+				members = append(members, m.Name)
+			}
+			return members
+		}
+		if t.Kind != "type_alias" || t.Type == nil {
+			return nil
+		}
+		v = *t.Type
+	}
+	return nil
+}
+
+// Returns the first sentence of the description, the way the descriptions of the attributes are written
+func firstSentence(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	if i := strings.Index(description, ". "); i >= 0 {
+		description = description[:i+1]
+	}
+	if description != "" && !strings.HasSuffix(description, ".") {
+		description += "."
+	}
+	return description
+}
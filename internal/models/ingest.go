@@ -8,6 +8,21 @@ type IngestPipeline struct {
 	Metadata    map[string]interface{}   `json:"_meta,omitempty"`
 }
 
+type GeoipDatabase struct {
+	Id                 string                `json:"-"`
+	Name               string                `json:"name"`
+	Maxmind            *GeoipDatabaseMaxmind `json:"maxmind,omitempty"`
+	Ipinfo             *GeoipDatabaseIpinfo  `json:"ipinfo,omitempty"`
+	Version            int64                 `json:"-"`
+	ModifiedDateMillis int64                 `json:"-"`
+}
+
+type GeoipDatabaseMaxmind struct {
+	AccountId string `json:"account_id"`
+}
+
+type GeoipDatabaseIpinfo struct{}
+
 type CommonProcessor struct {
 	Description   string                   `json:"description,omitempty"`
 	If            string                   `json:"if,omitempty"`
@@ -158,6 +173,7 @@ type ProcessorForeach struct {
 }
 
 type ProcessorGeoip struct {
+	CommonProcessor
 	ProcessortFields
 
 	DatabaseFile string   `json:"database_file,omitempty"`
@@ -328,6 +344,7 @@ type ProcessorUriParts struct {
 }
 
 type ProcessorUserAgent struct {
+	CommonProcessor
 	ProcessortFields
 
 	RegexFile         string   `json:"regex_file,omitempty"`
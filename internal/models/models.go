@@ -1,5 +1,7 @@
 package models
 
+import "encoding/json"
+
 type User struct {
 	Username     string                 `json:"-"`
 	FullName     string                 `json:"full_name,omitempty"`
@@ -19,6 +21,43 @@ type Role struct {
 	Indices      []IndexPerms           `json:"indices,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 	RusAs        []string               `json:"run_as,omitempty"`
+	Restriction  *RoleRestriction       `json:"restriction,omitempty"`
+}
+
+type BuiltinPrivileges struct {
+	Cluster       []string `json:"cluster"`
+	Index         []string `json:"index"`
+	RemoteCluster []string `json:"remote_cluster,omitempty"`
+}
+
+type RoleRestriction struct {
+	Workflows []string `json:"workflows"`
+}
+
+type ApiKey struct {
+	Id          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Creation    int64                  `json:"creation"`
+	Expiration  int64                  `json:"expiration,omitempty"`
+	Invalidated bool                   `json:"invalidated"`
+	Username    string                 `json:"username"`
+	Realm       string                 `json:"realm"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	// the sort values of the API key returned by the query, used to fetch the next page
+	Sort []interface{} `json:"_sort,omitempty"`
+}
+
+type UserProfile struct {
+	Uid  string          `json:"uid"`
+	User UserProfileUser `json:"user"`
+}
+
+type UserProfileUser struct {
+	Username  string   `json:"username"`
+	Roles     []string `json:"roles"`
+	RealmName string   `json:"realm_name"`
+	Email     *string  `json:"email,omitempty"`
+	FullName  *string  `json:"full_name,omitempty"`
 }
 
 type IndexPerms struct {
@@ -50,6 +89,9 @@ type IndexTemplate struct {
 	Priority      *int                   `json:"priority,omitempty"`
 	Template      *Template              `json:"template,omitempty"`
 	Version       *int                   `json:"version,omitempty"`
+
+	IgnoreMissingComponentTemplates []string `json:"ignore_missing_component_templates,omitempty"`
+	Deprecated                      bool     `json:"deprecated,omitempty"`
 }
 
 type DataStreamSettings struct {
@@ -58,9 +100,10 @@ type DataStreamSettings struct {
 }
 
 type Template struct {
-	Aliases  map[string]IndexAlias  `json:"aliases,omitempty"`
-	Mappings map[string]interface{} `json:"mappings,omitempty"`
-	Settings map[string]interface{} `json:"settings,omitempty"`
+	Aliases   map[string]IndexAlias  `json:"aliases,omitempty"`
+	Mappings  map[string]interface{} `json:"mappings,omitempty"`
+	Settings  map[string]interface{} `json:"settings,omitempty"`
+	Lifecycle *DataStreamLifecycle   `json:"lifecycle,omitempty"`
 }
 
 type IndexTemplatesResponse struct {
@@ -72,6 +115,16 @@ type IndexTemplateResponse struct {
 	IndexTemplate IndexTemplate `json:"index_template"`
 }
 
+type SimulatedIndexTemplate struct {
+	Template    *Template                  `json:"template"`
+	Overlapping []OverlappingIndexTemplate `json:"overlapping"`
+}
+
+type OverlappingIndexTemplate struct {
+	Name          string   `json:"name"`
+	IndexPatterns []string `json:"index_patterns"`
+}
+
 type ComponentTemplate struct {
 	Name     string                 `json:"-"`
 	Meta     map[string]interface{} `json:"_meta,omitempty"`
@@ -106,6 +159,16 @@ type Phase struct {
 
 type Action map[string]interface{}
 
+type IlmExplain struct {
+	Index               string `json:"index"`
+	Managed             bool   `json:"managed"`
+	Policy              string `json:"policy,omitempty"`
+	LifecycleDateMillis int64  `json:"lifecycle_date_millis,omitempty"`
+	Phase               string `json:"phase,omitempty"`
+	Action              string `json:"action,omitempty"`
+	Step                string `json:"step,omitempty"`
+}
+
 type SnapshotRepository struct {
 	Name     string                 `json:"-"`
 	Type     string                 `json:"type"`
@@ -122,6 +185,19 @@ type SnapshotPolicy struct {
 	Schedule   string                `json:"schedule"`
 }
 
+type SnapshotPolicyInfo struct {
+	Policy              SnapshotPolicy            `json:"policy"`
+	LastSuccess         *SnapshotPolicyInvocation `json:"last_success,omitempty"`
+	LastFailure         *SnapshotPolicyInvocation `json:"last_failure,omitempty"`
+	NextExecutionMillis int64                     `json:"next_execution_millis"`
+}
+
+type SnapshotPolicyInvocation struct {
+	SnapshotName string `json:"snapshot_name"`
+	Time         int64  `json:"time"`
+	Details      string `json:"details,omitempty"`
+}
+
 type SnapshortRetention struct {
 	ExpireAfter *string `json:"expire_after,omitempty"`
 	MaxCount    *int    `json:"max_count,omitempty"`
@@ -155,6 +231,19 @@ type IndexAlias struct {
 	SearchRouting string                 `json:"search_routing,omitempty"`
 }
 
+// The index as listed by the cat indices API, the numbers are returned as strings
+type IndexInfo struct {
+	Health           string `json:"health"`
+	Status           string `json:"status"`
+	Index            string `json:"index"`
+	Uuid             string `json:"uuid"`
+	Primaries        string `json:"pri"`
+	Replicas         string `json:"rep"`
+	DocsCount        string `json:"docs.count"`
+	StoreSize        string `json:"store.size"`
+	PrimaryStoreSize string `json:"pri.store.size"`
+}
+
 type DataStream struct {
 	Name           string                 `json:"name"`
 	TimestampField TimestampField         `json:"timestamp_field"`
@@ -167,6 +256,12 @@ type DataStream struct {
 	Hidden         bool                   `json:"hidden"`
 	System         bool                   `json:"system"`
 	Replicated     bool                   `json:"replicated"`
+	Lifecycle      *DataStreamLifecycle   `json:"lifecycle,omitempty"`
+}
+
+type DataStreamLifecycle struct {
+	Enabled       *bool  `json:"enabled,omitempty"`
+	DataRetention string `json:"data_retention,omitempty"`
 }
 
 type DataStreamIndex struct {
@@ -177,3 +272,385 @@ type DataStreamIndex struct {
 type TimestampField struct {
 	Name string `json:"name"`
 }
+
+type SearchApplication struct {
+	Name                    string                 `json:"name"`
+	Indices                 []string               `json:"indices"`
+	AnalyticsCollectionName string                 `json:"analytics_collection_name,omitempty"`
+	Template                map[string]interface{} `json:"template,omitempty"`
+	UpdatedAtMillis         int64                  `json:"updated_at_millis,omitempty"`
+}
+
+type AnalyticsCollection struct {
+	Name            string `json:"-"`
+	EventDataStream struct {
+		Name string `json:"name"`
+	} `json:"event_data_stream"`
+}
+
+type CrossClusterApiKey struct {
+	Id          string                   `json:"-"`
+	Name        string                   `json:"name,omitempty"`
+	Expiration  string                   `json:"expiration,omitempty"`
+	Access      CrossClusterApiKeyAccess `json:"access"`
+	Metadata    map[string]interface{}   `json:"metadata,omitempty"`
+	Invalidated bool                     `json:"-"`
+}
+
+type CrossClusterApiKeyAccess struct {
+	Search      []CrossClusterApiKeyIndices `json:"search,omitempty"`
+	Replication []CrossClusterApiKeyIndices `json:"replication,omitempty"`
+}
+
+type CrossClusterApiKeyIndices struct {
+	Names                  []string       `json:"names"`
+	Query                  *string        `json:"query,omitempty"`
+	FieldSecurity          *FieldSecurity `json:"field_security,omitempty"`
+	AllowRestrictedIndices *bool          `json:"allow_restricted_indices,omitempty"`
+}
+
+type CreatedApiKey struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Expiration int64  `json:"expiration,omitempty"`
+	ApiKey     string `json:"api_key"`
+	Encoded    string `json:"encoded"`
+}
+
+type ApiKeyInvalidation struct {
+	Ids       []string `json:"ids,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	RealmName string   `json:"realm_name,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	Owner     bool     `json:"owner,omitempty"`
+}
+
+type ApiKeyInvalidationResponse struct {
+	InvalidatedApiKeys           []string                 `json:"invalidated_api_keys"`
+	PreviouslyInvalidatedApiKeys []string                 `json:"previously_invalidated_api_keys"`
+	ErrorCount                   int                      `json:"error_count"`
+	ErrorDetails                 []map[string]interface{} `json:"error_details,omitempty"`
+}
+
+type RemoteClusterInfo struct {
+	Connected                bool     `json:"connected"`
+	Mode                     string   `json:"mode"`
+	Seeds                    []string `json:"seeds,omitempty"`
+	ProxyAddress             string   `json:"proxy_address,omitempty"`
+	SkipUnavailable          bool     `json:"skip_unavailable"`
+	ClusterCredentials       string   `json:"cluster_credentials,omitempty"`
+	NumNodesConnected        int      `json:"num_nodes_connected,omitempty"`
+	NumProxySocketsConnected int      `json:"num_proxy_sockets_connected,omitempty"`
+}
+
+type VotingConfigExclusion struct {
+	NodeId   string `json:"node_id"`
+	NodeName string `json:"node_name"`
+}
+
+type NodeAttribute struct {
+	NodeId   string `json:"id"`
+	NodeName string `json:"node"`
+	Name     string `json:"attr"`
+	Value    string `json:"value"`
+}
+
+type NodesInfoResponse struct {
+	Nodes map[string]NodeInfo `json:"nodes"`
+}
+
+type NodeInfo struct {
+	Name     string                 `json:"name"`
+	Roles    []string               `json:"roles"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+type ReloadSecureSettingsResponse struct {
+	Nodes map[string]ReloadSecureSettingsNode `json:"nodes"`
+}
+
+type ReloadSecureSettingsNode struct {
+	Name            string                 `json:"name"`
+	ReloadException map[string]interface{} `json:"reload_exception,omitempty"`
+}
+
+type License struct {
+	Uid                string `json:"uid"`
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	IssueDate          string `json:"issue_date"`
+	ExpiryDate         string `json:"expiry_date"`
+	ExpiryDateInMillis int64  `json:"expiry_date_in_millis"`
+	MaxNodes           *int   `json:"max_nodes"`
+	MaxResourceUnits   *int   `json:"max_resource_units"`
+	IssuedTo           string `json:"issued_to"`
+	Issuer             string `json:"issuer"`
+}
+
+type LicenseResponse struct {
+	Acknowledged  bool                   `json:"acknowledged"`
+	LicenseStatus string                 `json:"license_status"`
+	Acknowledge   map[string]interface{} `json:"acknowledge"`
+}
+
+type NodeShutdown struct {
+	NodeId          string                      `json:"node_id,omitempty"`
+	Type            string                      `json:"type"`
+	Reason          string                      `json:"reason"`
+	AllocationDelay string                      `json:"allocation_delay,omitempty"`
+	TargetNodeName  string                      `json:"target_node_name,omitempty"`
+	Status          string                      `json:"status,omitempty"`
+	ShardMigration  *NodeShutdownShardMigration `json:"shard_migration,omitempty"`
+}
+
+type NodeShutdownShardMigration struct {
+	Status          string `json:"status"`
+	ShardsRemaining int64  `json:"shard_migrations_remaining"`
+}
+
+type DesiredNodes struct {
+	HistoryId string        `json:"history_id"`
+	Version   int64         `json:"version"`
+	Nodes     []DesiredNode `json:"nodes"`
+}
+
+type DesiredNode struct {
+	Settings        map[string]interface{} `json:"settings"`
+	Processors      *float64               `json:"processors,omitempty"`
+	ProcessorsRange *DesiredNodeProcessors `json:"processors_range,omitempty"`
+	Memory          string                 `json:"memory"`
+	Storage         string                 `json:"storage"`
+	NodeVersion     string                 `json:"node_version,omitempty"`
+}
+
+type DesiredNodeProcessors struct {
+	Min float64  `json:"min"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+type SynonymsSet struct {
+	Id    string        `json:"-"`
+	Rules []SynonymRule `json:"synonyms_set"`
+}
+
+type SynonymRule struct {
+	Id       string `json:"id,omitempty"`
+	Synonyms string `json:"synonyms"`
+}
+
+type SynonymsUpdateResult struct {
+	Result                 string `json:"result"`
+	ReloadAnalyzersDetails struct {
+		ReloadDetails []struct {
+			Index             string   `json:"index"`
+			ReloadedAnalyzers []string `json:"reloaded_analyzers"`
+			ReloadedNodeIds   []string `json:"reloaded_node_ids"`
+		} `json:"reload_details"`
+	} `json:"reload_analyzers_details"`
+}
+
+type QueryRuleset struct {
+	Id    string      `json:"ruleset_id,omitempty"`
+	Rules []QueryRule `json:"rules"`
+}
+
+type QueryRule struct {
+	Id       string              `json:"rule_id"`
+	Type     string              `json:"type"`
+	Criteria []QueryRuleCriteria `json:"criteria"`
+	Actions  QueryRuleActions    `json:"actions"`
+}
+
+type QueryRuleCriteria struct {
+	Type     string        `json:"type"`
+	Metadata string        `json:"metadata,omitempty"`
+	Values   []interface{} `json:"values,omitempty"`
+}
+
+type QueryRuleActions struct {
+	Ids  []string             `json:"ids,omitempty"`
+	Docs []QueryRuleActionDoc `json:"docs,omitempty"`
+}
+
+type QueryRuleActionDoc struct {
+	Index string `json:"_index"`
+	Id    string `json:"_id"`
+}
+
+type Connector struct {
+	Id            string                            `json:"id,omitempty"`
+	IndexName     *string                           `json:"index_name"`
+	Name          string                            `json:"name,omitempty"`
+	Description   string                            `json:"description,omitempty"`
+	ServiceType   string                            `json:"service_type,omitempty"`
+	IsNative      bool                              `json:"is_native"`
+	Language      string                            `json:"language,omitempty"`
+	Status        string                            `json:"status,omitempty"`
+	Scheduling    *ConnectorScheduling              `json:"scheduling,omitempty"`
+	Pipeline      *ConnectorPipeline                `json:"pipeline,omitempty"`
+	Configuration map[string]ConnectorConfiguration `json:"configuration,omitempty"`
+}
+
+type ConnectorScheduling struct {
+	Full          ConnectorSchedule `json:"full"`
+	Incremental   ConnectorSchedule `json:"incremental"`
+	AccessControl ConnectorSchedule `json:"access_control"`
+}
+
+type ConnectorSchedule struct {
+	Enabled  bool   `json:"enabled"`
+	Interval string `json:"interval"`
+}
+
+type ConnectorPipeline struct {
+	Name                 string `json:"name"`
+	ExtractBinaryContent bool   `json:"extract_binary_content"`
+	ReduceWhitespace     bool   `json:"reduce_whitespace"`
+	RunMlInference       bool   `json:"run_ml_inference"`
+}
+
+type ConnectorConfiguration struct {
+	Value     interface{} `json:"value"`
+	Sensitive bool        `json:"sensitive"`
+}
+
+type ConnectorSyncJob struct {
+	Id            string `json:"id"`
+	JobType       string `json:"job_type"`
+	TriggerMethod string `json:"trigger_method"`
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+	Connector     struct {
+		Id string `json:"id"`
+	} `json:"connector"`
+	IndexedDocumentCount int64 `json:"indexed_document_count"`
+	DeletedDocumentCount int64 `json:"deleted_document_count"`
+}
+
+type Reindex struct {
+	Source    ReindexSource  `json:"source"`
+	Dest      ReindexDest    `json:"dest"`
+	Script    *ReindexScript `json:"script,omitempty"`
+	Conflicts string         `json:"conflicts,omitempty"`
+	MaxDocs   int            `json:"max_docs,omitempty"`
+}
+
+type ReindexSource struct {
+	Index []string               `json:"index"`
+	Query map[string]interface{} `json:"query,omitempty"`
+}
+
+type ReindexDest struct {
+	Index    string `json:"index"`
+	OpType   string `json:"op_type,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+type ReindexScript struct {
+	Source string                 `json:"source"`
+	Lang   string                 `json:"lang,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+type Rollover struct {
+	Conditions map[string]interface{} `json:"conditions,omitempty"`
+}
+
+type RolloverResponse struct {
+	OldIndex   string          `json:"old_index"`
+	NewIndex   string          `json:"new_index"`
+	RolledOver bool            `json:"rolled_over"`
+	DryRun     bool            `json:"dry_run"`
+	Conditions map[string]bool `json:"conditions"`
+}
+
+type ReindexStatus struct {
+	Total            int64                    `json:"total"`
+	Created          int64                    `json:"created"`
+	Updated          int64                    `json:"updated"`
+	Deleted          int64                    `json:"deleted"`
+	VersionConflicts int64                    `json:"version_conflicts"`
+	Noops            int64                    `json:"noops"`
+	Failures         []map[string]interface{} `json:"failures,omitempty"`
+}
+
+// The running task as listed by the task management API
+type TaskInfo struct {
+	Node               string            `json:"node"`
+	NodeName           string            `json:"-"`
+	Id                 int64             `json:"id"`
+	Type               string            `json:"type"`
+	Action             string            `json:"action"`
+	Description        string            `json:"description"`
+	StartTimeInMillis  int64             `json:"start_time_in_millis"`
+	RunningTimeInNanos int64             `json:"running_time_in_nanos"`
+	Cancellable        bool              `json:"cancellable"`
+	Cancelled          bool              `json:"cancelled"`
+	ParentTaskId       string            `json:"parent_task_id,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+}
+
+type TasksResponse struct {
+	Nodes map[string]struct {
+		Name  string              `json:"name"`
+		Tasks map[string]TaskInfo `json:"tasks"`
+	} `json:"nodes"`
+	NodeFailures []map[string]interface{} `json:"node_failures,omitempty"`
+	TaskFailures []map[string]interface{} `json:"task_failures,omitempty"`
+}
+
+type Task struct {
+	Completed bool `json:"completed"`
+	Task      struct {
+		Node        string          `json:"node"`
+		Id          int64           `json:"id"`
+		Action      string          `json:"action"`
+		Description string          `json:"description"`
+		Status      json.RawMessage `json:"status,omitempty"`
+	} `json:"task"`
+	Response json.RawMessage        `json:"response,omitempty"`
+	Error    map[string]interface{} `json:"error,omitempty"`
+}
+
+type ClusterHealth struct {
+	ClusterName         string `json:"cluster_name"`
+	Status              string `json:"status"`
+	TimedOut            bool   `json:"timed_out"`
+	ActivePrimaryShards int    `json:"active_primary_shards"`
+	ActiveShards        int    `json:"active_shards"`
+	RelocatingShards    int    `json:"relocating_shards"`
+	InitializingShards  int    `json:"initializing_shards"`
+	UnassignedShards    int    `json:"unassigned_shards"`
+}
+
+type MlCalendar struct {
+	CalendarId  string   `json:"calendar_id"`
+	JobIds      []string `json:"job_ids"`
+	Description string   `json:"description,omitempty"`
+}
+
+type MlCalendarEvent struct {
+	EventId     string `json:"event_id,omitempty"`
+	CalendarId  string `json:"calendar_id,omitempty"`
+	Description string `json:"description"`
+	StartTime   int64  `json:"start_time"`
+	EndTime     int64  `json:"end_time"`
+}
+
+type DeprecationsResponse struct {
+	ClusterSettings []Deprecation            `json:"cluster_settings"`
+	NodeSettings    []Deprecation            `json:"node_settings"`
+	MlSettings      []Deprecation            `json:"ml_settings"`
+	IndexSettings   map[string][]Deprecation `json:"index_settings"`
+	DataStreams     map[string][]Deprecation `json:"data_streams,omitempty"`
+	Templates       map[string][]Deprecation `json:"templates,omitempty"`
+	IlmPolicies     map[string][]Deprecation `json:"ilm_policies,omitempty"`
+}
+
+type Deprecation struct {
+	Level                       string `json:"level"`
+	Message                     string `json:"message"`
+	Url                         string `json:"url"`
+	Details                     string `json:"details,omitempty"`
+	ResolveDuringRollingUpgrade bool   `json:"resolve_during_rolling_upgrade"`
+}
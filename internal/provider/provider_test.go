@@ -1,6 +1,7 @@
 package provider_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -11,3 +12,24 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestProviderResourceTimeouts(t *testing.T) {
+	for name, r := range acctest.Provider.ResourcesMap {
+		if r.Timeouts == nil || r.Timeouts.Create == nil || r.Timeouts.Read == nil || r.Timeouts.Update == nil || r.Timeouts.Delete == nil {
+			t.Errorf("the resource %s must define the timeouts of all the operations", name)
+		}
+	}
+}
+
+func TestProviderIngestProcessorCommonFields(t *testing.T) {
+	for name, r := range acctest.Provider.DataSourcesMap {
+		if !strings.HasPrefix(name, "elasticstack_elasticsearch_ingest_processor_") {
+			continue
+		}
+		for _, field := range []string{"description", "if", "ignore_failure", "on_failure", "tag"} {
+			if _, ok := r.Schema[field]; !ok {
+				t.Errorf("the processor %s must support the %s field", name, field)
+			}
+		}
+	}
+}
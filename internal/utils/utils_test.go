@@ -1,7 +1,13 @@
 package utils_test
 
 import (
+	"io"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 )
@@ -84,3 +90,204 @@ func TestDiffIndexTemplateSuppress(t *testing.T) {
 		}
 	}
 }
+
+func TestStringIsElasticDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"10m", true},
+		{"1d", true},
+		{"500ms", true},
+		{"30micros", true},
+		{"", true},
+		{"10", false},
+		{"10 m", false},
+		{"1w", false},
+		{"-1h", false},
+	}
+
+	for _, tc := range tests {
+		if _, errs := utils.StringIsElasticDuration(tc.value, "test"); (len(errs) == 0) != tc.valid {
+			t.Errorf("Failed for test case: %+v, errors: %v", tc, errs)
+		}
+	}
+}
+
+func TestStringIsCronExpression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"0 30 1 * * ?", true},
+		{"0 0 12 ? * MON-FRI 2030", true},
+		{"", true},
+		{"30 1 * * *", false},
+		{"0 0 0 1 1 ? 2030 extra", false},
+	}
+
+	for _, tc := range tests {
+		if _, errs := utils.StringIsCronExpression(tc.value, "test"); (len(errs) == 0) != tc.valid {
+			t.Errorf("Failed for test case: %+v, errors: %v", tc, errs)
+		}
+	}
+}
+
+func TestCheckError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		detail string
+	}{
+		{
+			"structured error with causes and request",
+			400,
+			http.Header{utils.RequestPathHeader: []string{"PUT /_ilm/policy/test"}},
+			`{"error":{"root_cause":[{"type":"x_content_parse_exception","reason":"[1:42] unknown field [foo]"}],"type":"x_content_parse_exception","reason":"[1:42] [put_lifecycle_request] failed to parse field [policy]","caused_by":{"type":"illegal_argument_exception","reason":"unknown field [foo]","caused_by":{"type":"parse_exception","reason":"bar"}}},"status":400}`,
+			"Failed with: x_content_parse_exception: [1:42] [put_lifecycle_request] failed to parse field [policy]\n" +
+				"Root causes:\n  - x_content_parse_exception: [1:42] unknown field [foo]\n" +
+				"Caused by:\n  - illegal_argument_exception: unknown field [foo]\n  - parse_exception: bar\n" +
+				"Request: PUT /_ilm/policy/test\n" +
+				"Status: 400",
+		},
+		{
+			"string error",
+			404,
+			nil,
+			`{"error":"alias [test] missing","status":404}`,
+			"Failed with: alias [test] missing\nStatus: 404",
+		},
+		{
+			"unparsable body",
+			500,
+			nil,
+			`Internal Server Error`,
+			"Failed with: Internal Server Error\nStatus: 500",
+		},
+	}
+
+	for _, tc := range tests {
+		res := &esapi.Response{StatusCode: tc.status, Header: tc.header, Body: io.NopCloser(strings.NewReader(tc.body))}
+		diags := utils.CheckError(res, "Unable to perform the request")
+		if len(diags) != 1 {
+			t.Fatalf("%s: expected one diagnostic, got %d", tc.name, len(diags))
+		}
+		if diags[0].Summary != "Unable to perform the request" {
+			t.Errorf("%s: unexpected summary: %s", tc.name, diags[0].Summary)
+		}
+		if diags[0].Detail != tc.detail {
+			t.Errorf("%s: expected detail:\n%s\ngot:\n%s", tc.name, tc.detail, diags[0].Detail)
+		}
+	}
+
+	res := &esapi.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{}`))}
+	if diags := utils.CheckError(res, "Unable to perform the request"); diags.HasError() {
+		t.Errorf("expected no diagnostics for successful response, got %v", diags)
+	}
+}
+
+func TestResponseWarnings(t *testing.T) {
+	t.Parallel()
+
+	res := &esapi.Response{StatusCode: 200, Header: http.Header{"Warning": []string{
+		`299 Elasticsearch-8.12.0-abc "index template [logs] is deprecated, use \"logs@template\" instead" "Mon, 01 Jan 2024 00:00:00 GMT"`,
+		`unstructured warning`,
+	}}}
+	diags := utils.ResponseWarnings(res, "Elasticsearch warned")
+	if len(diags) != 2 {
+		t.Fatalf("expected two diagnostics, got %v", diags)
+	}
+	if diags.HasError() {
+		t.Errorf("expected warnings, got %v", diags)
+	}
+	if expected := `index template [logs] is deprecated, use "logs@template" instead`; diags[0].Detail != expected {
+		t.Errorf("expected the message %q, got %q", expected, diags[0].Detail)
+	}
+	if diags[1].Detail != "unstructured warning" {
+		t.Errorf("expected the unstructured warning as is, got %q", diags[1].Detail)
+	}
+
+	if diags := utils.ResponseWarnings(&esapi.Response{StatusCode: 200}, "Elasticsearch warned"); len(diags) != 0 {
+		t.Errorf("expected no diagnostics without warnings, got %v", diags)
+	}
+}
+
+func TestRedactSensitiveJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{
+			`{"username":"elastic","password":"s3cr\"et","roles":["superuser"]}`,
+			`{"username":"elastic","password":"[REDACTED]","roles":["superuser"]}`,
+		},
+		{
+			`{"id":"VuaCfGcBCdbkQm-e5aOx","name":"my-api-key","api_key":"ui2lp2axTNmsyakw9tvNnw","encoded":"VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw=="}`,
+			`{"id":"VuaCfGcBCdbkQm-e5aOx","name":"my-api-key","api_key":"[REDACTED]","encoded":"[REDACTED]"}`,
+		},
+		{
+			"{\"index\":{}}\n{\"password_hash\" : \"$2a$10$abc\"}\n",
+			"{\"index\":{}}\n{\"password_hash\" : \"[REDACTED]\"}\n",
+		},
+		{
+			`{"license":{"uid":"893361dc-9749-4997-93cb-802e3d7fa4xx","type":"platinum","signature":"AAAAAwAAAA2lWbo"}}`,
+			`{"license":{"uid":"893361dc-9749-4997-93cb-802e3d7fa4xx","type":"platinum","signature":"[REDACTED]"}}`,
+		},
+		{
+			`{"values":{"host":"db.local","secret_key":"abc","client_secret":"def","token":"ghi","bearer_token":"jkl"}}`,
+			`{"values":{"host":"db.local","secret_key":"[REDACTED]","client_secret":"[REDACTED]","token":"[REDACTED]","bearer_token":"[REDACTED]"}}`,
+		},
+		{
+			`{"configuration":{"db_pass":{"label":"Password","sensitive":true,"validations":[{"type":"regex","constraint":".+"}],"value":"s3cret"},"host":{"sensitive":false,"value":"db.local"}}}`,
+			`{"configuration":{"db_pass":{"label":"Password","sensitive":true,"validations":[{"type":"regex","constraint":".+"}],"value":"[REDACTED]"},"host":{"sensitive":false,"value":"db.local"}}}`,
+		},
+		{
+			`{"settings":{"index.number_of_replicas":"1","analysis":{"analyzer":{"a":{"tokenizer":"standard"}}}}}`,
+			`{"settings":{"index.number_of_replicas":"1","analysis":{"analyzer":{"a":{"tokenizer":"standard"}}}}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		if got := utils.RedactSensitiveJSON(tc.in); got != tc.out {
+			t.Errorf("expected %s, got %s", tc.out, got)
+		}
+	}
+}
+
+func TestParseElasticDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		out     time.Duration
+		isError bool
+	}{
+		{"90d", 90 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"30s", 30 * time.Second, false},
+		{"500ms", 500 * time.Millisecond, false},
+		{"10micros", 10 * time.Microsecond, false},
+		{"1w", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tc := range tests {
+		out, err := utils.ParseElasticDuration(tc.in)
+		if (err != nil) != tc.isError {
+			t.Errorf("%s: unexpected error: %v", tc.in, err)
+		}
+		if out != tc.out {
+			t.Errorf("%s: expected %v, got %v", tc.in, tc.out, out)
+		}
+	}
+}
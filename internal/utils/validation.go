@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeValueRe = regexp.MustCompile(`^(\d+)(d|h|m|s|ms|micros|nanos)$`)
+
+var timeUnits = map[string]time.Duration{
+	"d":      24 * time.Hour,
+	"h":      time.Hour,
+	"m":      time.Minute,
+	"s":      time.Second,
+	"ms":     time.Millisecond,
+	"micros": time.Microsecond,
+	"nanos":  time.Nanosecond,
+}
+
+// Converts the Elasticsearch time value, e.g. "90d" or "12h", into the time.Duration
+func ParseElasticDuration(v string) (time.Duration, error) {
+	parts := timeValueRe.FindStringSubmatch(v)
+	if parts == nil {
+		return 0, fmt.Errorf(`invalid time value: "%s"`, v)
+	}
+	n, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n) * timeUnits[parts[2]], nil
+}
+
+// Validates that the provided string is a valid Elasticsearch time value, e.g. "10m" or "1d".
+// See, https://www.elastic.co/guide/en/elasticsearch/reference/current/api-conventions.html#time-units
+func StringIsElasticDuration(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+	if v == "" {
+		return
+	}
+	if !timeValueRe.MatchString(v) {
+		errors = append(errors, fmt.Errorf(`%q contains an invalid time value: "%s", it must be a number followed by one of the time units: d, h, m, s, ms, micros, nanos`, k, v))
+	}
+	return
+}
+
+// Validates that the provided string is an Elasticsearch cron expression, which consists of 6 or 7 space separated fields:
+// <seconds> <minutes> <hours> <day_of_month> <month> <day_of_week> [year].
+// See, https://www.elastic.co/guide/en/elasticsearch/reference/current/trigger-schedule.html#schedule-cron
+func StringIsCronExpression(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+	if v == "" {
+		return
+	}
+	fields := strings.Fields(v)
+	if len(fields) < 6 || len(fields) > 7 {
+		errors = append(errors, fmt.Errorf(`%q contains an invalid cron expression: "%s", expected 6 or 7 fields (seconds, minutes, hours, day of month, month, day of week and optional year), got %d`, k, v, len(fields)))
+	}
+	return
+}